@@ -0,0 +1,132 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/config"
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/repository"
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/service"
+)
+
+// stringsFlag は同じフラグを繰り返し指定した値を順に保持する。
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringsFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+// openAPIKeyRepository は API キーのファイルを開く。読めない場合は起動を中止する。
+func openAPIKeyRepository(cfg *config.Config) *repository.InMemoryAPIKeyRepository {
+	apiKeyRepository := repository.NewInMemoryAPIKeyRepository()
+	if err := apiKeyRepository.OpenJSONFile(cfg.APIKeyFilePath); err != nil {
+		log.Fatalf("Failed to load API keys: %v", err)
+	}
+	return apiKeyRepository
+}
+
+// runAPIKey は API キーを発行・一覧・失効する。
+// 実行中のサーバーは次のリクエストで鍵のファイルを読み直すため、停止せずに実行できる。
+func runAPIKey(args []string) {
+	usage := func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s api-key <create|list|revoke> [flags]\n", os.Args[0])
+	}
+	if len(args) == 0 {
+		usage()
+		os.Exit(2)
+	}
+
+	switch args[0] {
+	case "create":
+		runAPIKeyCreate(args[1:])
+	case "list":
+		runAPIKeyList(args[1:])
+	case "revoke":
+		runAPIKeyRevoke(args[1:])
+	default:
+		usage()
+		os.Exit(2)
+	}
+}
+
+func runAPIKeyCreate(args []string) {
+	fs := flag.NewFlagSet("api-key create", flag.ExitOnError)
+	flags := registerConfigFlags(fs)
+	name := fs.String("name", "", "鍵の用途を表す名前")
+	scope := fs.String("scope", string(entity.API_KEY_SCOPE_READ_ONLY), fmt.Sprintf("鍵のスコープ (%s または %s)", entity.API_KEY_SCOPE_READ_ONLY, entity.API_KEY_SCOPE_READ_WRITE))
+	var accounts stringsFlag
+	fs.Var(&accounts, "account", fmt.Sprintf("鍵で扱えるアカウント。繰り返し指定でき、%q はすべてのアカウント", entity.APIKeyAllAccounts))
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s api-key create -name <name> -account <account> [flags]\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	cfg := flags.load()
+	apiKeyRepository := openAPIKeyRepository(cfg)
+	apiKeyService := service.NewAPIKeyService(apiKeyRepository, apiKeyRepository, apiKeyRepository)
+
+	created, err := apiKeyService.CreateAPIKey(service.CreateAPIKeyCommand{
+		Name:     *name,
+		Scope:    *scope,
+		Accounts: accounts,
+	})
+	if err != nil {
+		log.Fatalf("Failed to create API key: %v", err)
+	}
+	log.Printf("Created API key %s (%s, scope=%s, accounts=%s)", created.ID, created.Name, created.Scope, strings.Join(created.Accounts, ","))
+	log.Printf("The key is shown only once. Store it securely.")
+	fmt.Println(created.Key)
+}
+
+func runAPIKeyList(args []string) {
+	fs := flag.NewFlagSet("api-key list", flag.ExitOnError)
+	flags := registerConfigFlags(fs)
+	fs.Parse(args)
+
+	cfg := flags.load()
+	apiKeyRepository := openAPIKeyRepository(cfg)
+	apiKeys, err := service.NewAPIKeyService(apiKeyRepository, apiKeyRepository, apiKeyRepository).GetAPIKeys()
+	if err != nil {
+		log.Fatalf("Failed to list API keys: %v", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tSCOPE\tACCOUNTS\tCREATED")
+	for _, apiKey := range apiKeys {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", apiKey.ID, apiKey.Name, apiKey.Scope, strings.Join(apiKey.Accounts, ","), apiKey.CreatedAt.Format(time.RFC3339))
+	}
+	w.Flush()
+}
+
+func runAPIKeyRevoke(args []string) {
+	fs := flag.NewFlagSet("api-key revoke", flag.ExitOnError)
+	flags := registerConfigFlags(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s api-key revoke [flags] <id>\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	cfg := flags.load()
+	apiKeyRepository := openAPIKeyRepository(cfg)
+	if err := service.NewAPIKeyService(apiKeyRepository, apiKeyRepository, apiKeyRepository).RevokeAPIKey(fs.Arg(0)); err != nil {
+		log.Fatalf("Failed to revoke API key: %v", err)
+	}
+	log.Printf("Revoked API key %s", fs.Arg(0))
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/repository"
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/service"
+)
+
+// runImportGOOD は GOOD 形式のファイルをデータファイルに取り込む。
+// サーバーと同じデータファイルを書き換えるため、サーバーを停止してから実行すること。
+func runImportGOOD(args []string) {
+	fs := flag.NewFlagSet("import-good", flag.ExitOnError)
+	flags := registerConfigFlags(fs)
+	account := fs.String("account", repository.DefaultAccount, "取り込み先のアカウント")
+	onDuplicate := fs.String("on-duplicate", string(service.DUPLICATE_POLICY_WARN), "内容が同じ聖遺物がある場合の扱い (warn, reject, allow)")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s import-good [flags] <file>\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	data, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		log.Fatalf("Failed to read GOOD file: %v", err)
+	}
+
+	cfg := flags.load()
+	accounts := openAccountRepositories(cfg, cfg.Storage.Backend)
+	artifactRepository, err := accounts.GetAccountArtifacts(*account)
+	if err != nil {
+		log.Fatalf("Failed to open account: %v", err)
+	}
+
+	result, err := service.NewImportService(artifactRepository, artifactRepository).ImportGOOD(data, service.DuplicatePolicy(*onDuplicate))
+	if err != nil {
+		log.Fatalf("Failed to import GOOD file: %v", err)
+	}
+	for _, rejection := range result.Rejections {
+		log.Printf("Rejected artifacts[%d]: %s: %s", rejection.Index, rejection.Code, rejection.Reason)
+	}
+	for _, duplicate := range result.Duplicates {
+		log.Printf("Duplicate artifacts[%d]: %s has the same content as %s", duplicate.Index, duplicate.ID, strings.Join(duplicate.DuplicateOf, ", "))
+	}
+
+	if err := accounts.Compact(); err != nil {
+		log.Fatalf("Failed to save artifacts: %v", err)
+	}
+	if err := accounts.Close(); err != nil {
+		log.Printf("Warning: Failed to close data file: %v", err)
+	}
+	log.Printf("Imported %d artifacts into account %s, rejected %d", result.Imported, *account, result.Rejected)
+}
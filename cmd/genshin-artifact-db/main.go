@@ -1,11 +1,15 @@
 package main
 
 import (
+	"errors"
 	"flag"
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
+	"time"
 
 	"github.com/YutoOkawa/genshin-artifact-db/pkg/config"
 	"github.com/YutoOkawa/genshin-artifact-db/pkg/handler"
@@ -16,40 +20,230 @@ import (
 )
 
 func main() {
-	configPath := flag.String("config", config.DefaultConfigPath, "設定ファイルのパス")
-	portFlag := flag.String("port", "", "サーバーポート (設定ファイルを上書き)")
-	dataFlag := flag.String("data", "", "データファイルパス (設定ファイルを上書き)")
-	flag.Parse()
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "import-good":
+			runImportGOOD(os.Args[2:])
+			return
+		case "migrate-kv":
+			runMigrateKV(os.Args[2:])
+			return
+		case "api-key":
+			runAPIKey(os.Args[2:])
+			return
+		}
+	}
+	runServer(os.Args[1:])
+}
+
+// configFlags はサブコマンド共通の設定ファイルとデータファイルのフラグ。
+type configFlags struct {
+	configPath *string
+	dataPath   *string
+	walPath    *string
+}
 
-	cfg, err := config.LoadConfig(*configPath)
+func registerConfigFlags(fs *flag.FlagSet) *configFlags {
+	return &configFlags{
+		configPath: fs.String("config", config.DefaultConfigPath, "設定ファイルのパス"),
+		dataPath:   fs.String("data", "", "データファイルパス (設定ファイルを上書き)"),
+		walPath:    fs.String("wal", "", "先行書き込みログのパス (設定ファイルを上書き)"),
+	}
+}
+
+func (f *configFlags) load() *config.Config {
+	cfg, err := config.LoadConfig(*f.configPath)
 	if err != nil {
 		log.Fatalf("Failed to load config: %v", err)
 	}
+	if *f.dataPath != "" {
+		cfg.DataFilePath = *f.dataPath
+	}
+	if *f.walPath != "" {
+		cfg.WALFilePath = *f.walPath
+	}
+	return cfg
+}
 
+// jsonArtifactStore はスナップショットの書き出し先を保持し、InMemoryArtifactRepository を repository.ArtifactStore に合わせる。
+type jsonArtifactStore struct {
+	*repository.InMemoryArtifactRepository
+	dataFilePath string
+}
+
+func (s *jsonArtifactStore) Compact() error {
+	return s.InMemoryArtifactRepository.Compact(s.dataFilePath)
+}
+
+func (s *jsonArtifactStore) Close() error {
+	return s.CloseWAL()
+}
+
+// openJSONArtifactStore はデータファイルとスナップショット以降の先行書き込みログから聖遺物を復元する。
+// データファイルが読めない場合はエラーを返す。空のまま開くと次の Compact で既存データを上書きしてしまうため。
+func openJSONArtifactStore(dataFilePath, walFilePath string, backupCount int) (*jsonArtifactStore, error) {
+	artifactRepository := repository.NewInMemoryArtifactRepository()
+	artifactRepository.BackupCount = backupCount
+	loadedFrom, err := artifactRepository.RestoreJSONFile(dataFilePath)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		log.Printf("No data file found, starting with empty database: %s", dataFilePath)
+	case err != nil:
+		return nil, fmt.Errorf("failed to load data file: %w", err)
+	case loadedFrom != dataFilePath:
+		log.Printf("Warning: Data file is corrupted, restored from backup: %s", loadedFrom)
+	}
+	if err := artifactRepository.OpenWAL(walFilePath); err != nil {
+		return nil, fmt.Errorf("failed to open write-ahead log: %w", err)
+	}
+	return &jsonArtifactStore{
+		InMemoryArtifactRepository: artifactRepository,
+		dataFilePath:               dataFilePath,
+	}, nil
+}
+
+// artifactStoreOpener は backend の保存先でアカウントの聖遺物リポジトリを開く。
+// 既定のアカウントは設定ファイルのパスを、それ以外のアカウントはアカウントのディレクトリにある同名のファイルを使う。
+func artifactStoreOpener(cfg *config.Config, backend string) repository.ArtifactStoreOpener {
+	return func(account, dir string) (repository.ArtifactStore, error) {
+		accountPath := func(path string) string {
+			if account == repository.DefaultAccount {
+				return path
+			}
+			return filepath.Join(dir, filepath.Base(path))
+		}
+
+		if backend == config.StorageBackendKV {
+			return repository.OpenKVArtifactRepository(accountPath(cfg.Storage.KVFilePath))
+		}
+		return openJSONArtifactStore(accountPath(cfg.DataFilePath), accountPath(cfg.WALFilePath), cfg.BackupCount)
+	}
+}
+
+// openAccountRepositories は既定のアカウントのリポジトリを開いた状態でアカウントの一覧を返す。
+// 既定のアカウントのデータが読めない場合は起動を中止する。
+func openAccountRepositories(cfg *config.Config, backend string) *repository.AccountArtifactRepositories {
+	accounts := repository.NewAccountArtifactRepositories(cfg.AccountsDir, artifactStoreOpener(cfg, backend))
+	if _, err := accounts.GetAccountArtifacts(repository.DefaultAccount); err != nil {
+		log.Fatalf("Failed to open artifacts: %v", err)
+	}
+	return accounts
+}
+
+func runServer(args []string) {
+	fs := flag.NewFlagSet("genshin-artifact-db", flag.ExitOnError)
+	flags := registerConfigFlags(fs)
+	portFlag := fs.String("port", "", "サーバーポート (設定ファイルを上書き)")
+	fs.Parse(args)
+
+	cfg := flags.load()
 	if *portFlag != "" {
 		cfg.Port = *portFlag
 	}
-	if *dataFlag != "" {
-		cfg.DataFilePath = *dataFlag
-	}
 
-	log.Printf("Starting server with config: port=%s, data_file=%s", cfg.Port, cfg.DataFilePath)
+	log.Printf("Starting server with config: port=%s, storage=%s, data_file=%s, wal_file=%s, kv_file=%s, accounts_dir=%s, api_key_file=%s", cfg.Port, cfg.Storage.Backend, cfg.DataFilePath, cfg.WALFilePath, cfg.Storage.KVFilePath, cfg.AccountsDir, cfg.APIKeyFilePath)
 
-	artifactRepository := repository.NewInMemoryArtifactRepository()
-	if err := artifactRepository.LoadJSONFile(cfg.DataFilePath); err != nil {
-		log.Printf("Warning: Failed to load data file: %v", err)
+	accounts := openAccountRepositories(cfg, cfg.Storage.Backend)
+
+	scoreProfileRepository := repository.NewInMemoryScoreProfileRepository()
+	if err := scoreProfileRepository.OpenJSONFile(cfg.ScoreProfileFilePath); err != nil {
+		log.Fatalf("Failed to load score profiles: %v", err)
 	}
 
-	getArtifactService := service.NewGetArtifactService(artifactRepository)
-	createArtifactService := service.NewUpdateArtifactService(artifactRepository)
+	apiKeyRepository := openAPIKeyRepository(cfg)
+
+	artifactSetService := service.NewArtifactSetService()
+	characterService := service.NewCharacterService()
+	apiKeyService := service.NewAPIKeyService(apiKeyRepository, apiKeyRepository, apiKeyRepository)
+	scoreProfileService := service.NewScoreProfileService(scoreProfileRepository, scoreProfileRepository, scoreProfileRepository)
+	accountService := service.NewAccountService(accounts, accounts, scoreProfileRepository)
+
+	idempotencyStore := handler.NewIdempotencyStore(time.Duration(cfg.IdempotencyWindowSeconds)*time.Second, handler.IdempotencyLimits{
+		MaxEntries:      cfg.IdempotencyMaxEntries,
+		MaxBytes:        cfg.IdempotencyMaxBytes,
+		MaxRequestBytes: cfg.IdempotencyMaxRequestBytes,
+	})
+
+	// forAccount はパスの :account のアカウントのサービスでハンドラーを組み立てる
+	forAccount := func(build func(s *service.AccountServices) gin.HandlerFunc) gin.HandlerFunc {
+		return handler.ForAccount(accountService, build)
+	}
 
 	r := gin.Default()
-	r.GET("/artifact/:id", handler.GetArtifact(getArtifactService))
-	r.GET("/artifacts/type/:type", handler.GetArtifactsByType(getArtifactService))
-	r.GET("/artifacts/set/:set", handler.GetArtifactsBySet(getArtifactService))
-	r.GET("/artifacts/type/:type/set/:set", handler.GetArtifacts(getArtifactService))
+	r.Use(handler.ErrorHandler())
+	r.Use(handler.Authenticate(apiKeyService, !cfg.RequireAuthForReads))
 
-	r.POST("/artifact", handler.CreateArtifact(createArtifactService))
+	r.GET("/accounts", handler.GetAccounts(accountService, cfg.PublicAccounts))
+	r.POST("/accounts", handler.RequireAllAccounts(), handler.CreateAccount(accountService))
+
+	account := r.Group("/accounts/:account", handler.AuthorizeAccount(cfg.PublicAccounts))
+	account.GET("", handler.GetAccount(accountService))
+	account.GET("/artifact/:id", forAccount(func(s *service.AccountServices) gin.HandlerFunc {
+		return handler.GetArtifact(s.GetArtifact)
+	}))
+	account.GET("/artifacts", forAccount(func(s *service.AccountServices) gin.HandlerFunc {
+		return handler.QueryArtifacts(s.GetArtifact)
+	}))
+	account.GET("/artifacts/type/:type", forAccount(func(s *service.AccountServices) gin.HandlerFunc {
+		return handler.GetArtifactsByType(s.GetArtifact)
+	}))
+	account.GET("/artifacts/set/:set", forAccount(func(s *service.AccountServices) gin.HandlerFunc {
+		return handler.GetArtifactsBySet(s.GetArtifact)
+	}))
+	account.GET("/artifacts/type/:type/set/:set", forAccount(func(s *service.AccountServices) gin.HandlerFunc {
+		return handler.GetArtifacts(s.GetArtifact)
+	}))
+
+	account.POST("/artifact", handler.Idempotency(idempotencyStore), forAccount(func(s *service.AccountServices) gin.HandlerFunc {
+		return handler.CreateArtifact(s.UpdateArtifact)
+	}))
+	account.PUT("/artifact/:id", forAccount(func(s *service.AccountServices) gin.HandlerFunc {
+		return handler.UpdateArtifact(s.UpdateArtifact)
+	}))
+	account.PATCH("/artifact/:id", forAccount(func(s *service.AccountServices) gin.HandlerFunc {
+		return handler.PatchArtifact(s.UpdateArtifact)
+	}))
+	account.DELETE("/artifact/:id", forAccount(func(s *service.AccountServices) gin.HandlerFunc {
+		return handler.DeleteArtifact(s.UpdateArtifact)
+	}))
+	account.POST("/artifacts:method", handler.Idempotency(idempotencyStore), forAccount(func(s *service.AccountServices) gin.HandlerFunc {
+		return handler.CustomMethods("method", map[string]gin.HandlerFunc{
+			":batch": handler.BatchCreateArtifacts(s.BatchArtifact),
+		})
+	}))
+
+	account.GET("/artifact/:id/score", forAccount(func(s *service.AccountServices) gin.HandlerFunc {
+		return handler.GetArtifactScore(s.Score)
+	}))
+
+	account.POST("/import/good", handler.Idempotency(idempotencyStore), forAccount(func(s *service.AccountServices) gin.HandlerFunc {
+		return handler.ImportGOOD(s.Import)
+	}))
+	account.GET("/export", forAccount(func(s *service.AccountServices) gin.HandlerFunc {
+		return handler.ExportArtifacts(s.Export)
+	}))
+
+	account.GET("/characters/:character/loadout", forAccount(func(s *service.AccountServices) gin.HandlerFunc {
+		return handler.GetLoadout(s.Equipment)
+	}))
+	account.POST("/characters/:character/loadout", handler.Idempotency(idempotencyStore), forAccount(func(s *service.AccountServices) gin.HandlerFunc {
+		return handler.EquipArtifact(s.Equipment)
+	}))
+	account.DELETE("/characters/:character/loadout/:type", forAccount(func(s *service.AccountServices) gin.HandlerFunc {
+		return handler.UnequipArtifact(s.Equipment)
+	}))
+
+	r.GET("/sets", handler.GetArtifactSets(artifactSetService))
+	r.GET("/sets/:key", handler.GetArtifactSet(artifactSetService))
+
+	r.GET("/characters", handler.GetCharacters(characterService))
+	r.GET("/characters/:key", handler.GetCharacter(characterService))
+
+	r.GET("/profiles", handler.GetScoreProfiles(scoreProfileService))
+	r.GET("/profiles/:name", handler.GetScoreProfile(scoreProfileService))
+	// スコアプロファイルはアカウント間で共有するため、変更はすべてのアカウントを扱える鍵に限る
+	r.PUT("/profiles/:name", handler.RequireAllAccounts(), handler.SaveScoreProfile(scoreProfileService))
+	r.DELETE("/profiles/:name", handler.RequireAllAccounts(), handler.DeleteScoreProfile(scoreProfileService))
 
 	serve := server.NewServer(cfg.Port, r, 1)
 	serverCh := serve.Start()
@@ -57,13 +251,23 @@ func main() {
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGTERM, syscall.SIGINT)
 
+	compactionTicker := time.NewTicker(time.Duration(cfg.CompactionIntervalSeconds) * time.Second)
+	defer compactionTicker.Stop()
+
 	for {
 		select {
+		case <-compactionTicker.C:
+			if err := accounts.Compact(); err != nil {
+				log.Printf("Warning: Failed to compact data file: %v", err)
+			}
 		case <-quit:
-			if err := artifactRepository.SaveJSONFile(cfg.DataFilePath); err != nil {
+			serve.Shutdown()
+			if err := accounts.Compact(); err != nil {
 				log.Fatalf("Failed to save artifacts: %v", err)
 			}
-			serve.Shutdown()
+			if err := accounts.Close(); err != nil {
+				log.Printf("Warning: Failed to close data file: %v", err)
+			}
 			log.Println("Server shutdown")
 			return
 		case err := <-serverCh:
@@ -74,5 +278,4 @@ func main() {
 			return
 		}
 	}
-
 }
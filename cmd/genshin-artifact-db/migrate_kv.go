@@ -0,0 +1,86 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/config"
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/repository"
+)
+
+// runMigrateKV はすべてのアカウントについて、JSON のデータファイルと先行書き込みログの聖遺物を KV のデータファイルへ移す。
+// 移行元のファイルは変更しない。既に聖遺物がある KV のデータファイルがひとつでもあれば移行しない。
+// サーバーと同じデータファイルを読み書きするため、サーバーを停止してから実行すること。
+func runMigrateKV(args []string) {
+	fs := flag.NewFlagSet("migrate-kv", flag.ExitOnError)
+	flags := registerConfigFlags(fs)
+	kvPath := fs.String("kv", "", "既定のアカウントの移行先の KV データファイルのパス (設定ファイルを上書き)")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s migrate-kv [flags]\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	cfg := flags.load()
+	if *kvPath != "" {
+		cfg.Storage.KVFilePath = *kvPath
+	}
+
+	source := openAccountRepositories(cfg, config.StorageBackendJSON)
+	defer func() {
+		if err := source.Close(); err != nil {
+			log.Printf("Warning: Failed to close write-ahead log: %v", err)
+		}
+	}()
+	destination := openAccountRepositories(cfg, config.StorageBackendKV)
+	defer func() {
+		if err := destination.Close(); err != nil {
+			log.Printf("Warning: Failed to close KV data file: %v", err)
+		}
+	}()
+
+	accounts, err := source.GetAccounts()
+	if err != nil {
+		log.Fatalf("Failed to list accounts: %v", err)
+	}
+
+	// 書き込む前にすべての移行先を確認し、一部のアカウントだけが移行された状態にならないようにする
+	artifacts := make(map[string][]*entity.Artifact, len(accounts))
+	stores := make(map[string]repository.ArtifactStore, len(accounts))
+	for _, account := range accounts {
+		from, err := source.GetAccountArtifacts(account)
+		if err != nil {
+			log.Fatalf("Failed to open account %s: %v", account, err)
+		}
+		result, err := from.QueryArtifacts(repository.ArtifactQuery{})
+		if err != nil {
+			log.Fatalf("Failed to read artifacts of account %s: %v", account, err)
+		}
+		artifacts[account] = result.Artifacts
+
+		to, err := destination.GetAccountArtifacts(account)
+		if err != nil {
+			log.Fatalf("Failed to open KV data file of account %s: %v", account, err)
+		}
+		existing, err := to.QueryArtifacts(repository.ArtifactQuery{Limit: 1})
+		if err != nil {
+			log.Fatalf("Failed to read KV data file of account %s: %v", account, err)
+		}
+		if existing.Total > 0 {
+			log.Fatalf("KV data file of account %s already contains %d artifacts", account, existing.Total)
+		}
+		stores[account] = to
+	}
+
+	// アカウントごとに 1 回の書き込みで保存し、途中で失敗しても一部だけが移行された状態にならないようにする
+	for _, account := range accounts {
+		if err := stores[account].SaveArtifacts(artifacts[account], repository.ArtifactSaveOptions{}); err != nil {
+			log.Fatalf("Failed to migrate artifacts of account %s: %v", account, err)
+		}
+		log.Printf("Migrated %d artifacts of account %s", len(artifacts[account]), account)
+	}
+	log.Printf("Set storage.backend to %q in the config file to use the migrated data", config.StorageBackendKV)
+}
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"os"
 
 	"gopkg.in/yaml.v3"
@@ -10,17 +12,90 @@ const (
 	DefaultConfigPath   = "/etc/config/genshin-artifact-db/config.yaml"
 	DefaultPort         = ":8080"
 	DefaultDataFilePath = "/var/lib/genshin-artifact-db/artifacts.json"
+	DefaultWALFilePath  = "/var/lib/genshin-artifact-db/artifacts.wal"
+
+	DefaultScoreProfileFilePath = "/var/lib/genshin-artifact-db/score_profiles.json"
+
+	DefaultCompactionIntervalSeconds = 300
+	DefaultBackupCount               = 5
+
+	DefaultKVFilePath = "/var/lib/genshin-artifact-db/artifacts.db"
+
+	DefaultAccountsDir = "/var/lib/genshin-artifact-db/accounts"
+
+	DefaultAPIKeyFilePath = "/var/lib/genshin-artifact-db/api_keys.json"
+
+	// DefaultIdempotencyWindowSeconds は Idempotency-Key ごとの応答を覚えておく期間 (24 時間)。
+	DefaultIdempotencyWindowSeconds = 86400
+	// DefaultIdempotencyMaxEntries は応答を覚えておく Idempotency-Key の数の上限。
+	DefaultIdempotencyMaxEntries = 10000
+	// DefaultIdempotencyMaxBytes は覚えておく応答のボディの合計の上限 (64 MiB)。
+	DefaultIdempotencyMaxBytes = 64 << 20
+	// DefaultIdempotencyMaxRequestBytes は Idempotency-Key 付きのリクエストボディの上限 (10 MiB)。
+	DefaultIdempotencyMaxRequestBytes = 10 << 20
 )
 
+// StorageBackendJSON は聖遺物をメモリに保持し、JSON のスナップショットと先行書き込みログで永続化する。
+const StorageBackendJSON = "json"
+
+// StorageBackendKV は聖遺物を組み込みのキーバリューストア (bbolt) のファイルに保存する。
+const StorageBackendKV = "kv"
+
+var ErrUnknownStorageBackend = errors.New("unknown storage backend")
+
+type StorageConfig struct {
+	// Backend が空の場合は json として扱う
+	Backend    string `yaml:"backend"`
+	KVFilePath string `yaml:"kv_file_path"`
+}
+
 type Config struct {
-	Port         string `yaml:"port"`
-	DataFilePath string `yaml:"data_file_path"`
+	Port                      string `yaml:"port"`
+	DataFilePath              string `yaml:"data_file_path"`
+	WALFilePath               string `yaml:"wal_file_path"`
+	CompactionIntervalSeconds int    `yaml:"compaction_interval_seconds"`
+	// BackupCount はスナップショットのバックアップを残す世代数。負の値でバックアップを無効化する。
+	BackupCount          int    `yaml:"backup_count"`
+	ScoreProfileFilePath string `yaml:"score_profile_file_path"`
+	// IdempotencyWindowSeconds は同じ Idempotency-Key の再送に保存済みの応答を返す期間。
+	IdempotencyWindowSeconds int `yaml:"idempotency_window_seconds"`
+	// IdempotencyMaxEntries と IdempotencyMaxBytes は覚えておく応答の数とボディの合計の上限で、
+	// 超える場合は期間内でも古いキーから忘れる。
+	IdempotencyMaxEntries int `yaml:"idempotency_max_entries"`
+	IdempotencyMaxBytes   int `yaml:"idempotency_max_bytes"`
+	// IdempotencyMaxRequestBytes は Idempotency-Key 付きのリクエストボディの上限で、超えると 413 を返す。
+	IdempotencyMaxRequestBytes int64         `yaml:"idempotency_max_request_bytes"`
+	Storage                    StorageConfig `yaml:"storage"`
+	// AccountsDir は既定以外のアカウントのデータを置くディレクトリ。
+	// 既定のアカウントは DataFilePath などのファイルをそのまま使う。
+	AccountsDir string `yaml:"accounts_dir"`
+	// APIKeyFilePath は API キーのハッシュを保存するファイル。
+	APIKeyFilePath string `yaml:"api_key_file_path"`
+	// RequireAuthForReads が false の場合、API キーのない読み取りのリクエストも受け付ける。
+	// ただしアカウントのデータの読み取りは PublicAccounts のアカウントに限る。
+	RequireAuthForReads bool `yaml:"require_auth_for_reads"`
+	// PublicAccounts は API キーなしで読み取れるアカウント。空の場合はどのアカウントも API キーが必要。
+	PublicAccounts []string `yaml:"public_accounts"`
 }
 
 func DefaultConfig() *Config {
 	return &Config{
-		Port:         DefaultPort,
-		DataFilePath: DefaultDataFilePath,
+		Port:                       DefaultPort,
+		DataFilePath:               DefaultDataFilePath,
+		WALFilePath:                DefaultWALFilePath,
+		CompactionIntervalSeconds:  DefaultCompactionIntervalSeconds,
+		BackupCount:                DefaultBackupCount,
+		ScoreProfileFilePath:       DefaultScoreProfileFilePath,
+		IdempotencyWindowSeconds:   DefaultIdempotencyWindowSeconds,
+		IdempotencyMaxEntries:      DefaultIdempotencyMaxEntries,
+		IdempotencyMaxBytes:        DefaultIdempotencyMaxBytes,
+		IdempotencyMaxRequestBytes: DefaultIdempotencyMaxRequestBytes,
+		Storage: StorageConfig{
+			Backend:    StorageBackendJSON,
+			KVFilePath: DefaultKVFilePath,
+		},
+		AccountsDir:    DefaultAccountsDir,
+		APIKeyFilePath: DefaultAPIKeyFilePath,
 	}
 }
 
@@ -45,6 +120,46 @@ func LoadConfig(path string) (*Config, error) {
 	if cfg.DataFilePath == "" {
 		cfg.DataFilePath = DefaultDataFilePath
 	}
+	if cfg.WALFilePath == "" {
+		cfg.WALFilePath = DefaultWALFilePath
+	}
+	if cfg.CompactionIntervalSeconds <= 0 {
+		cfg.CompactionIntervalSeconds = DefaultCompactionIntervalSeconds
+	}
+	if cfg.BackupCount == 0 {
+		cfg.BackupCount = DefaultBackupCount
+	}
+	if cfg.ScoreProfileFilePath == "" {
+		cfg.ScoreProfileFilePath = DefaultScoreProfileFilePath
+	}
+	if cfg.IdempotencyWindowSeconds <= 0 {
+		cfg.IdempotencyWindowSeconds = DefaultIdempotencyWindowSeconds
+	}
+	if cfg.IdempotencyMaxEntries <= 0 {
+		cfg.IdempotencyMaxEntries = DefaultIdempotencyMaxEntries
+	}
+	if cfg.IdempotencyMaxBytes <= 0 {
+		cfg.IdempotencyMaxBytes = DefaultIdempotencyMaxBytes
+	}
+	if cfg.IdempotencyMaxRequestBytes <= 0 {
+		cfg.IdempotencyMaxRequestBytes = DefaultIdempotencyMaxRequestBytes
+	}
+	switch cfg.Storage.Backend {
+	case StorageBackendJSON, StorageBackendKV:
+	case "":
+		cfg.Storage.Backend = StorageBackendJSON
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownStorageBackend, cfg.Storage.Backend)
+	}
+	if cfg.Storage.KVFilePath == "" {
+		cfg.Storage.KVFilePath = DefaultKVFilePath
+	}
+	if cfg.AccountsDir == "" {
+		cfg.AccountsDir = DefaultAccountsDir
+	}
+	if cfg.APIKeyFilePath == "" {
+		cfg.APIKeyFilePath = DefaultAPIKeyFilePath
+	}
 
 	return cfg, nil
 }
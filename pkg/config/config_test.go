@@ -18,6 +18,30 @@ func TestDefaultConfig(t *testing.T) {
 	if cfg.DataFilePath != DefaultDataFilePath {
 		t.Errorf("expected data file path %s, got %s", DefaultDataFilePath, cfg.DataFilePath)
 	}
+
+	if cfg.WALFilePath != DefaultWALFilePath {
+		t.Errorf("expected WAL file path %s, got %s", DefaultWALFilePath, cfg.WALFilePath)
+	}
+
+	if cfg.CompactionIntervalSeconds != DefaultCompactionIntervalSeconds {
+		t.Errorf("expected compaction interval %d, got %d", DefaultCompactionIntervalSeconds, cfg.CompactionIntervalSeconds)
+	}
+
+	if cfg.BackupCount != DefaultBackupCount {
+		t.Errorf("expected backup count %d, got %d", DefaultBackupCount, cfg.BackupCount)
+	}
+
+	if cfg.ScoreProfileFilePath != DefaultScoreProfileFilePath {
+		t.Errorf("expected score profile file path %s, got %s", DefaultScoreProfileFilePath, cfg.ScoreProfileFilePath)
+	}
+
+	if cfg.IdempotencyWindowSeconds != DefaultIdempotencyWindowSeconds {
+		t.Errorf("expected idempotency window %d, got %d", DefaultIdempotencyWindowSeconds, cfg.IdempotencyWindowSeconds)
+	}
+
+	if cfg.Storage.Backend != StorageBackendJSON {
+		t.Errorf("expected storage backend %s, got %s", StorageBackendJSON, cfg.Storage.Backend)
+	}
 }
 
 func TestLoadConfig(t *testing.T) {
@@ -33,8 +57,22 @@ func TestLoadConfig(t *testing.T) {
 data_file_path: "/custom/path/data.json"
 `,
 			expectedConfig: &Config{
-				Port:         ":9090",
-				DataFilePath: "/custom/path/data.json",
+				Port:                       ":9090",
+				DataFilePath:               "/custom/path/data.json",
+				WALFilePath:                DefaultWALFilePath,
+				CompactionIntervalSeconds:  DefaultCompactionIntervalSeconds,
+				BackupCount:                DefaultBackupCount,
+				ScoreProfileFilePath:       DefaultScoreProfileFilePath,
+				IdempotencyWindowSeconds:   DefaultIdempotencyWindowSeconds,
+				IdempotencyMaxEntries:      DefaultIdempotencyMaxEntries,
+				IdempotencyMaxBytes:        DefaultIdempotencyMaxBytes,
+				IdempotencyMaxRequestBytes: DefaultIdempotencyMaxRequestBytes,
+				Storage: StorageConfig{
+					Backend:    StorageBackendJSON,
+					KVFilePath: DefaultKVFilePath,
+				},
+				AccountsDir:    DefaultAccountsDir,
+				APIKeyFilePath: DefaultAPIKeyFilePath,
 			},
 			expectError: false,
 		},
@@ -44,8 +82,22 @@ data_file_path: "/custom/path/data.json"
 data_file_path: ""
 `,
 			expectedConfig: &Config{
-				Port:         DefaultPort,
-				DataFilePath: DefaultDataFilePath,
+				Port:                       DefaultPort,
+				DataFilePath:               DefaultDataFilePath,
+				WALFilePath:                DefaultWALFilePath,
+				CompactionIntervalSeconds:  DefaultCompactionIntervalSeconds,
+				BackupCount:                DefaultBackupCount,
+				ScoreProfileFilePath:       DefaultScoreProfileFilePath,
+				IdempotencyWindowSeconds:   DefaultIdempotencyWindowSeconds,
+				IdempotencyMaxEntries:      DefaultIdempotencyMaxEntries,
+				IdempotencyMaxBytes:        DefaultIdempotencyMaxBytes,
+				IdempotencyMaxRequestBytes: DefaultIdempotencyMaxRequestBytes,
+				Storage: StorageConfig{
+					Backend:    StorageBackendJSON,
+					KVFilePath: DefaultKVFilePath,
+				},
+				AccountsDir:    DefaultAccountsDir,
+				APIKeyFilePath: DefaultAPIKeyFilePath,
 			},
 			expectError: false,
 		},
@@ -53,8 +105,22 @@ data_file_path: ""
 			name:          "ShouldUseDefaultConfigForEmptyFile",
 			configContent: "",
 			expectedConfig: &Config{
-				Port:         DefaultPort,
-				DataFilePath: DefaultDataFilePath,
+				Port:                       DefaultPort,
+				DataFilePath:               DefaultDataFilePath,
+				WALFilePath:                DefaultWALFilePath,
+				CompactionIntervalSeconds:  DefaultCompactionIntervalSeconds,
+				BackupCount:                DefaultBackupCount,
+				ScoreProfileFilePath:       DefaultScoreProfileFilePath,
+				IdempotencyWindowSeconds:   DefaultIdempotencyWindowSeconds,
+				IdempotencyMaxEntries:      DefaultIdempotencyMaxEntries,
+				IdempotencyMaxBytes:        DefaultIdempotencyMaxBytes,
+				IdempotencyMaxRequestBytes: DefaultIdempotencyMaxRequestBytes,
+				Storage: StorageConfig{
+					Backend:    StorageBackendJSON,
+					KVFilePath: DefaultKVFilePath,
+				},
+				AccountsDir:    DefaultAccountsDir,
+				APIKeyFilePath: DefaultAPIKeyFilePath,
 			},
 			expectError: false,
 		},
@@ -63,11 +129,185 @@ data_file_path: ""
 			configContent: `data_file_path: "/custom/data.json"
 `,
 			expectedConfig: &Config{
-				Port:         DefaultPort,
-				DataFilePath: "/custom/data.json",
+				Port:                       DefaultPort,
+				DataFilePath:               "/custom/data.json",
+				WALFilePath:                DefaultWALFilePath,
+				CompactionIntervalSeconds:  DefaultCompactionIntervalSeconds,
+				BackupCount:                DefaultBackupCount,
+				ScoreProfileFilePath:       DefaultScoreProfileFilePath,
+				IdempotencyWindowSeconds:   DefaultIdempotencyWindowSeconds,
+				IdempotencyMaxEntries:      DefaultIdempotencyMaxEntries,
+				IdempotencyMaxBytes:        DefaultIdempotencyMaxBytes,
+				IdempotencyMaxRequestBytes: DefaultIdempotencyMaxRequestBytes,
+				Storage: StorageConfig{
+					Backend:    StorageBackendJSON,
+					KVFilePath: DefaultKVFilePath,
+				},
+				AccountsDir:    DefaultAccountsDir,
+				APIKeyFilePath: DefaultAPIKeyFilePath,
 			},
 			expectError: false,
 		},
+		{
+			name: "ShouldLoadWALConfigSuccessfully",
+			configContent: `wal_file_path: "/custom/data.wal"
+compaction_interval_seconds: 60
+`,
+			expectedConfig: &Config{
+				Port:                       DefaultPort,
+				DataFilePath:               DefaultDataFilePath,
+				WALFilePath:                "/custom/data.wal",
+				CompactionIntervalSeconds:  60,
+				BackupCount:                DefaultBackupCount,
+				ScoreProfileFilePath:       DefaultScoreProfileFilePath,
+				IdempotencyWindowSeconds:   DefaultIdempotencyWindowSeconds,
+				IdempotencyMaxEntries:      DefaultIdempotencyMaxEntries,
+				IdempotencyMaxBytes:        DefaultIdempotencyMaxBytes,
+				IdempotencyMaxRequestBytes: DefaultIdempotencyMaxRequestBytes,
+				Storage: StorageConfig{
+					Backend:    StorageBackendJSON,
+					KVFilePath: DefaultKVFilePath,
+				},
+				AccountsDir:    DefaultAccountsDir,
+				APIKeyFilePath: DefaultAPIKeyFilePath,
+			},
+			expectError: false,
+		},
+		{
+			name: "ShouldLoadBackupCountSuccessfully",
+			configContent: `backup_count: -1
+`,
+			expectedConfig: &Config{
+				Port:                       DefaultPort,
+				DataFilePath:               DefaultDataFilePath,
+				WALFilePath:                DefaultWALFilePath,
+				CompactionIntervalSeconds:  DefaultCompactionIntervalSeconds,
+				BackupCount:                -1,
+				ScoreProfileFilePath:       DefaultScoreProfileFilePath,
+				IdempotencyWindowSeconds:   DefaultIdempotencyWindowSeconds,
+				IdempotencyMaxEntries:      DefaultIdempotencyMaxEntries,
+				IdempotencyMaxBytes:        DefaultIdempotencyMaxBytes,
+				IdempotencyMaxRequestBytes: DefaultIdempotencyMaxRequestBytes,
+				Storage: StorageConfig{
+					Backend:    StorageBackendJSON,
+					KVFilePath: DefaultKVFilePath,
+				},
+				AccountsDir:    DefaultAccountsDir,
+				APIKeyFilePath: DefaultAPIKeyFilePath,
+			},
+			expectError: false,
+		},
+		{
+			name: "ShouldLoadScoreProfileFilePathSuccessfully",
+			configContent: `score_profile_file_path: "/custom/profiles.json"
+`,
+			expectedConfig: &Config{
+				Port:                       DefaultPort,
+				DataFilePath:               DefaultDataFilePath,
+				WALFilePath:                DefaultWALFilePath,
+				CompactionIntervalSeconds:  DefaultCompactionIntervalSeconds,
+				BackupCount:                DefaultBackupCount,
+				ScoreProfileFilePath:       "/custom/profiles.json",
+				IdempotencyWindowSeconds:   DefaultIdempotencyWindowSeconds,
+				IdempotencyMaxEntries:      DefaultIdempotencyMaxEntries,
+				IdempotencyMaxBytes:        DefaultIdempotencyMaxBytes,
+				IdempotencyMaxRequestBytes: DefaultIdempotencyMaxRequestBytes,
+				Storage: StorageConfig{
+					Backend:    StorageBackendJSON,
+					KVFilePath: DefaultKVFilePath,
+				},
+				AccountsDir:    DefaultAccountsDir,
+				APIKeyFilePath: DefaultAPIKeyFilePath,
+			},
+			expectError: false,
+		},
+		{
+			name: "ShouldLoadStorageConfigSuccessfully",
+			configContent: `storage:
+  backend: kv
+  kv_file_path: "/custom/artifacts.db"
+`,
+			expectedConfig: &Config{
+				Port:                       DefaultPort,
+				DataFilePath:               DefaultDataFilePath,
+				WALFilePath:                DefaultWALFilePath,
+				CompactionIntervalSeconds:  DefaultCompactionIntervalSeconds,
+				BackupCount:                DefaultBackupCount,
+				ScoreProfileFilePath:       DefaultScoreProfileFilePath,
+				IdempotencyWindowSeconds:   DefaultIdempotencyWindowSeconds,
+				IdempotencyMaxEntries:      DefaultIdempotencyMaxEntries,
+				IdempotencyMaxBytes:        DefaultIdempotencyMaxBytes,
+				IdempotencyMaxRequestBytes: DefaultIdempotencyMaxRequestBytes,
+				Storage: StorageConfig{
+					Backend:    StorageBackendKV,
+					KVFilePath: "/custom/artifacts.db",
+				},
+				AccountsDir:    DefaultAccountsDir,
+				APIKeyFilePath: DefaultAPIKeyFilePath,
+			},
+			expectError: false,
+		},
+		{
+			name: "ShouldLoadAccountsDirSuccessfully",
+			configContent: `accounts_dir: "/custom/accounts"
+`,
+			expectedConfig: &Config{
+				Port:                       DefaultPort,
+				DataFilePath:               DefaultDataFilePath,
+				WALFilePath:                DefaultWALFilePath,
+				CompactionIntervalSeconds:  DefaultCompactionIntervalSeconds,
+				BackupCount:                DefaultBackupCount,
+				ScoreProfileFilePath:       DefaultScoreProfileFilePath,
+				IdempotencyWindowSeconds:   DefaultIdempotencyWindowSeconds,
+				IdempotencyMaxEntries:      DefaultIdempotencyMaxEntries,
+				IdempotencyMaxBytes:        DefaultIdempotencyMaxBytes,
+				IdempotencyMaxRequestBytes: DefaultIdempotencyMaxRequestBytes,
+				Storage: StorageConfig{
+					Backend:    StorageBackendJSON,
+					KVFilePath: DefaultKVFilePath,
+				},
+				AccountsDir:    "/custom/accounts",
+				APIKeyFilePath: DefaultAPIKeyFilePath,
+			},
+			expectError: false,
+		},
+		{
+			name: "ShouldLoadAuthSettingsSuccessfully",
+			configContent: `api_key_file_path: "/custom/api_keys.json"
+require_auth_for_reads: true
+public_accounts:
+  - default
+`,
+			expectedConfig: &Config{
+				Port:                       DefaultPort,
+				DataFilePath:               DefaultDataFilePath,
+				WALFilePath:                DefaultWALFilePath,
+				CompactionIntervalSeconds:  DefaultCompactionIntervalSeconds,
+				BackupCount:                DefaultBackupCount,
+				ScoreProfileFilePath:       DefaultScoreProfileFilePath,
+				IdempotencyWindowSeconds:   DefaultIdempotencyWindowSeconds,
+				IdempotencyMaxEntries:      DefaultIdempotencyMaxEntries,
+				IdempotencyMaxBytes:        DefaultIdempotencyMaxBytes,
+				IdempotencyMaxRequestBytes: DefaultIdempotencyMaxRequestBytes,
+				Storage: StorageConfig{
+					Backend:    StorageBackendJSON,
+					KVFilePath: DefaultKVFilePath,
+				},
+				AccountsDir:         DefaultAccountsDir,
+				APIKeyFilePath:      "/custom/api_keys.json",
+				RequireAuthForReads: true,
+				PublicAccounts:      []string{"default"},
+			},
+			expectError: false,
+		},
+		{
+			name: "ShouldReturnErrorForUnknownStorageBackend",
+			configContent: `storage:
+  backend: sqlite
+`,
+			expectedConfig: nil,
+			expectError:    true,
+		},
 		{
 			name:           "ShouldReturnErrorForInvalidYAML",
 			configContent:  "invalid: yaml: content:",
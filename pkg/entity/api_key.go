@@ -0,0 +1,73 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+var (
+	ErrInvalidAPIKeyName     = errors.New("API key name cannot be empty")
+	ErrInvalidAPIKeyScope    = errors.New("invalid API key scope")
+	ErrInvalidAPIKeyAccounts = errors.New("API key must be allowed at least one account")
+)
+
+type APIKeyScope string
+
+const API_KEY_SCOPE_READ_ONLY APIKeyScope = "read-only"
+const API_KEY_SCOPE_READ_WRITE APIKeyScope = "read-write"
+
+// APIKeyAllAccounts を Accounts に含む API キーは、存在するかどうかにかかわらずすべてのアカウントを扱える。
+const APIKeyAllAccounts = "*"
+
+func NewAPIKeyScope(scope string) (APIKeyScope, error) {
+	switch APIKeyScope(scope) {
+	case API_KEY_SCOPE_READ_ONLY, API_KEY_SCOPE_READ_WRITE:
+		return APIKeyScope(scope), nil
+	}
+	return "", fmt.Errorf("%w: %q: must be %q or %q", ErrInvalidAPIKeyScope, scope, API_KEY_SCOPE_READ_ONLY, API_KEY_SCOPE_READ_WRITE)
+}
+
+// APIKey は API の利用者を識別する鍵。鍵そのものは保存せず、秘密部分のハッシュだけを持つ。
+type APIKey struct {
+	ID         string
+	Name       string
+	SecretHash string
+	Scope      APIKeyScope
+	Accounts   []string
+	CreatedAt  time.Time
+}
+
+func NewAPIKey(id, name, secretHash string, scope APIKeyScope, accounts []string, createdAt time.Time) (*APIKey, error) {
+	if name == "" {
+		return nil, ErrInvalidAPIKeyName
+	}
+	if _, err := NewAPIKeyScope(string(scope)); err != nil {
+		return nil, err
+	}
+	if len(accounts) == 0 {
+		return nil, ErrInvalidAPIKeyAccounts
+	}
+
+	return &APIKey{
+		ID:         id,
+		Name:       name,
+		SecretHash: secretHash,
+		Scope:      scope,
+		Accounts:   accounts,
+		CreatedAt:  createdAt,
+	}, nil
+}
+
+func (k *APIKey) CanWrite() bool {
+	return k.Scope == API_KEY_SCOPE_READ_WRITE
+}
+
+func (k *APIKey) CanAccessAllAccounts() bool {
+	return slices.Contains(k.Accounts, APIKeyAllAccounts)
+}
+
+func (k *APIKey) CanAccessAccount(account string) bool {
+	return k.CanAccessAllAccounts() || slices.Contains(k.Accounts, account)
+}
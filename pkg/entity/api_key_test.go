@@ -0,0 +1,124 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewAPIKey(t *testing.T) {
+	tests := []struct {
+		name string
+
+		// WHEN
+		keyName  string
+		scope    APIKeyScope
+		accounts []string
+
+		// THEN
+		expectedError error
+	}{
+		{
+			name: "ShouldCreateAPIKeySuccessfully",
+
+			keyName:  "scanner",
+			scope:    API_KEY_SCOPE_READ_WRITE,
+			accounts: []string{"default"},
+		},
+		{
+			name: "ShouldReturnErrorWhenNameIsEmpty",
+
+			scope:    API_KEY_SCOPE_READ_ONLY,
+			accounts: []string{"default"},
+
+			expectedError: ErrInvalidAPIKeyName,
+		},
+		{
+			name: "ShouldReturnErrorWhenScopeIsUnknown",
+
+			keyName:  "scanner",
+			scope:    "admin",
+			accounts: []string{"default"},
+
+			expectedError: ErrInvalidAPIKeyScope,
+		},
+		{
+			name: "ShouldReturnErrorWhenNoAccountIsAllowed",
+
+			keyName: "scanner",
+			scope:   API_KEY_SCOPE_READ_ONLY,
+
+			expectedError: ErrInvalidAPIKeyAccounts,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewAPIKey("id", tt.keyName, "hash", tt.scope, tt.accounts, time.Time{})
+			if !errors.Is(err, tt.expectedError) {
+				t.Errorf("expected error: %v, got: %v", tt.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestAPIKeyPermissions(t *testing.T) {
+	tests := []struct {
+		name string
+
+		// GIVEN
+		apiKey *APIKey
+
+		// WHEN
+		account string
+
+		// THEN
+		expectedCanWrite             bool
+		expectedCanAccessAccount     bool
+		expectedCanAccessAllAccounts bool
+	}{
+		{
+			name: "ShouldAllowOnlyListedAccounts",
+
+			apiKey: &APIKey{Scope: API_KEY_SCOPE_READ_WRITE, Accounts: []string{"alt"}},
+
+			account: "default",
+
+			expectedCanWrite: true,
+		},
+		{
+			name: "ShouldAllowListedAccountReadOnly",
+
+			apiKey: &APIKey{Scope: API_KEY_SCOPE_READ_ONLY, Accounts: []string{"alt", "default"}},
+
+			account: "default",
+
+			expectedCanAccessAccount: true,
+		},
+		{
+			name: "ShouldAllowAnyAccountWithWildcard",
+
+			apiKey: &APIKey{Scope: API_KEY_SCOPE_READ_WRITE, Accounts: []string{APIKeyAllAccounts}},
+
+			account: "missing",
+
+			expectedCanWrite:             true,
+			expectedCanAccessAccount:     true,
+			expectedCanAccessAllAccounts: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.apiKey.CanWrite(); got != tt.expectedCanWrite {
+				t.Errorf("CanWrite() = %v, want %v", got, tt.expectedCanWrite)
+			}
+			if got := tt.apiKey.CanAccessAccount(tt.account); got != tt.expectedCanAccessAccount {
+				t.Errorf("CanAccessAccount(%q) = %v, want %v", tt.account, got, tt.expectedCanAccessAccount)
+			}
+			if got := tt.apiKey.CanAccessAllAccounts(); got != tt.expectedCanAccessAllAccounts {
+				t.Errorf("CanAccessAllAccounts() = %v, want %v", got, tt.expectedCanAccessAllAccounts)
+			}
+		})
+	}
+}
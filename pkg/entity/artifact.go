@@ -1,6 +1,10 @@
 package entity
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
 
 var (
 	ErrInvalidArtifactID      = errors.New("artifact ID cannot be empty")
@@ -8,6 +12,10 @@ var (
 	ErrInvalidArtifactSet     = errors.New("invalid artifact set")
 	ErrInvalidPrimaryStatType = errors.New("invalid primary stat")
 	ErrInvalidSubstatType     = errors.New("invalid substat type")
+
+	ErrPrimaryStatNotAllowedForType = errors.New("primary stat is not allowed for artifact type")
+	ErrSubstatDuplicatesPrimaryStat = errors.New("substat duplicates primary stat")
+	ErrDuplicateSubstat             = errors.New("duplicate substat")
 )
 
 type ArtifactType string
@@ -18,17 +26,22 @@ const ARTIFACT_TYPE_SANDS ArtifactType = "SANDS"
 const ARTIFACT_TYPE_GOBLET ArtifactType = "GOBLET"
 const ARTIFACT_TYPE_CIRCLET ArtifactType = "CIRCLET"
 
+// ArtifactSet の正規キーと表示名・セット効果は data/artifact_sets.json のカタログで定義する。
 type ArtifactSet string
 
-const ARTIFACT_SET_GLADIATORS_FINALOFFERING ArtifactSet = "Gladiator"
-const ARTIFACT_SET_WANDERERS_TROUPE ArtifactSet = "Wanderer"
-const ARTIFACT_SET_NOBLESSE_OBLIGE ArtifactSet = "Noblesse"
-const ARTIFACT_SET_BLOODSTAINED_CHIVALRY ArtifactSet = "Bloodstained"
-const ARTIFACT_SET_MAIDENS_BELLSING ArtifactSet = "Maiden"
-const ARTIFACT_SET_VIRIDESCENT_VENERER ArtifactSet = "Vermillion"
+const ARTIFACT_SET_GLADIATORS_FINALOFFERING ArtifactSet = "GladiatorsFinale"
+const ARTIFACT_SET_WANDERERS_TROUPE ArtifactSet = "WanderersTroupe"
+const ARTIFACT_SET_NOBLESSE_OBLIGE ArtifactSet = "NoblesseOblige"
+const ARTIFACT_SET_BLOODSTAINED_CHIVALRY ArtifactSet = "BloodstainedChivalry"
+const ARTIFACT_SET_MAIDENS_BELLSING ArtifactSet = "MaidenBeloved"
+
+// 旧バージョンではこの定数の値が "Vermillion" だったため、カタログで旧キーとして登録している
+const ARTIFACT_SET_VIRIDESCENT_VENERER ArtifactSet = "ViridescentVenerer"
 
 type PrimaryStatType string
 
+const HP_FLAT PrimaryStatType = "HP_FLAT"
+const ATK_FLAT PrimaryStatType = "ATK_FLAT"
 const ATK_PERCENT PrimaryStatType = "ATK_PERCENT"
 const HP_PERCENT PrimaryStatType = "HP_PERCENT"
 const DEF_PERCENT PrimaryStatType = "DEF_PERCENT"
@@ -37,6 +50,20 @@ const CRIT_RATE PrimaryStatType = "CRIT_RATE"
 const CRIT_DMG PrimaryStatType = "CRIT_DMG"
 const ENERGY_RECHARGE PrimaryStatType = "ENERGY_RECHARGE"
 const PHYSICAL_DMG_BONUS PrimaryStatType = "PHYSICAL_DMG_BONUS"
+const PYRO_DMG_BONUS PrimaryStatType = "PYRO_DMG_BONUS"
+const HYDRO_DMG_BONUS PrimaryStatType = "HYDRO_DMG_BONUS"
+const ELECTRO_DMG_BONUS PrimaryStatType = "ELECTRO_DMG_BONUS"
+const CRYO_DMG_BONUS PrimaryStatType = "CRYO_DMG_BONUS"
+const ANEMO_DMG_BONUS PrimaryStatType = "ANEMO_DMG_BONUS"
+const GEO_DMG_BONUS PrimaryStatType = "GEO_DMG_BONUS"
+const DENDRO_DMG_BONUS PrimaryStatType = "DENDRO_DMG_BONUS"
+
+// UNKNOWN_ELEMENT_DMG_BONUS は元素の区別がなかった旧データの元素ダメージバフを表す。
+// 既存データを編集できるよう有効な値として扱うが、元素が判明したら置き換えること。
+const UNKNOWN_ELEMENT_DMG_BONUS PrimaryStatType = "UNKNOWN_ELEMENT_DMG_BONUS"
+
+// ELEMENTAL_DMG_BONUS は旧バージョンの値で、読み込み時に UNKNOWN_ELEMENT_DMG_BONUS へ移行される。
+// 新規の入力としては受け付けない。
 const ELEMENTAL_DMG_BONUS PrimaryStatType = "ELEMENTAL_DMG_BONUS"
 const HEALING_BONUS PrimaryStatType = "HEALING_BONUS"
 
@@ -48,9 +75,11 @@ type PrimaryStat struct {
 func NewPrimaryStat(statType string, value float64) (*PrimaryStat, error) {
 	statTypeEnum := PrimaryStatType(statType)
 	switch statTypeEnum {
-	case ATK_PERCENT, HP_PERCENT, DEF_PERCENT, ELEMENTAL_MASTERY,
+	case HP_FLAT, ATK_FLAT, ATK_PERCENT, HP_PERCENT, DEF_PERCENT, ELEMENTAL_MASTERY,
 		CRIT_RATE, CRIT_DMG, ENERGY_RECHARGE, PHYSICAL_DMG_BONUS,
-		ELEMENTAL_DMG_BONUS, HEALING_BONUS:
+		PYRO_DMG_BONUS, HYDRO_DMG_BONUS, ELECTRO_DMG_BONUS, CRYO_DMG_BONUS,
+		ANEMO_DMG_BONUS, GEO_DMG_BONUS, DENDRO_DMG_BONUS, UNKNOWN_ELEMENT_DMG_BONUS,
+		HEALING_BONUS:
 	default:
 		return nil, ErrInvalidPrimaryStatType
 	}
@@ -63,6 +92,9 @@ func NewPrimaryStat(statType string, value float64) (*PrimaryStat, error) {
 
 type SubstatType string
 
+const SUBSTAT_HP_FLAT SubstatType = "HP_FLAT"
+const SUBSTAT_ATK_FLAT SubstatType = "ATK_FLAT"
+const SUBSTAT_DEF_FLAT SubstatType = "DEF_FLAT"
 const SUBSTAT_ATK_PERCENT SubstatType = "ATK_PERCENT"
 const SUBSTAT_HP_PERCENT SubstatType = "HP_PERCENT"
 const SUBSTAT_DEF_PERCENT SubstatType = "DEF_PERCENT"
@@ -79,7 +111,8 @@ type Substat struct {
 func NewSubstat(substatType string, value float64) (*Substat, error) {
 	substatTypeEnum := SubstatType(substatType)
 	switch substatTypeEnum {
-	case SUBSTAT_ATK_PERCENT, SUBSTAT_HP_PERCENT, SUBSTAT_DEF_PERCENT,
+	case SUBSTAT_HP_FLAT, SUBSTAT_ATK_FLAT, SUBSTAT_DEF_FLAT,
+		SUBSTAT_ATK_PERCENT, SUBSTAT_HP_PERCENT, SUBSTAT_DEF_PERCENT,
 		SUBSTAT_ELEMENTAL_MASTERY, SUBSTAT_CRIT_RATE, SUBSTAT_CRIT_DMG,
 		SUBSTAT_ENERGY_RECHARGE:
 	default:
@@ -92,43 +125,120 @@ func NewSubstat(substatType string, value float64) (*Substat, error) {
 	}, nil
 }
 
+// primaryStatPools は部位ごとに取り得るメインステータスを定義する。
+var primaryStatPools = map[ArtifactType][]PrimaryStatType{
+	ARTIFACT_TYPE_FLOWER: {HP_FLAT},
+	ARTIFACT_TYPE_PLUME:  {ATK_FLAT},
+	ARTIFACT_TYPE_SANDS: {
+		HP_PERCENT, ATK_PERCENT, DEF_PERCENT, ELEMENTAL_MASTERY, ENERGY_RECHARGE,
+	},
+	ARTIFACT_TYPE_GOBLET: {
+		HP_PERCENT, ATK_PERCENT, DEF_PERCENT, ELEMENTAL_MASTERY,
+		PHYSICAL_DMG_BONUS, PYRO_DMG_BONUS, HYDRO_DMG_BONUS, ELECTRO_DMG_BONUS,
+		CRYO_DMG_BONUS, ANEMO_DMG_BONUS, GEO_DMG_BONUS, DENDRO_DMG_BONUS,
+		UNKNOWN_ELEMENT_DMG_BONUS,
+	},
+	ARTIFACT_TYPE_CIRCLET: {
+		HP_PERCENT, ATK_PERCENT, DEF_PERCENT, ELEMENTAL_MASTERY,
+		CRIT_RATE, CRIT_DMG, HEALING_BONUS,
+	},
+}
+
+// ParseArtifactType は部位の文字列を検証して ArtifactType に変換する。
+func ParseArtifactType(artifactType string) (ArtifactType, error) {
+	artifactTypeEnum := ArtifactType(artifactType)
+	if _, ok := primaryStatPools[artifactTypeEnum]; !ok {
+		return "", ErrInvalidArtifactType
+	}
+	return artifactTypeEnum, nil
+}
+
+// AllowedPrimaryStats は部位に応じたメインステータスの候補を返す。
+func AllowedPrimaryStats(artifactType ArtifactType) []PrimaryStatType {
+	return slices.Clone(primaryStatPools[artifactType])
+}
+
 type Artifact struct {
 	ID          string
 	ArtifactSet ArtifactSet
 	Type        ArtifactType
+	Rarity      int
 	Level       int
 	PrimaryStat PrimaryStat
 	Substats    []Substat
+
+	// Locked はゲーム内で誤って分解しないようロックされているかを表す
+	Locked bool
+	// EquippedBy は装備しているキャラクターで、装備していなければ空。
+	// ひとりのキャラクターが同じ部位の聖遺物を複数装備することはない。
+	EquippedBy CharacterKey
 }
 
-func NewArtifact(id string, artifactSet, artifactType string, level int, primaryStat PrimaryStat, substats []Substat) (*Artifact, error) {
+func NewArtifact(id string, artifactSet, artifactType string, rarity, level int, primaryStat PrimaryStat, substats []Substat) (*Artifact, error) {
 	if id == "" {
 		return nil, ErrInvalidArtifactID
 	}
 
-	artifactSetEnum := ArtifactSet(artifactSet)
-	switch artifactSetEnum {
-	case ARTIFACT_SET_GLADIATORS_FINALOFFERING, ARTIFACT_SET_WANDERERS_TROUPE,
-		ARTIFACT_SET_NOBLESSE_OBLIGE, ARTIFACT_SET_BLOODSTAINED_CHIVALRY,
-		ARTIFACT_SET_MAIDENS_BELLSING, ARTIFACT_SET_VIRIDESCENT_VENERER:
-	default:
+	artifactSetInfo, ok := LookupArtifactSet(artifactSet)
+	if !ok {
 		return nil, ErrInvalidArtifactSet
 	}
 
 	artifactTypeEnum := ArtifactType(artifactType)
-	switch artifactTypeEnum {
-	case ARTIFACT_TYPE_FLOWER, ARTIFACT_TYPE_PLUME, ARTIFACT_TYPE_SANDS,
-		ARTIFACT_TYPE_GOBLET, ARTIFACT_TYPE_CIRCLET:
-	default:
+	allowedPrimaryStats, ok := primaryStatPools[artifactTypeEnum]
+	if !ok {
 		return nil, ErrInvalidArtifactType
 	}
 
+	if !slices.Contains(allowedPrimaryStats, primaryStat.Type) {
+		return nil, fmt.Errorf("%w: %s cannot have %s", ErrPrimaryStatNotAllowedForType, artifactTypeEnum, primaryStat.Type)
+	}
+
+	seenSubstats := make(map[SubstatType]struct{}, len(substats))
+	for _, substat := range substats {
+		if string(substat.Type) == string(primaryStat.Type) {
+			return nil, fmt.Errorf("%w: %s", ErrSubstatDuplicatesPrimaryStat, substat.Type)
+		}
+		if _, exists := seenSubstats[substat.Type]; exists {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateSubstat, substat.Type)
+		}
+		seenSubstats[substat.Type] = struct{}{}
+	}
+
+	if err := validateRarityAndLevel(artifactSetInfo, rarity, level, len(substats)); err != nil {
+		return nil, err
+	}
+
+	if err := validatePrimaryStatValue(rarity, level, primaryStat); err != nil {
+		return nil, err
+	}
+
+	if _, err := DecomposeSubstats(rarity, level, substats); err != nil {
+		return nil, err
+	}
+
 	return &Artifact{
 		ID:          id,
-		ArtifactSet: artifactSetEnum,
+		ArtifactSet: artifactSetInfo.Key,
 		Type:        artifactTypeEnum,
+		Rarity:      rarity,
 		Level:       level,
 		PrimaryStat: primaryStat,
 		Substats:    substats,
 	}, nil
 }
+
+// SameContent は ID・ロック・装備キャラクターを除いて同じ聖遺物かを判定する。
+// サブステータスは並び順を区別しない。
+func (a *Artifact) SameContent(other *Artifact) bool {
+	if a.ArtifactSet != other.ArtifactSet || a.Type != other.Type || a.Rarity != other.Rarity ||
+		a.Level != other.Level || a.PrimaryStat != other.PrimaryStat || len(a.Substats) != len(other.Substats) {
+		return false
+	}
+	for _, substat := range a.Substats {
+		if !slices.Contains(other.Substats, substat) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,164 @@
+package entity
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+)
+
+// DefaultLanguage は表示名の言語が見つからない場合に使われる。
+const DefaultLanguage = "en"
+
+type SetBonusStatType string
+
+const SET_BONUS_ATK_PERCENT SetBonusStatType = "ATK_PERCENT"
+const SET_BONUS_HP_PERCENT SetBonusStatType = "HP_PERCENT"
+const SET_BONUS_DEF_PERCENT SetBonusStatType = "DEF_PERCENT"
+const SET_BONUS_HP_FLAT SetBonusStatType = "HP_FLAT"
+const SET_BONUS_DEF_FLAT SetBonusStatType = "DEF_FLAT"
+const SET_BONUS_ELEMENTAL_MASTERY SetBonusStatType = "ELEMENTAL_MASTERY"
+const SET_BONUS_CRIT_RATE SetBonusStatType = "CRIT_RATE"
+const SET_BONUS_ENERGY_RECHARGE SetBonusStatType = "ENERGY_RECHARGE"
+const SET_BONUS_HEALING_BONUS SetBonusStatType = "HEALING_BONUS"
+const SET_BONUS_INCOMING_HEALING_BONUS SetBonusStatType = "INCOMING_HEALING_BONUS"
+const SET_BONUS_SHIELD_STRENGTH SetBonusStatType = "SHIELD_STRENGTH"
+const SET_BONUS_PHYSICAL_DMG_BONUS SetBonusStatType = "PHYSICAL_DMG_BONUS"
+const SET_BONUS_PYRO_DMG_BONUS SetBonusStatType = "PYRO_DMG_BONUS"
+const SET_BONUS_HYDRO_DMG_BONUS SetBonusStatType = "HYDRO_DMG_BONUS"
+const SET_BONUS_ELECTRO_DMG_BONUS SetBonusStatType = "ELECTRO_DMG_BONUS"
+const SET_BONUS_CRYO_DMG_BONUS SetBonusStatType = "CRYO_DMG_BONUS"
+const SET_BONUS_ANEMO_DMG_BONUS SetBonusStatType = "ANEMO_DMG_BONUS"
+const SET_BONUS_GEO_DMG_BONUS SetBonusStatType = "GEO_DMG_BONUS"
+const SET_BONUS_DENDRO_DMG_BONUS SetBonusStatType = "DENDRO_DMG_BONUS"
+const SET_BONUS_PYRO_RES SetBonusStatType = "PYRO_RES"
+const SET_BONUS_ELECTRO_RES SetBonusStatType = "ELECTRO_RES"
+const SET_BONUS_ALL_ELEMENTAL_RES SetBonusStatType = "ALL_ELEMENTAL_RES"
+const SET_BONUS_NORMAL_ATTACK_DMG_BONUS SetBonusStatType = "NORMAL_ATTACK_DMG_BONUS"
+const SET_BONUS_CHARGED_ATTACK_DMG_BONUS SetBonusStatType = "CHARGED_ATTACK_DMG_BONUS"
+const SET_BONUS_PLUNGING_ATTACK_DMG_BONUS SetBonusStatType = "PLUNGING_ATTACK_DMG_BONUS"
+const SET_BONUS_ELEMENTAL_SKILL_DMG_BONUS SetBonusStatType = "ELEMENTAL_SKILL_DMG_BONUS"
+const SET_BONUS_ELEMENTAL_BURST_DMG_BONUS SetBonusStatType = "ELEMENTAL_BURST_DMG_BONUS"
+
+func (t SetBonusStatType) valid() bool {
+	switch t {
+	case SET_BONUS_ATK_PERCENT, SET_BONUS_HP_PERCENT, SET_BONUS_DEF_PERCENT,
+		SET_BONUS_HP_FLAT, SET_BONUS_DEF_FLAT, SET_BONUS_ELEMENTAL_MASTERY,
+		SET_BONUS_CRIT_RATE, SET_BONUS_ENERGY_RECHARGE, SET_BONUS_HEALING_BONUS,
+		SET_BONUS_INCOMING_HEALING_BONUS, SET_BONUS_SHIELD_STRENGTH,
+		SET_BONUS_PHYSICAL_DMG_BONUS, SET_BONUS_PYRO_DMG_BONUS, SET_BONUS_HYDRO_DMG_BONUS,
+		SET_BONUS_ELECTRO_DMG_BONUS, SET_BONUS_CRYO_DMG_BONUS, SET_BONUS_ANEMO_DMG_BONUS,
+		SET_BONUS_GEO_DMG_BONUS, SET_BONUS_DENDRO_DMG_BONUS, SET_BONUS_PYRO_RES,
+		SET_BONUS_ELECTRO_RES, SET_BONUS_ALL_ELEMENTAL_RES,
+		SET_BONUS_NORMAL_ATTACK_DMG_BONUS, SET_BONUS_CHARGED_ATTACK_DMG_BONUS,
+		SET_BONUS_PLUNGING_ATTACK_DMG_BONUS, SET_BONUS_ELEMENTAL_SKILL_DMG_BONUS,
+		SET_BONUS_ELEMENTAL_BURST_DMG_BONUS:
+		return true
+	}
+	return false
+}
+
+type SetBonusStat struct {
+	Type  SetBonusStatType `json:"type"`
+	Value float64          `json:"value"`
+}
+
+type SetBonus struct {
+	Pieces      int            `json:"pieces"`
+	Description string         `json:"description"`
+	Stats       []SetBonusStat `json:"stats"`
+}
+
+type ArtifactSetInfo struct {
+	Key ArtifactSet `json:"key"`
+	// Aliases は過去のバージョンで保存されていた旧キー
+	Aliases   []string          `json:"aliases"`
+	Names     map[string]string `json:"names"`
+	MaxRarity int               `json:"max_rarity"`
+	Bonuses   []SetBonus        `json:"bonuses"`
+}
+
+// Name は指定した言語の表示名を返す。存在しなければ DefaultLanguage の名前を返す。
+func (i *ArtifactSetInfo) Name(lang string) string {
+	if name, ok := i.Names[lang]; ok {
+		return name
+	}
+	return i.Names[DefaultLanguage]
+}
+
+//go:embed data/artifact_sets.json
+var artifactSetCatalogJSON []byte
+
+type artifactSetCatalog struct {
+	sets  []*ArtifactSetInfo
+	index map[string]*ArtifactSetInfo
+}
+
+var defaultArtifactSetCatalog = mustLoadArtifactSetCatalog(artifactSetCatalogJSON)
+
+func mustLoadArtifactSetCatalog(data []byte) *artifactSetCatalog {
+	catalog, err := loadArtifactSetCatalog(data)
+	if err != nil {
+		panic(fmt.Sprintf("invalid embedded artifact set catalog: %v", err))
+	}
+	return catalog
+}
+
+func loadArtifactSetCatalog(data []byte) (*artifactSetCatalog, error) {
+	var file struct {
+		ArtifactSets []*ArtifactSetInfo `json:"artifact_sets"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+
+	catalog := &artifactSetCatalog{
+		sets:  file.ArtifactSets,
+		index: make(map[string]*ArtifactSetInfo),
+	}
+	for _, set := range file.ArtifactSets {
+		if set.Key == "" {
+			return nil, fmt.Errorf("artifact set key is empty")
+		}
+		if _, ok := set.Names[DefaultLanguage]; !ok {
+			return nil, fmt.Errorf("artifact set %s has no %s name", set.Key, DefaultLanguage)
+		}
+		if set.MaxRarity < 1 || set.MaxRarity > 5 {
+			return nil, fmt.Errorf("artifact set %s has invalid max rarity %d", set.Key, set.MaxRarity)
+		}
+		for _, bonus := range set.Bonuses {
+			for _, stat := range bonus.Stats {
+				if !stat.Type.valid() {
+					return nil, fmt.Errorf("artifact set %s has invalid bonus stat %s", set.Key, stat.Type)
+				}
+			}
+		}
+
+		for _, key := range append([]string{string(set.Key)}, set.Aliases...) {
+			if _, exists := catalog.index[key]; exists {
+				return nil, fmt.Errorf("duplicated artifact set key %s", key)
+			}
+			catalog.index[key] = set
+		}
+	}
+	return catalog, nil
+}
+
+// ArtifactSets は既知のすべての聖遺物セットをカタログの定義順に返す。
+func ArtifactSets() []*ArtifactSetInfo {
+	return defaultArtifactSetCatalog.sets
+}
+
+// LookupArtifactSet は正規キーまたは旧キーからセット情報を引く。
+func LookupArtifactSet(keyOrAlias string) (*ArtifactSetInfo, bool) {
+	set, ok := defaultArtifactSetCatalog.index[keyOrAlias]
+	return set, ok
+}
+
+// ParseArtifactSet は正規キーまたは旧キーを正規キーへ変換する。
+func ParseArtifactSet(keyOrAlias string) (ArtifactSet, error) {
+	set, ok := LookupArtifactSet(keyOrAlias)
+	if !ok {
+		return "", ErrInvalidArtifactSet
+	}
+	return set.Key, nil
+}
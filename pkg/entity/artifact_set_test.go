@@ -0,0 +1,183 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestArtifactSetCatalog(t *testing.T) {
+	sets := ArtifactSets()
+	if len(sets) == 0 {
+		t.Fatal("expected embedded catalog to contain artifact sets")
+	}
+
+	for _, set := range sets {
+		if len(set.Bonuses) == 0 {
+			t.Errorf("artifact set %s has no bonuses", set.Key)
+		}
+		for _, bonus := range set.Bonuses {
+			if bonus.Description == "" {
+				t.Errorf("artifact set %s has an empty %d-piece description", set.Key, bonus.Pieces)
+			}
+		}
+	}
+
+	for _, key := range []ArtifactSet{
+		ARTIFACT_SET_GLADIATORS_FINALOFFERING, ARTIFACT_SET_WANDERERS_TROUPE,
+		ARTIFACT_SET_NOBLESSE_OBLIGE, ARTIFACT_SET_BLOODSTAINED_CHIVALRY,
+		ARTIFACT_SET_MAIDENS_BELLSING, ARTIFACT_SET_VIRIDESCENT_VENERER,
+	} {
+		if _, ok := LookupArtifactSet(string(key)); !ok {
+			t.Errorf("artifact set %s is not in catalog", key)
+		}
+	}
+}
+
+func TestParseArtifactSet(t *testing.T) {
+	tests := []struct {
+		name string
+
+		// WHEN
+		keyOrAlias string
+
+		// THEN
+		expectedArtifactSet ArtifactSet
+		expectedError       error
+	}{
+		{
+			name: "ShouldParseCanonicalKey",
+
+			keyOrAlias: "EmblemOfSeveredFate",
+
+			expectedArtifactSet: "EmblemOfSeveredFate",
+		},
+		{
+			name: "ShouldParseLegacyAlias",
+
+			keyOrAlias: "Gladiator",
+
+			expectedArtifactSet: ARTIFACT_SET_GLADIATORS_FINALOFFERING,
+		},
+		{
+			name: "ShouldParseMisnamedViridescentVenererAlias",
+
+			keyOrAlias: "Vermillion",
+
+			expectedArtifactSet: ARTIFACT_SET_VIRIDESCENT_VENERER,
+		},
+		{
+			name: "ShouldReturnErrorWhenUnknownKey",
+
+			keyOrAlias: "INVALID_SET",
+
+			expectedError: ErrInvalidArtifactSet,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			artifactSet, err := ParseArtifactSet(tt.keyOrAlias)
+
+			if artifactSet != tt.expectedArtifactSet {
+				t.Errorf("ParseArtifactSet() = %s, expected %s", artifactSet, tt.expectedArtifactSet)
+			}
+
+			if !errors.Is(err, tt.expectedError) {
+				t.Errorf("ParseArtifactSet() error = %v, expectedError %v", err, tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestArtifactSetInfoName(t *testing.T) {
+	set, ok := LookupArtifactSet(string(ARTIFACT_SET_GLADIATORS_FINALOFFERING))
+	if !ok {
+		t.Fatal("expected Gladiator's Finale in catalog")
+	}
+
+	tests := []struct {
+		name string
+
+		lang string
+
+		expectedName string
+	}{
+		{
+			name: "ShouldReturnLocalizedName",
+
+			lang: "ja",
+
+			expectedName: "剣闘士のフィナーレ",
+		},
+		{
+			name: "ShouldFallBackToDefaultLanguage",
+
+			lang: "fr",
+
+			expectedName: "Gladiator's Finale",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if name := set.Name(tt.lang); name != tt.expectedName {
+				t.Errorf("Name() = %s, expected %s", name, tt.expectedName)
+			}
+		})
+	}
+}
+
+func TestLoadArtifactSetCatalog(t *testing.T) {
+	tests := []struct {
+		name string
+
+		data string
+
+		expectedError bool
+	}{
+		{
+			name: "ShouldLoadValidCatalog",
+
+			data: `{"artifact_sets":[{"key":"A","aliases":["B"],"names":{"en":"A"},"max_rarity":5,"bonuses":[{"pieces":2,"description":"ATK +18%.","stats":[{"type":"ATK_PERCENT","value":18}]}]}]}`,
+
+			expectedError: false,
+		},
+		{
+			name: "ShouldReturnErrorWhenKeyIsDuplicated",
+
+			data: `{"artifact_sets":[{"key":"A","names":{"en":"A"},"max_rarity":5},{"key":"B","aliases":["A"],"names":{"en":"B"},"max_rarity":5}]}`,
+
+			expectedError: true,
+		},
+		{
+			name: "ShouldReturnErrorWhenDefaultNameIsMissing",
+
+			data: `{"artifact_sets":[{"key":"A","names":{"ja":"A"},"max_rarity":5}]}`,
+
+			expectedError: true,
+		},
+		{
+			name: "ShouldReturnErrorWhenMaxRarityIsInvalid",
+
+			data: `{"artifact_sets":[{"key":"A","names":{"en":"A"},"max_rarity":6}]}`,
+
+			expectedError: true,
+		},
+		{
+			name: "ShouldReturnErrorWhenBonusStatIsUnknown",
+
+			data: `{"artifact_sets":[{"key":"A","names":{"en":"A"},"max_rarity":5,"bonuses":[{"pieces":2,"description":"?","stats":[{"type":"UNKNOWN","value":1}]}]}]}`,
+
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadArtifactSetCatalog([]byte(tt.data))
+			if (err != nil) != tt.expectedError {
+				t.Errorf("loadArtifactSetCatalog() error = %v, expectedError %v", err, tt.expectedError)
+			}
+		})
+	}
+}
@@ -33,6 +33,15 @@ func TestNewPrimaryStat(t *testing.T) {
 			expectedPrimaryStat: testPrimaryStat,
 			expectedError:       nil,
 		},
+		{
+			name: "ShouldReturnErrorWhenLegacyElementalDMGBonus",
+
+			statType: "ELEMENTAL_DMG_BONUS",
+			value:    46.6,
+
+			expectedPrimaryStat: nil,
+			expectedError:       ErrInvalidPrimaryStatType,
+		},
 		{
 			name: "ShouldReturnErrorWhenInvalidPrimaryStatType",
 
@@ -85,6 +94,15 @@ func TestNewSubStat(t *testing.T) {
 			expectedSubStat: testSubStat,
 			expectedError:   nil,
 		},
+		{
+			name: "ShouldNewFlatSubStatSuccessfully",
+
+			statType: "DEF_FLAT",
+			value:    19,
+
+			expectedSubStat: &Substat{Type: SUBSTAT_DEF_FLAT, Value: 19},
+			expectedError:   nil,
+		},
 		{
 			name: "ShoudlReturnErrorWhenInvalidSubstatType",
 
@@ -116,9 +134,10 @@ func TestNewArtifact(t *testing.T) {
 		ID:          "test-id",
 		ArtifactSet: ARTIFACT_SET_BLOODSTAINED_CHIVALRY,
 		Type:        ARTIFACT_TYPE_FLOWER,
+		Rarity:      3,
 		Level:       0,
-		PrimaryStat: PrimaryStat{Type: "ATK_PERCENT", Value: 0.1},
-		Substats:    []Substat{{Type: "ATK_PERCENT", Value: 0.1}},
+		PrimaryStat: PrimaryStat{Type: "HP_FLAT", Value: 430},
+		Substats:    []Substat{{Type: "ATK_PERCENT", Value: 3.5}},
 	}
 
 	tests := []struct {
@@ -128,6 +147,7 @@ func TestNewArtifact(t *testing.T) {
 		id           string
 		artifactSet  string
 		artifactType string
+		rarity       int
 		level        int
 		primaryStat  PrimaryStat
 		substats     []Substat
@@ -142,15 +162,16 @@ func TestNewArtifact(t *testing.T) {
 			id:           "test-id",
 			artifactSet:  string(ARTIFACT_SET_BLOODSTAINED_CHIVALRY),
 			artifactType: string(ARTIFACT_TYPE_FLOWER),
+			rarity:       3,
 			level:        0,
 			primaryStat: PrimaryStat{
-				Type:  "ATK_PERCENT",
-				Value: 0.1,
+				Type:  "HP_FLAT",
+				Value: 430,
 			},
 			substats: []Substat{
 				{
 					Type:  "ATK_PERCENT",
-					Value: 0.1,
+					Value: 3.5,
 				},
 			},
 
@@ -181,6 +202,87 @@ func TestNewArtifact(t *testing.T) {
 
 			expectedError: ErrInvalidArtifactType,
 		},
+		{
+			name: "ShouldReturnErrorWhenPrimaryStatIsNotAllowedForFlower",
+
+			id:           "test-id",
+			artifactSet:  string(ARTIFACT_SET_BLOODSTAINED_CHIVALRY),
+			artifactType: string(ARTIFACT_TYPE_FLOWER),
+			primaryStat:  PrimaryStat{Type: CRIT_DMG, Value: 62.2},
+
+			expectedError: ErrPrimaryStatNotAllowedForType,
+		},
+		{
+			name: "ShouldReturnErrorWhenPrimaryStatIsNotAllowedForCirclet",
+
+			id:           "test-id",
+			artifactSet:  string(ARTIFACT_SET_BLOODSTAINED_CHIVALRY),
+			artifactType: string(ARTIFACT_TYPE_CIRCLET),
+			primaryStat:  PrimaryStat{Type: ENERGY_RECHARGE, Value: 51.8},
+
+			expectedError: ErrPrimaryStatNotAllowedForType,
+		},
+		{
+			name: "ShouldReturnErrorWhenElementalDMGBonusOnSands",
+
+			id:           "test-id",
+			artifactSet:  string(ARTIFACT_SET_BLOODSTAINED_CHIVALRY),
+			artifactType: string(ARTIFACT_TYPE_SANDS),
+			primaryStat:  PrimaryStat{Type: HYDRO_DMG_BONUS, Value: 46.6},
+
+			expectedError: ErrPrimaryStatNotAllowedForType,
+		},
+		{
+			name: "ShouldReturnErrorWhenSubstatDuplicatesPrimaryStat",
+
+			id:           "test-id",
+			artifactSet:  string(ARTIFACT_SET_BLOODSTAINED_CHIVALRY),
+			artifactType: string(ARTIFACT_TYPE_PLUME),
+			primaryStat:  PrimaryStat{Type: ATK_FLAT, Value: 311},
+			substats:     []Substat{{Type: SUBSTAT_ATK_FLAT, Value: 19}},
+
+			expectedError: ErrSubstatDuplicatesPrimaryStat,
+		},
+		{
+			name: "ShouldReturnErrorWhenSubstatIsDuplicated",
+
+			id:           "test-id",
+			artifactSet:  string(ARTIFACT_SET_BLOODSTAINED_CHIVALRY),
+			artifactType: string(ARTIFACT_TYPE_SANDS),
+			primaryStat:  PrimaryStat{Type: ATK_PERCENT, Value: 46.6},
+			substats: []Substat{
+				{Type: SUBSTAT_CRIT_RATE, Value: 3.9},
+				{Type: SUBSTAT_CRIT_RATE, Value: 3.5},
+			},
+
+			expectedError: ErrDuplicateSubstat,
+		},
+		{
+			name: "ShouldReturnErrorWhenPrimaryStatValueDoesNotMatchLevel",
+
+			id:           "test-id",
+			artifactSet:  string(ARTIFACT_SET_BLOODSTAINED_CHIVALRY),
+			artifactType: string(ARTIFACT_TYPE_FLOWER),
+			rarity:       3,
+			level:        0,
+			primaryStat:  PrimaryStat{Type: HP_FLAT, Value: 717},
+			substats:     []Substat{{Type: SUBSTAT_ATK_PERCENT, Value: 3.5}},
+
+			expectedError: ErrPrimaryStatValueMismatch,
+		},
+		{
+			name: "ShouldReturnErrorWhenSubstatValueIsImpossible",
+
+			id:           "test-id",
+			artifactSet:  string(ARTIFACT_SET_BLOODSTAINED_CHIVALRY),
+			artifactType: string(ARTIFACT_TYPE_FLOWER),
+			rarity:       3,
+			level:        0,
+			primaryStat:  PrimaryStat{Type: HP_FLAT, Value: 430},
+			substats:     []Substat{{Type: SUBSTAT_ATK_PERCENT, Value: 35}},
+
+			expectedError: ErrImpossibleSubstatValue,
+		},
 	}
 
 	for _, tt := range tests {
@@ -189,6 +291,7 @@ func TestNewArtifact(t *testing.T) {
 				tt.id,
 				tt.artifactSet,
 				tt.artifactType,
+				tt.rarity,
 				tt.level,
 				tt.primaryStat,
 				tt.substats,
@@ -204,3 +307,126 @@ func TestNewArtifact(t *testing.T) {
 		})
 	}
 }
+
+func TestAllowedPrimaryStats(t *testing.T) {
+	tests := []struct {
+		name string
+
+		artifactType ArtifactType
+
+		expectedPrimaryStats []PrimaryStatType
+	}{
+		{
+			name: "ShouldReturnFlatHPForFlower",
+
+			artifactType: ARTIFACT_TYPE_FLOWER,
+
+			expectedPrimaryStats: []PrimaryStatType{HP_FLAT},
+		},
+		{
+			name: "ShouldReturnFlatATKForPlume",
+
+			artifactType: ARTIFACT_TYPE_PLUME,
+
+			expectedPrimaryStats: []PrimaryStatType{ATK_FLAT},
+		},
+		{
+			name: "ShouldReturnNilForInvalidType",
+
+			artifactType: "INVALID_TYPE",
+
+			expectedPrimaryStats: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.expectedPrimaryStats, AllowedPrimaryStats(tt.artifactType)); diff != "" {
+				t.Errorf("AllowedPrimaryStats() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestArtifactSameContent(t *testing.T) {
+	base := &Artifact{
+		ID:          "base-id",
+		ArtifactSet: ARTIFACT_SET_GLADIATORS_FINALOFFERING,
+		Type:        ARTIFACT_TYPE_FLOWER,
+		Rarity:      5,
+		Level:       0,
+		PrimaryStat: PrimaryStat{Type: HP_FLAT, Value: 717},
+		Substats: []Substat{
+			{Type: SUBSTAT_CRIT_RATE, Value: 3.9},
+			{Type: SUBSTAT_CRIT_DMG, Value: 7.8},
+		},
+	}
+
+	tests := []struct {
+		name string
+
+		other *Artifact
+
+		expected bool
+	}{
+		{
+			name: "ShouldIgnoreIDLockEquippedByAndSubstatOrder",
+
+			other: &Artifact{
+				ID:          "other-id",
+				ArtifactSet: ARTIFACT_SET_GLADIATORS_FINALOFFERING,
+				Type:        ARTIFACT_TYPE_FLOWER,
+				Rarity:      5,
+				Level:       0,
+				PrimaryStat: PrimaryStat{Type: HP_FLAT, Value: 717},
+				Substats: []Substat{
+					{Type: SUBSTAT_CRIT_DMG, Value: 7.8},
+					{Type: SUBSTAT_CRIT_RATE, Value: 3.9},
+				},
+				Locked:     true,
+				EquippedBy: "Diluc",
+			},
+
+			expected: true,
+		},
+		{
+			name: "ShouldDetectDifferentLevel",
+
+			other: &Artifact{
+				ArtifactSet: ARTIFACT_SET_GLADIATORS_FINALOFFERING,
+				Type:        ARTIFACT_TYPE_FLOWER,
+				Rarity:      5,
+				Level:       4,
+				PrimaryStat: PrimaryStat{Type: HP_FLAT, Value: 717},
+				Substats:    base.Substats,
+			},
+
+			expected: false,
+		},
+		{
+			name: "ShouldDetectDifferentSubstatValue",
+
+			other: &Artifact{
+				ArtifactSet: ARTIFACT_SET_GLADIATORS_FINALOFFERING,
+				Type:        ARTIFACT_TYPE_FLOWER,
+				Rarity:      5,
+				Level:       0,
+				PrimaryStat: PrimaryStat{Type: HP_FLAT, Value: 717},
+				Substats: []Substat{
+					{Type: SUBSTAT_CRIT_RATE, Value: 3.5},
+					{Type: SUBSTAT_CRIT_DMG, Value: 7.8},
+				},
+			},
+
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := base.SameContent(tt.other); got != tt.expected {
+				t.Errorf("SameContent() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package entity
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidCharacter = errors.New("invalid character")
+
+// CharacterKey は GOOD 形式と同じキャラクターのキー (例: "RaidenShogun")。
+// 正規キーと表示名・ステータスは data/characters.json のカタログで定義する。
+type CharacterKey string
+
+type WeaponType string
+
+const WEAPON_TYPE_SWORD WeaponType = "SWORD"
+const WEAPON_TYPE_CLAYMORE WeaponType = "CLAYMORE"
+const WEAPON_TYPE_POLEARM WeaponType = "POLEARM"
+const WEAPON_TYPE_BOW WeaponType = "BOW"
+const WEAPON_TYPE_CATALYST WeaponType = "CATALYST"
+
+func (t WeaponType) valid() bool {
+	switch t {
+	case WEAPON_TYPE_SWORD, WEAPON_TYPE_CLAYMORE, WEAPON_TYPE_POLEARM, WEAPON_TYPE_BOW, WEAPON_TYPE_CATALYST:
+		return true
+	}
+	return false
+}
+
+// CharacterBaseStats はレベル 90 (突破済み) の基礎ステータス。
+type CharacterBaseStats struct {
+	HP  float64 `json:"hp"`
+	ATK float64 `json:"atk"`
+	DEF float64 `json:"def"`
+}
+
+// AscensionStat は突破で上がるステータスの、レベル 90 での値。
+type AscensionStat struct {
+	Type  PrimaryStatType `json:"type"`
+	Value float64         `json:"value"`
+}
+
+type CharacterInfo struct {
+	Key           CharacterKey       `json:"key"`
+	Names         map[string]string  `json:"names"`
+	Element       Element            `json:"element"`
+	WeaponType    WeaponType         `json:"weapon_type"`
+	Rarity        int                `json:"rarity"`
+	BaseStats     CharacterBaseStats `json:"base_stats"`
+	AscensionStat AscensionStat      `json:"ascension_stat"`
+}
+
+// Name は指定した言語の表示名を返す。存在しなければ DefaultLanguage の名前を返す。
+func (i *CharacterInfo) Name(lang string) string {
+	if name, ok := i.Names[lang]; ok {
+		return name
+	}
+	return i.Names[DefaultLanguage]
+}
+
+//go:embed data/characters.json
+var characterCatalogJSON []byte
+
+type characterCatalog struct {
+	characters []*CharacterInfo
+	index      map[CharacterKey]*CharacterInfo
+}
+
+var defaultCharacterCatalog = mustLoadCharacterCatalog(characterCatalogJSON)
+
+func mustLoadCharacterCatalog(data []byte) *characterCatalog {
+	catalog, err := loadCharacterCatalog(data)
+	if err != nil {
+		panic(fmt.Sprintf("invalid embedded character catalog: %v", err))
+	}
+	return catalog
+}
+
+func loadCharacterCatalog(data []byte) (*characterCatalog, error) {
+	var file struct {
+		Characters []*CharacterInfo `json:"characters"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+
+	catalog := &characterCatalog{
+		characters: file.Characters,
+		index:      make(map[CharacterKey]*CharacterInfo),
+	}
+	for _, character := range file.Characters {
+		if character.Key == "" {
+			return nil, fmt.Errorf("character key is empty")
+		}
+		if _, ok := character.Names[DefaultLanguage]; !ok {
+			return nil, fmt.Errorf("character %s has no %s name", character.Key, DefaultLanguage)
+		}
+		if _, ok := ElementalDMGBonusStat(character.Element); !ok {
+			return nil, fmt.Errorf("character %s has invalid element %s", character.Key, character.Element)
+		}
+		if !character.WeaponType.valid() {
+			return nil, fmt.Errorf("character %s has invalid weapon type %s", character.Key, character.WeaponType)
+		}
+		if character.Rarity != 4 && character.Rarity != 5 {
+			return nil, fmt.Errorf("character %s has invalid rarity %d", character.Key, character.Rarity)
+		}
+		if _, err := NewPrimaryStat(string(character.AscensionStat.Type), character.AscensionStat.Value); err != nil {
+			return nil, fmt.Errorf("character %s has invalid ascension stat %s", character.Key, character.AscensionStat.Type)
+		}
+
+		if _, exists := catalog.index[character.Key]; exists {
+			return nil, fmt.Errorf("duplicated character key %s", character.Key)
+		}
+		catalog.index[character.Key] = character
+	}
+	return catalog, nil
+}
+
+// Characters は既知のすべてのキャラクターをカタログの定義順に返す。
+func Characters() []*CharacterInfo {
+	return defaultCharacterCatalog.characters
+}
+
+func LookupCharacter(key string) (*CharacterInfo, bool) {
+	character, ok := defaultCharacterCatalog.index[CharacterKey(key)]
+	return character, ok
+}
+
+// ParseCharacterKey はカタログにあるキャラクターのキーを検証して CharacterKey に変換する。
+func ParseCharacterKey(key string) (CharacterKey, error) {
+	character, ok := LookupCharacter(key)
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrInvalidCharacter, key)
+	}
+	return character.Key, nil
+}
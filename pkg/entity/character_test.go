@@ -0,0 +1,146 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCharacterCatalog(t *testing.T) {
+	characters := Characters()
+	if len(characters) == 0 {
+		t.Fatal("expected embedded catalog to contain characters")
+	}
+
+	for _, character := range characters {
+		if character.BaseStats.HP <= 0 || character.BaseStats.ATK <= 0 || character.BaseStats.DEF <= 0 {
+			t.Errorf("character %s has non-positive base stats %+v", character.Key, character.BaseStats)
+		}
+		if character.AscensionStat.Value <= 0 {
+			t.Errorf("character %s has non-positive ascension stat %+v", character.Key, character.AscensionStat)
+		}
+	}
+
+	for _, key := range []string{"RaidenShogun", "Xiangling", "Diluc"} {
+		if _, ok := LookupCharacter(key); !ok {
+			t.Errorf("character %s is not in catalog", key)
+		}
+	}
+}
+
+func TestParseCharacterKey(t *testing.T) {
+	tests := []struct {
+		name string
+
+		// WHEN
+		key string
+
+		// THEN
+		expectedCharacterKey CharacterKey
+		expectedError        error
+	}{
+		{
+			name: "ShouldParseCatalogKey",
+
+			key: "RaidenShogun",
+
+			expectedCharacterKey: "RaidenShogun",
+		},
+		{
+			name: "ShouldReturnErrorWhenDisplayNameIsGiven",
+
+			key: "Raiden Shogun",
+
+			expectedError: ErrInvalidCharacter,
+		},
+		{
+			name: "ShouldReturnErrorWhenKeyIsEmpty",
+
+			key: "",
+
+			expectedError: ErrInvalidCharacter,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			characterKey, err := ParseCharacterKey(tt.key)
+
+			if characterKey != tt.expectedCharacterKey {
+				t.Errorf("ParseCharacterKey() = %s, expected %s", characterKey, tt.expectedCharacterKey)
+			}
+
+			if !errors.Is(err, tt.expectedError) {
+				t.Errorf("ParseCharacterKey() error = %v, expectedError %v", err, tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestLoadCharacterCatalog(t *testing.T) {
+	tests := []struct {
+		name string
+
+		data string
+
+		expectedError bool
+	}{
+		{
+			name: "ShouldLoadValidCatalog",
+
+			data: `{"characters":[{"key":"A","names":{"en":"A"},"element":"PYRO","weapon_type":"SWORD","rarity":5,"base_stats":{"hp":1,"atk":1,"def":1},"ascension_stat":{"type":"CRIT_RATE","value":19.2}}]}`,
+
+			expectedError: false,
+		},
+		{
+			name: "ShouldReturnErrorWhenKeyIsDuplicated",
+
+			data: `{"characters":[{"key":"A","names":{"en":"A"},"element":"PYRO","weapon_type":"SWORD","rarity":5,"ascension_stat":{"type":"CRIT_RATE"}},{"key":"A","names":{"en":"B"},"element":"PYRO","weapon_type":"SWORD","rarity":5,"ascension_stat":{"type":"CRIT_RATE"}}]}`,
+
+			expectedError: true,
+		},
+		{
+			name: "ShouldReturnErrorWhenDefaultNameIsMissing",
+
+			data: `{"characters":[{"key":"A","names":{"ja":"A"},"element":"PYRO","weapon_type":"SWORD","rarity":5,"ascension_stat":{"type":"CRIT_RATE"}}]}`,
+
+			expectedError: true,
+		},
+		{
+			name: "ShouldReturnErrorWhenElementIsUnknown",
+
+			data: `{"characters":[{"key":"A","names":{"en":"A"},"element":"PHYSICAL","weapon_type":"SWORD","rarity":5,"ascension_stat":{"type":"CRIT_RATE"}}]}`,
+
+			expectedError: true,
+		},
+		{
+			name: "ShouldReturnErrorWhenWeaponTypeIsUnknown",
+
+			data: `{"characters":[{"key":"A","names":{"en":"A"},"element":"PYRO","weapon_type":"GUN","rarity":5,"ascension_stat":{"type":"CRIT_RATE"}}]}`,
+
+			expectedError: true,
+		},
+		{
+			name: "ShouldReturnErrorWhenRarityIsInvalid",
+
+			data: `{"characters":[{"key":"A","names":{"en":"A"},"element":"PYRO","weapon_type":"SWORD","rarity":3,"ascension_stat":{"type":"CRIT_RATE"}}]}`,
+
+			expectedError: true,
+		},
+		{
+			name: "ShouldReturnErrorWhenAscensionStatIsUnknown",
+
+			data: `{"characters":[{"key":"A","names":{"en":"A"},"element":"PYRO","weapon_type":"SWORD","rarity":5,"ascension_stat":{"type":"UNKNOWN"}}]}`,
+
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadCharacterCatalog([]byte(tt.data))
+			if (err != nil) != tt.expectedError {
+				t.Errorf("loadCharacterCatalog() error = %v, expectedError %v", err, tt.expectedError)
+			}
+		})
+	}
+}
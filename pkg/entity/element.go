@@ -0,0 +1,38 @@
+package entity
+
+type Element string
+
+const ELEMENT_PYRO Element = "PYRO"
+const ELEMENT_HYDRO Element = "HYDRO"
+const ELEMENT_ELECTRO Element = "ELECTRO"
+const ELEMENT_CRYO Element = "CRYO"
+const ELEMENT_ANEMO Element = "ANEMO"
+const ELEMENT_GEO Element = "GEO"
+const ELEMENT_DENDRO Element = "DENDRO"
+
+var elementalDMGBonusStats = map[Element]PrimaryStatType{
+	ELEMENT_PYRO:    PYRO_DMG_BONUS,
+	ELEMENT_HYDRO:   HYDRO_DMG_BONUS,
+	ELEMENT_ELECTRO: ELECTRO_DMG_BONUS,
+	ELEMENT_CRYO:    CRYO_DMG_BONUS,
+	ELEMENT_ANEMO:   ANEMO_DMG_BONUS,
+	ELEMENT_GEO:     GEO_DMG_BONUS,
+	ELEMENT_DENDRO:  DENDRO_DMG_BONUS,
+}
+
+// ElementalDMGBonusStat は元素に対応する元素ダメージバフのメインステータスを返す。
+func ElementalDMGBonusStat(element Element) (PrimaryStatType, bool) {
+	statType, ok := elementalDMGBonusStats[element]
+	return statType, ok
+}
+
+// Element は元素ダメージバフのメインステータスに対応する元素を返す。
+// 元素が不明な旧データや元素ダメージバフ以外では false を返す。
+func (t PrimaryStatType) Element() (Element, bool) {
+	for element, statType := range elementalDMGBonusStats {
+		if statType == t {
+			return element, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,66 @@
+package entity
+
+import "testing"
+
+func TestPrimaryStatTypeElement(t *testing.T) {
+	tests := []struct {
+		name string
+
+		statType PrimaryStatType
+
+		expectedElement Element
+		expectedOK      bool
+	}{
+		{
+			name: "ShouldReturnPyroForPyroDMGBonus",
+
+			statType: PYRO_DMG_BONUS,
+
+			expectedElement: ELEMENT_PYRO,
+			expectedOK:      true,
+		},
+		{
+			name: "ShouldReturnFalseForUnknownElement",
+
+			statType: UNKNOWN_ELEMENT_DMG_BONUS,
+
+			expectedOK: false,
+		},
+		{
+			name: "ShouldReturnFalseForNonElementalStat",
+
+			statType: PHYSICAL_DMG_BONUS,
+
+			expectedOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			element, ok := tt.statType.Element()
+			if element != tt.expectedElement || ok != tt.expectedOK {
+				t.Errorf("Element() = (%s, %v), expected (%s, %v)", element, ok, tt.expectedElement, tt.expectedOK)
+			}
+		})
+	}
+}
+
+func TestElementalDMGBonusStat(t *testing.T) {
+	for _, element := range []Element{
+		ELEMENT_PYRO, ELEMENT_HYDRO, ELEMENT_ELECTRO, ELEMENT_CRYO,
+		ELEMENT_ANEMO, ELEMENT_GEO, ELEMENT_DENDRO,
+	} {
+		statType, ok := ElementalDMGBonusStat(element)
+		if !ok {
+			t.Errorf("expected DMG bonus stat for %s", element)
+			continue
+		}
+
+		if _, err := NewPrimaryStat(string(statType), 46.6); err != nil {
+			t.Errorf("expected %s to be a valid primary stat, got error: %v", statType, err)
+		}
+		if roundTrip, _ := statType.Element(); roundTrip != element {
+			t.Errorf("expected %s, got %s", element, roundTrip)
+		}
+	}
+}
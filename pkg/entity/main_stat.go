@@ -0,0 +1,109 @@
+package entity
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+)
+
+var (
+	ErrPrimaryStatValueMismatch = errors.New("primary stat value does not match rarity and level")
+)
+
+const (
+	// flatMainStatTolerance はゲーム内で整数表示されるメインステータスの許容誤差
+	flatMainStatTolerance = 1.0
+	// percentMainStatTolerance は小数第 1 位まで表示されるメインステータスの許容誤差
+	percentMainStatTolerance = 0.1
+
+	// toleranceEpsilon は浮動小数点の丸め誤差で境界値が弾かれないようにするための余裕
+	toleranceEpsilon = 1e-9
+)
+
+//go:embed data/main_stats.json
+var mainStatTableJSON []byte
+
+// mainStatTable はレアリティ・メインステータスごとに、レベル 0 から最大レベルまでの値を持つ。
+type mainStatTable map[int]map[PrimaryStatType][]float64
+
+var defaultMainStatTable = mustLoadMainStatTable(mainStatTableJSON)
+
+func mustLoadMainStatTable(data []byte) mainStatTable {
+	table, err := loadMainStatTable(data)
+	if err != nil {
+		panic(fmt.Sprintf("invalid embedded main stat table: %v", err))
+	}
+	return table
+}
+
+func loadMainStatTable(data []byte) (mainStatTable, error) {
+	var file struct {
+		MainStats []struct {
+			Rarity int                           `json:"rarity"`
+			Values map[PrimaryStatType][]float64 `json:"values"`
+		} `json:"main_stats"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+
+	table := make(mainStatTable, len(file.MainStats))
+	for _, entry := range file.MainStats {
+		maxLevel, err := MaxLevel(entry.Rarity)
+		if err != nil {
+			return nil, fmt.Errorf("main stat table has invalid rarity %d", entry.Rarity)
+		}
+		if _, exists := table[entry.Rarity]; exists {
+			return nil, fmt.Errorf("duplicated main stat table for rarity %d", entry.Rarity)
+		}
+		for statType, values := range entry.Values {
+			if _, err := NewPrimaryStat(string(statType), 0); err != nil {
+				return nil, fmt.Errorf("main stat table for rarity %d has invalid stat %s", entry.Rarity, statType)
+			}
+			if len(values) != maxLevel+1 {
+				return nil, fmt.Errorf("main stat table for %d-star %s has %d levels, expected %d", entry.Rarity, statType, len(values), maxLevel+1)
+			}
+		}
+		table[entry.Rarity] = entry.Values
+	}
+	return table, nil
+}
+
+// MainStatValue はレアリティとレベルから決まるメインステータスの値を返す。
+func MainStatValue(rarity, level int, statType PrimaryStatType) (float64, error) {
+	values, ok := defaultMainStatTable[rarity]
+	if !ok {
+		return 0, fmt.Errorf("%w: %d", ErrInvalidRarity, rarity)
+	}
+	levels, ok := values[statType]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidPrimaryStatType, statType)
+	}
+	if level < 0 || level >= len(levels) {
+		return 0, fmt.Errorf("%w: %d-star artifacts range from +0 to +%d, got %d", ErrInvalidLevel, rarity, len(levels)-1, level)
+	}
+	return levels[level], nil
+}
+
+// mainStatTolerance は表示桁の違いによる誤差を吸収するための許容幅を返す。
+func mainStatTolerance(statType PrimaryStatType) float64 {
+	switch statType {
+	case HP_FLAT, ATK_FLAT, ELEMENTAL_MASTERY:
+		return flatMainStatTolerance
+	default:
+		return percentMainStatTolerance
+	}
+}
+
+func validatePrimaryStatValue(rarity, level int, primaryStat PrimaryStat) error {
+	expected, err := MainStatValue(rarity, level, primaryStat.Type)
+	if err != nil {
+		return err
+	}
+	if math.Abs(primaryStat.Value-expected) > mainStatTolerance(primaryStat.Type)+toleranceEpsilon {
+		return fmt.Errorf("%w: %d-star +%d %s should be %g, got %g", ErrPrimaryStatValueMismatch, rarity, level, primaryStat.Type, expected, primaryStat.Value)
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMainStatValue(t *testing.T) {
+	tests := []struct {
+		name string
+
+		// WHEN
+		rarity   int
+		level    int
+		statType PrimaryStatType
+
+		// THEN
+		expectedValue float64
+		expectedError error
+	}{
+		{
+			name: "ShouldReturnBaseValueForFiveStarFlower",
+
+			rarity:   5,
+			level:    0,
+			statType: HP_FLAT,
+
+			expectedValue: 717,
+		},
+		{
+			name: "ShouldReturnMaxValueForFiveStarATKPercent",
+
+			rarity:   5,
+			level:    20,
+			statType: ATK_PERCENT,
+
+			expectedValue: 46.6,
+		},
+		{
+			name: "ShouldReturnSameTableForEveryElement",
+
+			rarity:   5,
+			level:    20,
+			statType: DENDRO_DMG_BONUS,
+
+			expectedValue: 46.6,
+		},
+		{
+			name: "ShouldReturnMaxValueForFourStarCritDMG",
+
+			rarity:   4,
+			level:    16,
+			statType: CRIT_DMG,
+
+			expectedValue: 46.4,
+		},
+		{
+			name: "ShouldReturnErrorWhenLevelExceedsMaxLevel",
+
+			rarity:   4,
+			level:    17,
+			statType: CRIT_DMG,
+
+			expectedError: ErrInvalidLevel,
+		},
+		{
+			name: "ShouldReturnErrorWhenInvalidRarity",
+
+			rarity:   6,
+			level:    0,
+			statType: HP_FLAT,
+
+			expectedError: ErrInvalidRarity,
+		},
+		{
+			name: "ShouldReturnErrorWhenLegacyElementalDMGBonus",
+
+			rarity:   5,
+			level:    0,
+			statType: ELEMENTAL_DMG_BONUS,
+
+			expectedError: ErrInvalidPrimaryStatType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := MainStatValue(tt.rarity, tt.level, tt.statType)
+
+			if value != tt.expectedValue {
+				t.Errorf("MainStatValue() = %v, expected %v", value, tt.expectedValue)
+			}
+
+			if !errors.Is(err, tt.expectedError) {
+				t.Errorf("MainStatValue() error = %v, expectedError %v", err, tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestValidatePrimaryStatValue(t *testing.T) {
+	tests := []struct {
+		name string
+
+		// WHEN
+		rarity      int
+		level       int
+		primaryStat PrimaryStat
+
+		// THEN
+		expectedError error
+	}{
+		{
+			name: "ShouldAcceptExactValue",
+
+			rarity:      5,
+			level:       20,
+			primaryStat: PrimaryStat{Type: ATK_PERCENT, Value: 46.6},
+		},
+		{
+			name: "ShouldAcceptValueWithinPercentTolerance",
+
+			rarity:      5,
+			level:       13,
+			primaryStat: PrimaryStat{Type: ATK_PERCENT, Value: 32.7},
+		},
+		{
+			name: "ShouldAcceptValueWithinFlatTolerance",
+
+			rarity:      5,
+			level:       20,
+			primaryStat: PrimaryStat{Type: ELEMENTAL_MASTERY, Value: 187},
+		},
+		{
+			name: "ShouldReturnErrorWhenDecimalPointIsMisplaced",
+
+			rarity:      5,
+			level:       20,
+			primaryStat: PrimaryStat{Type: ATK_PERCENT, Value: 4.66},
+
+			expectedError: ErrPrimaryStatValueMismatch,
+		},
+		{
+			name: "ShouldReturnErrorWhenValueBelongsToAnotherLevel",
+
+			rarity:      5,
+			level:       16,
+			primaryStat: PrimaryStat{Type: HP_FLAT, Value: 4780},
+
+			expectedError: ErrPrimaryStatValueMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePrimaryStatValue(tt.rarity, tt.level, tt.primaryStat)
+
+			if !errors.Is(err, tt.expectedError) {
+				t.Errorf("validatePrimaryStatValue() error = %v, expectedError %v", err, tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestLoadMainStatTable(t *testing.T) {
+	tests := []struct {
+		name string
+
+		// WHEN
+		data string
+
+		// THEN
+		expectError bool
+	}{
+		{
+			name: "ShouldLoadEmbeddedTable",
+
+			data: string(mainStatTableJSON),
+		},
+		{
+			name: "ShouldReturnErrorWhenLevelCountMismatch",
+
+			data: `{"main_stats": [{"rarity": 1, "values": {"HP_FLAT": [129, 430]}}]}`,
+
+			expectError: true,
+		},
+		{
+			name: "ShouldReturnErrorWhenInvalidStat",
+
+			data: `{"main_stats": [{"rarity": 1, "values": {"INVALID": [1, 2, 3, 4, 5]}}]}`,
+
+			expectError: true,
+		},
+		{
+			name: "ShouldReturnErrorWhenInvalidRarity",
+
+			data: `{"main_stats": [{"rarity": 6, "values": {}}]}`,
+
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadMainStatTable([]byte(tt.data))
+
+			if (err != nil) != tt.expectError {
+				t.Errorf("loadMainStatTable() error = %v, expectError %v", err, tt.expectError)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrInvalidRarity              = errors.New("invalid rarity")
+	ErrRarityExceedsSetMaxRarity  = errors.New("rarity exceeds max rarity of artifact set")
+	ErrInvalidLevel               = errors.New("invalid level")
+	ErrSubstatCountMismatchRarity = errors.New("number of substats is inconsistent with rarity and level")
+)
+
+const (
+	MinRarity = 1
+	MaxRarity = 5
+
+	// MaxSubstats は 1 つの聖遺物が持てるサブステータスの最大数
+	MaxSubstats = 4
+
+	// levelsPerUpgrade ごとにサブステータスが追加または強化される
+	levelsPerUpgrade = 4
+)
+
+type rarityRule struct {
+	maxLevel           int
+	minInitialSubstats int
+	maxInitialSubstats int
+}
+
+var rarityRules = map[int]rarityRule{
+	1: {maxLevel: 4, minInitialSubstats: 0, maxInitialSubstats: 0},
+	2: {maxLevel: 4, minInitialSubstats: 0, maxInitialSubstats: 1},
+	3: {maxLevel: 12, minInitialSubstats: 1, maxInitialSubstats: 2},
+	4: {maxLevel: 16, minInitialSubstats: 2, maxInitialSubstats: 3},
+	5: {maxLevel: 20, minInitialSubstats: 3, maxInitialSubstats: 4},
+}
+
+// MaxLevel はレアリティごとの最大レベルを返す。
+func MaxLevel(rarity int) (int, error) {
+	rule, ok := rarityRules[rarity]
+	if !ok {
+		return 0, ErrInvalidRarity
+	}
+	return rule.maxLevel, nil
+}
+
+// SubstatCountRange はレアリティとレベルから取り得るサブステータス数の範囲を返す。
+// 初期数に強化回数を足したものを MaxSubstats で打ち切る。
+func SubstatCountRange(rarity, level int) (int, int, error) {
+	rule, ok := rarityRules[rarity]
+	if !ok {
+		return 0, 0, ErrInvalidRarity
+	}
+	if level < 0 || level > rule.maxLevel {
+		return 0, 0, ErrInvalidLevel
+	}
+
+	upgrades := level / levelsPerUpgrade
+	return min(rule.minInitialSubstats+upgrades, MaxSubstats), min(rule.maxInitialSubstats+upgrades, MaxSubstats), nil
+}
+
+func validateRarityAndLevel(artifactSet *ArtifactSetInfo, rarity, level, substatCount int) error {
+	if rarity < MinRarity || rarity > MaxRarity {
+		return fmt.Errorf("%w: %d", ErrInvalidRarity, rarity)
+	}
+	if rarity > artifactSet.MaxRarity {
+		return fmt.Errorf("%w: %s is up to %d stars", ErrRarityExceedsSetMaxRarity, artifactSet.Key, artifactSet.MaxRarity)
+	}
+
+	maxLevel, err := MaxLevel(rarity)
+	if err != nil {
+		return err
+	}
+	if level < 0 || level > maxLevel {
+		return fmt.Errorf("%w: %d-star artifacts range from +0 to +%d, got %d", ErrInvalidLevel, rarity, maxLevel, level)
+	}
+
+	minSubstats, maxSubstats, err := SubstatCountRange(rarity, level)
+	if err != nil {
+		return err
+	}
+	if substatCount < minSubstats || substatCount > maxSubstats {
+		return fmt.Errorf("%w: %d-star +%d expects %d to %d substats, got %d", ErrSubstatCountMismatchRarity, rarity, level, minSubstats, maxSubstats, substatCount)
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSubstatCountRange(t *testing.T) {
+	tests := []struct {
+		name string
+
+		// WHEN
+		rarity int
+		level  int
+
+		// THEN
+		expectedMin   int
+		expectedMax   int
+		expectedError error
+	}{
+		{
+			name: "ShouldReturnInitialRangeForFiveStarAtLevelZero",
+
+			rarity: 5,
+			level:  0,
+
+			expectedMin: 3,
+			expectedMax: 4,
+		},
+		{
+			name: "ShouldReturnExactlyFourForFiveStarAtMaxLevel",
+
+			rarity: 5,
+			level:  20,
+
+			expectedMin: 4,
+			expectedMax: 4,
+		},
+		{
+			name: "ShouldAddUpgradesForFourStar",
+
+			rarity: 4,
+			level:  4,
+
+			expectedMin: 3,
+			expectedMax: 4,
+		},
+		{
+			name: "ShouldReturnZeroForOneStarAtLevelZero",
+
+			rarity: 1,
+			level:  0,
+
+			expectedMin: 0,
+			expectedMax: 0,
+		},
+		{
+			name: "ShouldReturnErrorWhenLevelExceedsMaxLevel",
+
+			rarity: 3,
+			level:  16,
+
+			expectedError: ErrInvalidLevel,
+		},
+		{
+			name: "ShouldReturnErrorWhenRarityIsInvalid",
+
+			rarity: 6,
+			level:  0,
+
+			expectedError: ErrInvalidRarity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			minSubstats, maxSubstats, err := SubstatCountRange(tt.rarity, tt.level)
+
+			if !errors.Is(err, tt.expectedError) {
+				t.Errorf("SubstatCountRange() error = %v, expectedError %v", err, tt.expectedError)
+			}
+			if minSubstats != tt.expectedMin || maxSubstats != tt.expectedMax {
+				t.Errorf("SubstatCountRange() = (%d, %d), expected (%d, %d)", minSubstats, maxSubstats, tt.expectedMin, tt.expectedMax)
+			}
+		})
+	}
+}
+
+func TestNewArtifactRarityAndLevel(t *testing.T) {
+	fourSubstats := []Substat{
+		{Type: SUBSTAT_CRIT_RATE, Value: 10.5},
+		{Type: SUBSTAT_CRIT_DMG, Value: 21.0},
+		{Type: SUBSTAT_ATK_PERCENT, Value: 9.9},
+		{Type: SUBSTAT_ENERGY_RECHARGE, Value: 6.5},
+	}
+
+	tests := []struct {
+		name string
+
+		// WHEN
+		artifactSet ArtifactSet
+		rarity      int
+		level       int
+		substats    []Substat
+
+		// THEN
+		expectedError error
+	}{
+		{
+			name: "ShouldNewFiveStarMaxLevelArtifactSuccessfully",
+
+			artifactSet: ARTIFACT_SET_GLADIATORS_FINALOFFERING,
+			rarity:      5,
+			level:       20,
+			substats:    fourSubstats,
+		},
+		{
+			name: "ShouldReturnErrorWhenRarityIsZero",
+
+			artifactSet: ARTIFACT_SET_GLADIATORS_FINALOFFERING,
+			rarity:      0,
+			level:       0,
+			substats:    fourSubstats,
+
+			expectedError: ErrInvalidRarity,
+		},
+		{
+			name: "ShouldReturnErrorWhenRarityExceedsSetMaxRarity",
+
+			artifactSet: "Adventurer",
+			rarity:      5,
+			level:       0,
+			substats:    fourSubstats,
+
+			expectedError: ErrRarityExceedsSetMaxRarity,
+		},
+		{
+			name: "ShouldReturnErrorWhenLevelIsNegative",
+
+			artifactSet: ARTIFACT_SET_GLADIATORS_FINALOFFERING,
+			rarity:      5,
+			level:       -1,
+			substats:    fourSubstats,
+
+			expectedError: ErrInvalidLevel,
+		},
+		{
+			name: "ShouldReturnErrorWhenLevelExceedsRarityMaxLevel",
+
+			artifactSet: ARTIFACT_SET_GLADIATORS_FINALOFFERING,
+			rarity:      4,
+			level:       20,
+			substats:    fourSubstats,
+
+			expectedError: ErrInvalidLevel,
+		},
+		{
+			name: "ShouldReturnErrorWhenMaxLevelFiveStarHasThreeSubstats",
+
+			artifactSet: ARTIFACT_SET_GLADIATORS_FINALOFFERING,
+			rarity:      5,
+			level:       20,
+			substats:    fourSubstats[:3],
+
+			expectedError: ErrSubstatCountMismatchRarity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewArtifact(
+				"test-id",
+				string(tt.artifactSet),
+				string(ARTIFACT_TYPE_FLOWER),
+				tt.rarity,
+				tt.level,
+				PrimaryStat{Type: HP_FLAT, Value: 4780},
+				tt.substats,
+			)
+
+			if !errors.Is(err, tt.expectedError) {
+				t.Errorf("NewArtifact() error = %v, expectedError %v", err, tt.expectedError)
+			}
+		})
+	}
+}
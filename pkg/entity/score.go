@@ -0,0 +1,97 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+var (
+	ErrInvalidScoreProfileName   = errors.New("score profile name cannot be empty")
+	ErrInvalidScoreProfileWeight = errors.New("invalid score profile weight")
+)
+
+// CritValue はサブステータスの会心率と会心ダメージから 2×会心率 + 会心ダメージ を返す。
+// メインステータスの会心は部位の選択で決まるため含めない。
+func CritValue(artifact *Artifact) float64 {
+	critValue := 0.0
+	for _, substat := range artifact.Substats {
+		switch substat.Type {
+		case SUBSTAT_CRIT_RATE:
+			critValue += 2 * substat.Value
+		case SUBSTAT_CRIT_DMG:
+			critValue += substat.Value
+		}
+	}
+	return critValue
+}
+
+// RollValue はサブステータスの値を最大段階 1 回分に対する割合で合計し、百分率で返す。
+// 最大段階のロールが 1 回あるごとに 100 増える。
+func RollValue(artifact *Artifact) (float64, error) {
+	rollValue := 0.0
+	for _, substat := range artifact.Substats {
+		rolls, err := maxRollEquivalent(artifact.Rarity, substat)
+		if err != nil {
+			return 0, err
+		}
+		rollValue += rolls * 100
+	}
+	return rollValue, nil
+}
+
+func maxRollEquivalent(rarity int, substat Substat) (float64, error) {
+	tiers, err := lookupSubstatRollTiers(rarity, substat.Type)
+	if err != nil {
+		return 0, err
+	}
+	return substat.Value / tiers[len(tiers)-1], nil
+}
+
+// ScoreProfile はサブステータスごとの重みで聖遺物を採点する。
+// 重みを指定しないサブステータスは 0 として扱う。
+type ScoreProfile struct {
+	Name    string
+	Weights map[SubstatType]float64
+}
+
+func NewScoreProfile(name string, weights map[string]float64) (*ScoreProfile, error) {
+	if name == "" {
+		return nil, ErrInvalidScoreProfileName
+	}
+
+	profileWeights := make(map[SubstatType]float64, len(weights))
+	for substatType, weight := range weights {
+		substat, err := NewSubstat(substatType, 0)
+		if err != nil {
+			return nil, err
+		}
+		if math.IsNaN(weight) || math.IsInf(weight, 0) || weight < 0 {
+			return nil, fmt.Errorf("%w: %s %g", ErrInvalidScoreProfileWeight, substatType, weight)
+		}
+		profileWeights[substat.Type] = weight
+	}
+
+	return &ScoreProfile{
+		Name:    name,
+		Weights: profileWeights,
+	}, nil
+}
+
+// Score はサブステータスを最大段階のロール回数に換算し、重みを掛けて合計する。
+// 例えば会心ダメージの重みが 1 なら、最大段階の会心ダメージ 1 回で 1 点になる。
+func (p *ScoreProfile) Score(artifact *Artifact) (float64, error) {
+	score := 0.0
+	for _, substat := range artifact.Substats {
+		weight, ok := p.Weights[substat.Type]
+		if !ok || weight == 0 {
+			continue
+		}
+		rolls, err := maxRollEquivalent(artifact.Rarity, substat)
+		if err != nil {
+			return 0, err
+		}
+		score += weight * rolls
+	}
+	return score, nil
+}
@@ -0,0 +1,250 @@
+package entity
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCritValue(t *testing.T) {
+	tests := []struct {
+		name string
+
+		// WHEN
+		artifact *Artifact
+
+		// THEN
+		expectedCritValue float64
+	}{
+		{
+			name: "ShouldDoubleCritRateAndAddCritDMG",
+
+			artifact: &Artifact{
+				Substats: []Substat{
+					{Type: SUBSTAT_CRIT_RATE, Value: 10.5},
+					{Type: SUBSTAT_CRIT_DMG, Value: 21.0},
+					{Type: SUBSTAT_ATK_PERCENT, Value: 5.8},
+				},
+			},
+
+			expectedCritValue: 42.0,
+		},
+		{
+			name: "ShouldIgnoreCritPrimaryStat",
+
+			artifact: &Artifact{
+				PrimaryStat: PrimaryStat{Type: CRIT_DMG, Value: 62.2},
+				Substats:    []Substat{{Type: SUBSTAT_CRIT_RATE, Value: 3.9}},
+			},
+
+			expectedCritValue: 7.8,
+		},
+		{
+			name: "ShouldReturnZeroWithoutCritSubstats",
+
+			artifact: &Artifact{
+				Substats: []Substat{{Type: SUBSTAT_HP_FLAT, Value: 299}},
+			},
+
+			expectedCritValue: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if critValue := CritValue(tt.artifact); math.Abs(critValue-tt.expectedCritValue) > 1e-9 {
+				t.Errorf("CritValue() = %v, expected %v", critValue, tt.expectedCritValue)
+			}
+		})
+	}
+}
+
+func TestRollValue(t *testing.T) {
+	tests := []struct {
+		name string
+
+		// WHEN
+		artifact *Artifact
+
+		// THEN
+		expectedRollValue float64
+		expectedError     error
+	}{
+		{
+			name: "ShouldReturnHundredPerMaxRoll",
+
+			artifact: &Artifact{
+				Rarity: 5,
+				Substats: []Substat{
+					{Type: SUBSTAT_CRIT_RATE, Value: 3.89},
+					{Type: SUBSTAT_CRIT_DMG, Value: 15.54},
+				},
+			},
+
+			expectedRollValue: 300,
+		},
+		{
+			name: "ShouldUseRollTiersOfRarity",
+
+			artifact: &Artifact{
+				Rarity:   4,
+				Substats: []Substat{{Type: SUBSTAT_CRIT_DMG, Value: 6.22}},
+			},
+
+			expectedRollValue: 100,
+		},
+		{
+			name: "ShouldReturnErrorWhenRarityIsInvalid",
+
+			artifact: &Artifact{
+				Substats: []Substat{{Type: SUBSTAT_CRIT_DMG, Value: 6.22}},
+			},
+
+			expectedError: ErrInvalidRarity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rollValue, err := RollValue(tt.artifact)
+
+			if math.Abs(rollValue-tt.expectedRollValue) > 1e-9 {
+				t.Errorf("RollValue() = %v, expected %v", rollValue, tt.expectedRollValue)
+			}
+
+			if !errors.Is(err, tt.expectedError) {
+				t.Errorf("RollValue() error = %v, expectedError %v", err, tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestNewScoreProfile(t *testing.T) {
+	tests := []struct {
+		name string
+
+		// WHEN
+		profileName string
+		weights     map[string]float64
+
+		// THEN
+		expectedProfile *ScoreProfile
+		expectedError   error
+	}{
+		{
+			name: "ShouldNewScoreProfileSuccessfully",
+
+			profileName: "HuTao",
+			weights:     map[string]float64{"CRIT_DMG": 1, "CRIT_RATE": 1, "HP_PERCENT": 0.8, "ELEMENTAL_MASTERY": 0.6},
+
+			expectedProfile: &ScoreProfile{
+				Name: "HuTao",
+				Weights: map[SubstatType]float64{
+					SUBSTAT_CRIT_DMG:          1,
+					SUBSTAT_CRIT_RATE:         1,
+					SUBSTAT_HP_PERCENT:        0.8,
+					SUBSTAT_ELEMENTAL_MASTERY: 0.6,
+				},
+			},
+		},
+		{
+			name: "ShouldReturnErrorWhenNameIsEmpty",
+
+			profileName: "",
+
+			expectedError: ErrInvalidScoreProfileName,
+		},
+		{
+			name: "ShouldReturnErrorWhenSubstatTypeIsInvalid",
+
+			profileName: "HuTao",
+			weights:     map[string]float64{"HEALING_BONUS": 1},
+
+			expectedError: ErrInvalidSubstatType,
+		},
+		{
+			name: "ShouldReturnErrorWhenWeightIsNegative",
+
+			profileName: "HuTao",
+			weights:     map[string]float64{"CRIT_DMG": -1},
+
+			expectedError: ErrInvalidScoreProfileWeight,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile, err := NewScoreProfile(tt.profileName, tt.weights)
+
+			if diff := cmp.Diff(tt.expectedProfile, profile); diff != "" {
+				t.Errorf("NewScoreProfile() mismatch (-want +got):\n%s", diff)
+			}
+
+			if !errors.Is(err, tt.expectedError) {
+				t.Errorf("NewScoreProfile() error = %v, expectedError %v", err, tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestScoreProfileScore(t *testing.T) {
+	profile := &ScoreProfile{
+		Name: "HuTao",
+		Weights: map[SubstatType]float64{
+			SUBSTAT_CRIT_DMG:   1,
+			SUBSTAT_CRIT_RATE:  1,
+			SUBSTAT_HP_PERCENT: 0.5,
+		},
+	}
+
+	tests := []struct {
+		name string
+
+		// WHEN
+		artifact *Artifact
+
+		// THEN
+		expectedScore float64
+		expectedError error
+	}{
+		{
+			name: "ShouldWeightMaxRollEquivalents",
+
+			artifact: &Artifact{
+				Rarity: 5,
+				Substats: []Substat{
+					{Type: SUBSTAT_CRIT_RATE, Value: 7.78},
+					{Type: SUBSTAT_HP_PERCENT, Value: 11.66},
+					{Type: SUBSTAT_DEF_FLAT, Value: 23.15},
+				},
+			},
+
+			expectedScore: 3,
+		},
+		{
+			name: "ShouldReturnErrorWhenRarityIsInvalid",
+
+			artifact: &Artifact{
+				Substats: []Substat{{Type: SUBSTAT_CRIT_RATE, Value: 3.89}},
+			},
+
+			expectedError: ErrInvalidRarity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, err := profile.Score(tt.artifact)
+
+			if math.Abs(score-tt.expectedScore) > 1e-9 {
+				t.Errorf("Score() = %v, expected %v", score, tt.expectedScore)
+			}
+
+			if !errors.Is(err, tt.expectedError) {
+				t.Errorf("Score() error = %v, expectedError %v", err, tt.expectedError)
+			}
+		})
+	}
+}
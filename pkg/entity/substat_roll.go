@@ -0,0 +1,269 @@
+package entity
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+)
+
+var (
+	ErrImpossibleSubstatValue   = errors.New("substat value cannot be produced by any roll combination")
+	ErrSubstatRollCountMismatch = errors.New("substat roll counts are inconsistent with rarity and level")
+)
+
+const (
+	// flatSubstatTolerance はゲーム内で整数表示されるサブステータスの許容誤差
+	flatSubstatTolerance = 0.5
+	// percentSubstatTolerance は小数第 1 位まで表示されるサブステータスの許容誤差
+	percentSubstatTolerance = 0.05
+
+	// substatTierPrecision はテーブルに記載した段階値の丸め幅で、ロール数に比例して誤差が積み上がる
+	substatTierPrecision = 0.005
+)
+
+//go:embed data/substat_rolls.json
+var substatRollTableJSON []byte
+
+// substatRollTable はレアリティ・サブステータスごとに 1 回のロールで上昇する値を小さい順に持つ。
+type substatRollTable map[int]map[SubstatType][]float64
+
+var defaultSubstatRollTable = mustLoadSubstatRollTable(substatRollTableJSON)
+
+func mustLoadSubstatRollTable(data []byte) substatRollTable {
+	table, err := loadSubstatRollTable(data)
+	if err != nil {
+		panic(fmt.Sprintf("invalid embedded substat roll table: %v", err))
+	}
+	return table
+}
+
+func loadSubstatRollTable(data []byte) (substatRollTable, error) {
+	var file struct {
+		SubstatRolls []struct {
+			Rarity int                       `json:"rarity"`
+			Tiers  map[SubstatType][]float64 `json:"tiers"`
+		} `json:"substat_rolls"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+
+	table := make(substatRollTable, len(file.SubstatRolls))
+	for _, entry := range file.SubstatRolls {
+		if _, err := MaxLevel(entry.Rarity); err != nil {
+			return nil, fmt.Errorf("substat roll table has invalid rarity %d", entry.Rarity)
+		}
+		if _, exists := table[entry.Rarity]; exists {
+			return nil, fmt.Errorf("duplicated substat roll table for rarity %d", entry.Rarity)
+		}
+		for substatType, tiers := range entry.Tiers {
+			if _, err := NewSubstat(string(substatType), 0); err != nil {
+				return nil, fmt.Errorf("substat roll table for rarity %d has invalid stat %s", entry.Rarity, substatType)
+			}
+			if len(tiers) == 0 || !slices.IsSorted(tiers) || tiers[0] <= 0 {
+				return nil, fmt.Errorf("substat roll table for %d-star %s must be positive and ascending", entry.Rarity, substatType)
+			}
+		}
+		table[entry.Rarity] = entry.Tiers
+	}
+	return table, nil
+}
+
+// SubstatRollTiers はレアリティごとのサブステータス 1 回分の上昇値を小さい順に返す。
+func SubstatRollTiers(rarity int, substatType SubstatType) ([]float64, error) {
+	tiers, err := lookupSubstatRollTiers(rarity, substatType)
+	if err != nil {
+		return nil, err
+	}
+	return slices.Clone(tiers), nil
+}
+
+func lookupSubstatRollTiers(rarity int, substatType SubstatType) ([]float64, error) {
+	values, ok := defaultSubstatRollTable[rarity]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidRarity, rarity)
+	}
+	tiers, ok := values[substatType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidSubstatType, substatType)
+	}
+	return tiers, nil
+}
+
+// substatTolerance は表示桁の違いとロールの積み上げによる誤差を吸収するための許容幅を返す。
+func substatTolerance(substatType SubstatType, rolls int) float64 {
+	tolerance := percentSubstatTolerance
+	switch substatType {
+	case SUBSTAT_HP_FLAT, SUBSTAT_ATK_FLAT, SUBSTAT_DEF_FLAT, SUBSTAT_ELEMENTAL_MASTERY:
+		tolerance = flatSubstatTolerance
+	}
+	return tolerance + float64(rolls)*substatTierPrecision
+}
+
+// SubstatRolls はサブステータスの値をロールに分解した結果を表す。
+type SubstatRolls struct {
+	Type SubstatType
+	// Tiers は各ロールの上昇値を大きい順に並べたもの
+	Tiers []float64
+}
+
+// Count はロール回数を返す。初期値も 1 回として数える。
+func (r SubstatRolls) Count() int {
+	return len(r.Tiers)
+}
+
+type substatRollCandidate struct {
+	tiers       []float64
+	probability float64
+}
+
+// substatRollCandidates はロール回数ごとに、値を再現できる最も確からしい段階の組み合わせと
+// その回数で値が出る確率を返す。各段階は等確率で選ばれるものとする。
+func substatRollCandidates(substat Substat, tiers []float64, maxRolls int) map[int]substatRollCandidate {
+	candidates := make(map[int]substatRollCandidate)
+	for rolls := 1; rolls <= maxRolls; rolls++ {
+		tolerance := substatTolerance(substat.Type, rolls)
+		outcomes := math.Pow(float64(len(tiers)), float64(rolls))
+
+		var best substatRollCandidate
+		var bestPermutations float64
+		counts := make([]int, len(tiers))
+		var walk func(tier, remaining int)
+		walk = func(tier, remaining int) {
+			if tier == len(tiers)-1 {
+				counts[tier] = remaining
+				defer func() { counts[tier] = 0 }()
+
+				sum := 0.0
+				for i, count := range counts {
+					sum += tiers[i] * float64(count)
+				}
+				if math.Abs(sum-substat.Value) > tolerance {
+					return
+				}
+
+				permutations := multinomial(rolls, counts)
+				best.probability += permutations / outcomes
+				if permutations > bestPermutations {
+					bestPermutations = permutations
+					best.tiers = expandTiers(tiers, counts)
+				}
+				return
+			}
+			for count := remaining; count >= 0; count-- {
+				counts[tier] = count
+				walk(tier+1, remaining-count)
+			}
+			counts[tier] = 0
+		}
+		walk(0, rolls)
+
+		if best.tiers != nil {
+			candidates[rolls] = best
+		}
+	}
+	return candidates
+}
+
+func multinomial(n int, counts []int) float64 {
+	result := factorial(n)
+	for _, count := range counts {
+		result /= factorial(count)
+	}
+	return result
+}
+
+func factorial(n int) float64 {
+	result := 1.0
+	for i := 2; i <= n; i++ {
+		result *= float64(i)
+	}
+	return result
+}
+
+func expandTiers(tiers []float64, counts []int) []float64 {
+	expanded := make([]float64, 0, len(tiers))
+	for i := len(tiers) - 1; i >= 0; i-- {
+		for range counts[i] {
+			expanded = append(expanded, tiers[i])
+		}
+	}
+	return expanded
+}
+
+// DecomposeSubstats はサブステータスの値をロール回数と段階の組み合わせに分解する。
+// 個々の値を再現できる組み合わせのうち、ロール回数の合計がレアリティとレベルに合うもので
+// 最も確からしいものを選ぶ。
+func DecomposeSubstats(rarity, level int, substats []Substat) ([]SubstatRolls, error) {
+	rule, ok := rarityRules[rarity]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidRarity, rarity)
+	}
+	if level < 0 || level > rule.maxLevel {
+		return nil, fmt.Errorf("%w: %d-star artifacts range from +0 to +%d, got %d", ErrInvalidLevel, rarity, rule.maxLevel, level)
+	}
+
+	// 初期サブステータス数が 4 未満の間は、強化のたびにサブステータスが 1 つ追加される
+	upgrades := level / levelsPerUpgrade
+	allowedTotals := make(map[int]struct{})
+	for initial := rule.minInitialSubstats; initial <= rule.maxInitialSubstats; initial++ {
+		if min(initial+upgrades, MaxSubstats) == len(substats) {
+			allowedTotals[initial+upgrades] = struct{}{}
+		}
+	}
+	if len(allowedTotals) == 0 {
+		return nil, fmt.Errorf("%w: %d-star +%d cannot have %d substats", ErrSubstatCountMismatchRarity, rarity, level, len(substats))
+	}
+
+	candidates := make([]map[int]substatRollCandidate, len(substats))
+	for i, substat := range substats {
+		tiers, err := lookupSubstatRollTiers(rarity, substat.Type)
+		if err != nil {
+			return nil, err
+		}
+		candidates[i] = substatRollCandidates(substat, tiers, 1+upgrades)
+		if len(candidates[i]) == 0 {
+			return nil, fmt.Errorf("%w: %d-star %s %g", ErrImpossibleSubstatValue, rarity, substat.Type, substat.Value)
+		}
+	}
+
+	// ロール回数の組み合わせは高々 6^4 通りなので全探索する
+	var bestRolls []int
+	bestProbability := 0.0
+	chosen := make([]int, len(substats))
+	var search func(i, total int, probability float64)
+	search = func(i, total int, probability float64) {
+		if i == len(substats) {
+			if _, ok := allowedTotals[total]; ok && probability > bestProbability {
+				bestProbability = probability
+				bestRolls = slices.Clone(chosen)
+			}
+			return
+		}
+		for rolls := 1; rolls <= 1+upgrades; rolls++ {
+			candidate, ok := candidates[i][rolls]
+			if !ok {
+				continue
+			}
+			chosen[i] = rolls
+			search(i+1, total+rolls, probability*candidate.probability)
+		}
+	}
+	search(0, 0, 1)
+
+	if bestRolls == nil && len(substats) > 0 {
+		return nil, fmt.Errorf("%w: %d-star +%d", ErrSubstatRollCountMismatch, rarity, level)
+	}
+
+	result := make([]SubstatRolls, len(substats))
+	for i, substat := range substats {
+		result[i] = SubstatRolls{
+			Type:  substat.Type,
+			Tiers: candidates[i][bestRolls[i]].tiers,
+		}
+	}
+	return result, nil
+}
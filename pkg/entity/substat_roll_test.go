@@ -0,0 +1,247 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSubstatRollTiers(t *testing.T) {
+	tests := []struct {
+		name string
+
+		// WHEN
+		rarity      int
+		substatType SubstatType
+
+		// THEN
+		expectedTiers []float64
+		expectedError error
+	}{
+		{
+			name: "ShouldReturnFourTiersForFiveStarCritRate",
+
+			rarity:      5,
+			substatType: SUBSTAT_CRIT_RATE,
+
+			expectedTiers: []float64{2.72, 3.11, 3.50, 3.89},
+		},
+		{
+			name: "ShouldReturnTwoTiersForOneStarCritDMG",
+
+			rarity:      1,
+			substatType: SUBSTAT_CRIT_DMG,
+
+			expectedTiers: []float64{1.55, 1.94},
+		},
+		{
+			name: "ShouldReturnErrorWhenInvalidRarity",
+
+			rarity:      0,
+			substatType: SUBSTAT_CRIT_RATE,
+
+			expectedError: ErrInvalidRarity,
+		},
+		{
+			name: "ShouldReturnErrorWhenInvalidSubstatType",
+
+			rarity:      5,
+			substatType: "INVALID_TYPE",
+
+			expectedError: ErrInvalidSubstatType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tiers, err := SubstatRollTiers(tt.rarity, tt.substatType)
+
+			if diff := cmp.Diff(tt.expectedTiers, tiers); diff != "" {
+				t.Errorf("SubstatRollTiers() mismatch (-want +got):\n%s", diff)
+			}
+
+			if !errors.Is(err, tt.expectedError) {
+				t.Errorf("SubstatRollTiers() error = %v, expectedError %v", err, tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestDecomposeSubstats(t *testing.T) {
+	tests := []struct {
+		name string
+
+		// WHEN
+		rarity   int
+		level    int
+		substats []Substat
+
+		// THEN
+		expectedRolls []SubstatRolls
+		expectedError error
+	}{
+		{
+			name: "ShouldDecomposeSingleRollsAtLevelZero",
+
+			rarity: 5,
+			level:  0,
+			substats: []Substat{
+				{Type: SUBSTAT_CRIT_RATE, Value: 3.9},
+				{Type: SUBSTAT_CRIT_DMG, Value: 5.4},
+				{Type: SUBSTAT_HP_FLAT, Value: 239},
+			},
+
+			expectedRolls: []SubstatRolls{
+				{Type: SUBSTAT_CRIT_RATE, Tiers: []float64{3.89}},
+				{Type: SUBSTAT_CRIT_DMG, Tiers: []float64{5.44}},
+				{Type: SUBSTAT_HP_FLAT, Tiers: []float64{239.00}},
+			},
+		},
+		{
+			name: "ShouldDecomposeMaxLevelArtifact",
+
+			rarity: 5,
+			level:  20,
+			substats: []Substat{
+				{Type: SUBSTAT_CRIT_RATE, Value: 3.9},
+				{Type: SUBSTAT_CRIT_DMG, Value: 21.0},
+				{Type: SUBSTAT_ATK_PERCENT, Value: 15.2},
+				{Type: SUBSTAT_ENERGY_RECHARGE, Value: 11.0},
+			},
+
+			expectedRolls: []SubstatRolls{
+				{Type: SUBSTAT_CRIT_RATE, Tiers: []float64{3.89}},
+				{Type: SUBSTAT_CRIT_DMG, Tiers: []float64{7.77, 6.99, 6.22}},
+				{Type: SUBSTAT_ATK_PERCENT, Tiers: []float64{5.83, 5.25, 4.08}},
+				{Type: SUBSTAT_ENERGY_RECHARGE, Tiers: []float64{6.48, 4.53}},
+			},
+		},
+		{
+			name: "ShouldPreferRollCountsThatMatchLevel",
+
+			rarity: 5,
+			level:  20,
+			substats: []Substat{
+				{Type: SUBSTAT_CRIT_RATE, Value: 3.9},
+				{Type: SUBSTAT_CRIT_DMG, Value: 22.5},
+				{Type: SUBSTAT_ATK_PERCENT, Value: 5.8},
+				{Type: SUBSTAT_ENERGY_RECHARGE, Value: 11.0},
+			},
+
+			expectedRolls: []SubstatRolls{
+				{Type: SUBSTAT_CRIT_RATE, Tiers: []float64{3.89}},
+				{Type: SUBSTAT_CRIT_DMG, Tiers: []float64{6.22, 5.44, 5.44, 5.44}},
+				{Type: SUBSTAT_ATK_PERCENT, Tiers: []float64{5.83}},
+				{Type: SUBSTAT_ENERGY_RECHARGE, Tiers: []float64{6.48, 4.53}},
+			},
+		},
+		{
+			name: "ShouldReturnErrorWhenValueIsBetweenRollCombinations",
+
+			rarity: 5,
+			level:  0,
+			substats: []Substat{
+				{Type: SUBSTAT_CRIT_RATE, Value: 4.5},
+				{Type: SUBSTAT_CRIT_DMG, Value: 7.8},
+				{Type: SUBSTAT_ATK_PERCENT, Value: 5.8},
+			},
+
+			expectedError: ErrImpossibleSubstatValue,
+		},
+		{
+			name: "ShouldReturnErrorWhenValueNeedsMoreRollsThanLevelAllows",
+
+			rarity: 5,
+			level:  0,
+			substats: []Substat{
+				{Type: SUBSTAT_CRIT_RATE, Value: 7.8},
+				{Type: SUBSTAT_CRIT_DMG, Value: 7.8},
+				{Type: SUBSTAT_ATK_PERCENT, Value: 5.8},
+			},
+
+			expectedError: ErrImpossibleSubstatValue,
+		},
+		{
+			name: "ShouldReturnErrorWhenTotalRollsDoNotMatchLevel",
+
+			rarity: 5,
+			level:  20,
+			substats: []Substat{
+				{Type: SUBSTAT_CRIT_RATE, Value: 3.9},
+				{Type: SUBSTAT_CRIT_DMG, Value: 7.8},
+				{Type: SUBSTAT_ATK_PERCENT, Value: 5.8},
+				{Type: SUBSTAT_ENERGY_RECHARGE, Value: 6.5},
+			},
+
+			expectedError: ErrSubstatRollCountMismatch,
+		},
+		{
+			name: "ShouldReturnErrorWhenSubstatCountDoesNotMatchLevel",
+
+			rarity: 5,
+			level:  20,
+			substats: []Substat{
+				{Type: SUBSTAT_CRIT_RATE, Value: 3.9},
+			},
+
+			expectedError: ErrSubstatCountMismatchRarity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rolls, err := DecomposeSubstats(tt.rarity, tt.level, tt.substats)
+
+			if diff := cmp.Diff(tt.expectedRolls, rolls); diff != "" {
+				t.Errorf("DecomposeSubstats() mismatch (-want +got):\n%s", diff)
+			}
+
+			if !errors.Is(err, tt.expectedError) {
+				t.Errorf("DecomposeSubstats() error = %v, expectedError %v", err, tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestLoadSubstatRollTable(t *testing.T) {
+	tests := []struct {
+		name string
+
+		// WHEN
+		data string
+
+		// THEN
+		expectError bool
+	}{
+		{
+			name: "ShouldLoadEmbeddedTable",
+
+			data: string(substatRollTableJSON),
+		},
+		{
+			name: "ShouldReturnErrorWhenTiersAreNotAscending",
+
+			data: `{"substat_rolls": [{"rarity": 1, "tiers": {"CRIT_RATE": [0.97, 0.78]}}]}`,
+
+			expectError: true,
+		},
+		{
+			name: "ShouldReturnErrorWhenInvalidStat",
+
+			data: `{"substat_rolls": [{"rarity": 1, "tiers": {"HEALING_BONUS": [1, 2]}}]}`,
+
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadSubstatRollTable([]byte(tt.data))
+
+			if (err != nil) != tt.expectError {
+				t.Errorf("loadSubstatRollTable() error = %v, expectError %v", err, tt.expectError)
+			}
+		})
+	}
+}
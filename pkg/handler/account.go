@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"fmt"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+var AccountLocationTemplate = "/accounts/%s"
+
+type CreateAccountRequestParam struct {
+	Name string `json:"name"`
+}
+
+// GetAccounts はリクエストの API キーで扱えるアカウントだけを返す。鍵のないリクエストには publicAccounts のアカウントだけを返す。
+func GetAccounts(accountService service.GetAccountsServiceInterface, publicAccounts []string) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		accounts, err := accountService.GetAccounts()
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		visibleAccounts := make([]*service.AccountDTO, 0, len(accounts))
+		for _, account := range accounts {
+			if canReadAccount(c, publicAccounts, account.Name) {
+				visibleAccounts = append(visibleAccounts, account)
+			}
+		}
+		c.JSON(200, visibleAccounts)
+	}
+}
+
+func GetAccount(accountService service.GetAccountServiceInterface) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		name := c.Param("account")
+
+		account, err := accountService.GetAccount(name)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		c.JSON(200, account)
+	}
+}
+
+func CreateAccount(accountService service.CreateAccountServiceInterface) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		var createAccountRequestParam CreateAccountRequestParam
+		if err := c.ShouldBindJSON(&createAccountRequestParam); err != nil {
+			c.Error(invalidRequestBodyError(err))
+			return
+		}
+
+		account, err := accountService.CreateAccount(service.CreateAccountCommand{Name: createAccountRequestParam.Name})
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		c.Header("Location", fmt.Sprintf(AccountLocationTemplate, account.Name))
+		c.JSON(201, account)
+	}
+}
+
+// ForAccount はパスの :account のアカウントのサービスで build が組み立てたハンドラーを実行する。
+// アカウントが存在しなければ 404 になり、build は呼ばれない。
+func ForAccount(resolver service.AccountServicesResolverInterface, build func(services *service.AccountServices) gin.HandlerFunc) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		services, err := resolver.AccountServices(c.Param("account"))
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		build(services)(c)
+	}
+}
@@ -0,0 +1,357 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/repository"
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGetAccounts(t *testing.T) {
+	testAccounts := []*service.AccountDTO{{Name: "alt"}, {Name: "default"}, {Name: "main"}}
+
+	tests := []struct {
+		name string
+
+		// GIVEN
+		principal      *service.Principal
+		publicAccounts []string
+
+		// THEN
+		expectedResponse string
+	}{
+		{
+			name: "ShouldListAllAccountsForAllAccountsKey",
+
+			principal: &service.Principal{KeyID: "admin", Scope: entity.API_KEY_SCOPE_READ_ONLY, Accounts: []string{entity.APIKeyAllAccounts}},
+
+			expectedResponse: `[{"name":"alt"},{"name":"default"},{"name":"main"}]`,
+		},
+		{
+			name: "ShouldListOnlyOwnAccountsForRestrictedKey",
+
+			principal:      &service.Principal{KeyID: "ro", Scope: entity.API_KEY_SCOPE_READ_ONLY, Accounts: []string{"alt", "main"}},
+			publicAccounts: []string{"default"},
+
+			expectedResponse: `[{"name":"alt"},{"name":"main"}]`,
+		},
+		{
+			name: "ShouldListOnlyPublicAccountsForAnonymousCaller",
+
+			publicAccounts: []string{"default"},
+
+			expectedResponse: `[{"name":"default"}]`,
+		},
+		{
+			name: "ShouldListNoAccountsForAnonymousCallerWithoutPublicAccounts",
+
+			expectedResponse: `[]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(func(c *gin.Context) {
+				if tt.principal != nil {
+					c.Set(principalContextKey, tt.principal)
+				}
+			})
+			r.GET("/accounts", GetAccounts(&service.MockGetAccountsService{
+				MockAccounts: testAccounts,
+			}, tt.publicAccounts))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/accounts", nil)
+			r.ServeHTTP(w, req)
+
+			if w.Code != 200 {
+				t.Errorf("Expected status code %d, got %d", 200, w.Code)
+			}
+
+			if diff := cmp.Diff(tt.expectedResponse, w.Body.String()); diff != "" {
+				t.Errorf("Response mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetAccount(t *testing.T) {
+	tests := []struct {
+		name string
+
+		// GIVEN
+		mockAccount         *service.AccountDTO
+		mockGetAccountError error
+
+		// WHEN
+		account string
+
+		// THEN
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name: "ShouldGetAccountSuccessfully",
+
+			mockAccount: &service.AccountDTO{Name: "alt"},
+
+			account: "alt",
+
+			expectedStatusCode: 200,
+			expectedResponse:   `{"name":"alt"}`,
+		},
+		{
+			name: "ShouldReturnErrorWhenAccountNotFound",
+
+			mockGetAccountError: service.ClassifyError(repository.ErrAccountNotFound),
+
+			account: "missing",
+
+			expectedStatusCode: 404,
+			expectedResponse:   `{"type":"about:blank","title":"Not Found","status":404,"detail":"account not found","instance":"/accounts/missing","code":"account_not_found"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &service.MockGetAccountService{
+				MockAccount:         tt.mockAccount,
+				MockGetAccountError: tt.mockGetAccountError,
+			}
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(ErrorHandler())
+			r.GET("/accounts/:account", GetAccount(service))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/accounts/"+tt.account, nil)
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatusCode, w.Code)
+			}
+
+			if diff := cmp.Diff(tt.expectedResponse, w.Body.String()); diff != "" {
+				t.Errorf("Response mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCreateAccount(t *testing.T) {
+	tests := []struct {
+		name string
+
+		// GIVEN
+		mockAccount            *service.AccountDTO
+		mockCreateAccountError error
+
+		// WHEN
+		body string
+
+		// THEN
+		expectedStatusCode int
+		expectedLocation   string
+		expectedResponse   string
+		expectedCommand    service.CreateAccountCommand
+	}{
+		{
+			name: "ShouldCreateAccountSuccessfully",
+
+			mockAccount: &service.AccountDTO{Name: "alt"},
+
+			body: `{"name":"alt"}`,
+
+			expectedStatusCode: 201,
+			expectedLocation:   "/accounts/alt",
+			expectedResponse:   `{"name":"alt"}`,
+			expectedCommand:    service.CreateAccountCommand{Name: "alt"},
+		},
+		{
+			name: "ShouldReturnErrorWhenAccountAlreadyExists",
+
+			mockCreateAccountError: service.ClassifyError(repository.ErrAccountAlreadyExists),
+
+			body: `{"name":"alt"}`,
+
+			expectedStatusCode: 409,
+			expectedResponse:   `{"type":"about:blank","title":"Conflict","status":409,"detail":"account already exists","instance":"/accounts","code":"account_already_exists"}`,
+			expectedCommand:    service.CreateAccountCommand{Name: "alt"},
+		},
+		{
+			name: "ShouldReturnErrorWhenRequestBodyIsInvalid",
+
+			body: `invalid`,
+
+			expectedStatusCode: 400,
+			expectedResponse:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid request body: invalid character 'i' looking for beginning of value","instance":"/accounts","code":"invalid_request_body"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &service.MockCreateAccountService{
+				MockAccount:            tt.mockAccount,
+				MockCreateAccountError: tt.mockCreateAccountError,
+			}
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(ErrorHandler())
+			r.POST("/accounts", CreateAccount(service))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/accounts", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatusCode, w.Code)
+			}
+
+			if location := w.Header().Get("Location"); location != tt.expectedLocation {
+				t.Errorf("Expected Location header %q, got %q", tt.expectedLocation, location)
+			}
+
+			if diff := cmp.Diff(tt.expectedResponse, w.Body.String()); diff != "" {
+				t.Errorf("Response mismatch (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tt.expectedCommand, service.QueriedCommand); diff != "" {
+				t.Errorf("Command mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestForAccount(t *testing.T) {
+	testArtifact := &entity.Artifact{
+		ID:          "test-id",
+		Type:        entity.ARTIFACT_TYPE_FLOWER,
+		ArtifactSet: entity.ARTIFACT_SET_GLADIATORS_FINALOFFERING,
+		Level:       20,
+		PrimaryStat: entity.PrimaryStat{Type: entity.HP_FLAT, Value: 4780},
+	}
+
+	tests := []struct {
+		name string
+
+		// GIVEN
+		mockAccountServices      *service.AccountServices
+		mockAccountServicesError error
+
+		// WHEN
+		path string
+
+		// THEN
+		expectedStatusCode int
+		expectedName       string
+		expectedResponse   string
+	}{
+		{
+			name: "ShouldServeWithAccountServices",
+
+			mockAccountServices: &service.AccountServices{
+				GetArtifact: service.NewGetArtifactService(&repository.MockArtifactGetter{
+					GetArtifactByIDResponse: testArtifact,
+				}, nil),
+			},
+
+			path: "/accounts/alt/artifact/test-id",
+
+			expectedStatusCode: 200,
+			expectedName:       "alt",
+			expectedResponse: func() string {
+				artifact, _ := service.NewGetArtifactService(&repository.MockArtifactGetter{
+					GetArtifactByIDResponse: testArtifact,
+				}, nil).GetArtifact("test-id")
+				response, _ := json.Marshal(artifact)
+				return string(response)
+			}(),
+		},
+		{
+			name: "ShouldReturnErrorWhenAccountNotFound",
+
+			mockAccountServicesError: service.ClassifyError(repository.ErrAccountNotFound),
+
+			path: "/accounts/missing/artifact/test-id",
+
+			expectedStatusCode: 404,
+			expectedName:       "missing",
+			expectedResponse:   `{"type":"about:blank","title":"Not Found","status":404,"detail":"account not found","instance":"/accounts/missing/artifact/test-id","code":"account_not_found"}`,
+		},
+		{
+			name: "ShouldReturnErrorWhenResolveFails",
+
+			mockAccountServicesError: errors.New("failed to open data file"),
+
+			path: "/accounts/alt/artifact/test-id",
+
+			expectedStatusCode: 500,
+			expectedName:       "alt",
+			expectedResponse:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"Internal server error","instance":"/accounts/alt/artifact/test-id","code":"internal"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver := &service.MockAccountServicesResolver{
+				MockAccountServices:      tt.mockAccountServices,
+				MockAccountServicesError: tt.mockAccountServicesError,
+			}
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(ErrorHandler())
+			r.GET("/accounts/:account/artifact/:id", ForAccount(resolver, func(s *service.AccountServices) gin.HandlerFunc {
+				return GetArtifact(s.GetArtifact)
+			}))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", tt.path, nil)
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatusCode, w.Code)
+			}
+
+			if resolver.QueriedName != tt.expectedName {
+				t.Errorf("Expected account %q, got %q", tt.expectedName, resolver.QueriedName)
+			}
+
+			if diff := cmp.Diff(tt.expectedResponse, w.Body.String()); diff != "" {
+				t.Errorf("Response mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCreateArtifactUnderAccount(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.Use(ErrorHandler())
+	r.POST("/accounts/:account/artifact", CreateArtifact(&service.MockCreateArtifactService{
+		MockArtifact: &service.ArtifactDTO{ID: "test-id"},
+	}))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/accounts/alt/artifact", bytes.NewBufferString(`{"type":"FLOWER"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+
+	if w.Code != 201 {
+		t.Errorf("Expected status code %d, got %d", 201, w.Code)
+	}
+
+	if location := w.Header().Get("Location"); location != "/accounts/alt/artifact/test-id" {
+		t.Errorf("Expected Location header %q, got %q", "/accounts/alt/artifact/test-id", location)
+	}
+}
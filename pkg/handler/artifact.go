@@ -1,17 +1,18 @@
 package handler
 
 import (
-	"errors"
+	"encoding/json"
 	"fmt"
 
-	"github.com/YutoOkawa/genshin-artifact-db/pkg/repository"
 	"github.com/YutoOkawa/genshin-artifact-db/pkg/service"
 
 	"github.com/gin-gonic/gin"
 )
 
 var (
-	InternalServerErrorTemplate = "Internal server error: %s"
+	ArtifactLocationTemplate = "/artifact/%s"
+	// AccountArtifactLocationTemplate はアカウントのパス配下で作成した聖遺物の Location
+	AccountArtifactLocationTemplate = "/accounts/%s/artifact/%s"
 )
 
 type StatRequestParam struct {
@@ -19,12 +20,44 @@ type StatRequestParam struct {
 	Value float64 `json:"value"`
 }
 
+// PrimaryStatRequestParam の value を省略した場合、レアリティとレベルから算出する。
+type PrimaryStatRequestParam struct {
+	Type  string   `json:"type"`
+	Value *float64 `json:"value"`
+}
+
+// CreateArtifactRequestParam の id は作成時のみ指定でき、省略した場合はサーバーで採番する。
+// 更新時に指定する場合はパスの ID と一致させること。
 type CreateArtifactRequestParam struct {
-	ArtifactSet string             `json:"artifact_set"`
-	Type        string             `json:"type"`
-	Level       int                `json:"level"`
-	PrimaryStat StatRequestParam   `json:"primary_stat"`
-	Substats    []StatRequestParam `json:"substats"`
+	ID          string                  `json:"id"`
+	ArtifactSet string                  `json:"artifact_set"`
+	Type        string                  `json:"type"`
+	Rarity      int                     `json:"rarity"`
+	Level       int                     `json:"level"`
+	PrimaryStat PrimaryStatRequestParam `json:"primary_stat"`
+	Substats    []StatRequestParam      `json:"substats"`
+	Locked      bool                    `json:"locked"`
+	Location    string                  `json:"location"`
+}
+
+type PatchStatRequestParam struct {
+	Type  *string  `json:"type"`
+	Value *float64 `json:"value"`
+}
+
+// PatchArtifactRequestParam は JSON Merge Patch (RFC 7396) として解釈する。
+// 省略されたフィールドは変更せず、primary_stat はフィールド単位でマージし、
+// substats は配列全体を置き換える。null は location にだけ指定でき、装備を外す。
+// 必須項目は削除できないため、他のフィールドの null は検証エラーにする。
+type PatchArtifactRequestParam struct {
+	ArtifactSet *string                `json:"artifact_set"`
+	Type        *string                `json:"type"`
+	Rarity      *int                   `json:"rarity"`
+	Level       *int                   `json:"level"`
+	PrimaryStat *PatchStatRequestParam `json:"primary_stat"`
+	Substats    *[]StatRequestParam    `json:"substats"`
+	Locked      *bool                  `json:"locked"`
+	Location    *string                `json:"location"`
 }
 
 func GetArtifact(artifactService service.GetArtifactServiceInterface) func(c *gin.Context) {
@@ -33,13 +66,8 @@ func GetArtifact(artifactService service.GetArtifactServiceInterface) func(c *gi
 
 		artifact, err := artifactService.GetArtifact(artifactID)
 		if err != nil {
-			if errors.Is(err, repository.ErrArtifactNotFound) {
-				c.JSON(404, gin.H{"error": err.Error()})
-				return
-			} else {
-				c.JSON(500, gin.H{"error": fmt.Sprintf(InternalServerErrorTemplate, err.Error())})
-				return
-			}
+			c.Error(err)
+			return
 		}
 
 		c.JSON(200, artifact)
@@ -50,15 +78,10 @@ func GetArtifactsByType(artifactService service.GetArtifactsByTypeServiceInterfa
 	return func(c *gin.Context) {
 		artifactType := c.Param("type")
 
-		artifacts, err := artifactService.GetArtifactsByType(artifactType)
+		artifacts, err := artifactService.GetArtifactsByType(artifactType, c.Query("profile"))
 		if err != nil {
-			if errors.Is(err, repository.ErrArtifactNotFound) {
-				c.JSON(404, gin.H{"error": err.Error()})
-				return
-			} else {
-				c.JSON(500, gin.H{"error": fmt.Sprintf(InternalServerErrorTemplate, err.Error())})
-				return
-			}
+			c.Error(err)
+			return
 		}
 		c.JSON(200, artifacts)
 	}
@@ -68,15 +91,10 @@ func GetArtifactsBySet(artifactService service.GetArtifactsBySetServiceInterface
 	return func(c *gin.Context) {
 		artifactSet := c.Param("set")
 
-		artifacts, err := artifactService.GetArtifactsBySet(artifactSet)
+		artifacts, err := artifactService.GetArtifactsBySet(artifactSet, c.Query("profile"))
 		if err != nil {
-			if errors.Is(err, repository.ErrArtifactNotFound) {
-				c.JSON(404, gin.H{"error": err.Error()})
-				return
-			} else {
-				c.JSON(500, gin.H{"error": fmt.Sprintf(InternalServerErrorTemplate, err.Error())})
-				return
-			}
+			c.Error(err)
+			return
 		}
 		c.JSON(200, artifacts)
 	}
@@ -87,51 +105,197 @@ func GetArtifacts(artifactService service.GetArtifactsServiceInterface) func(c *
 		artifactType := c.Param("type")
 		artifactSet := c.Param("set")
 
-		artifacts, err := artifactService.GetArtifactsByTypeAndSet(artifactType, artifactSet)
+		artifacts, err := artifactService.GetArtifactsByTypeAndSet(artifactType, artifactSet, c.Query("profile"))
 		if err != nil {
-			if errors.Is(err, repository.ErrArtifactNotFound) {
-				c.JSON(404, gin.H{"error": err.Error()})
-				return
-			} else {
-				c.JSON(500, gin.H{"error": fmt.Sprintf(InternalServerErrorTemplate, err.Error())})
-				return
-			}
+			c.Error(err)
+			return
 		}
 		c.JSON(200, artifacts)
 	}
 }
 
+// CreateArtifact は ?on_duplicate=warn|reject|allow で内容が同じ聖遺物がある場合の扱いを指定できる。
+// 既定の warn では作成したうえで、重複している聖遺物の ID をレスポンスの duplicate_of で返す。
 func CreateArtifact(artifactService service.CreateArtifactServiceInterface) func(c *gin.Context) {
 	return func(c *gin.Context) {
 		var createArtifactRequestParam CreateArtifactRequestParam
 		if err := c.ShouldBindJSON(&createArtifactRequestParam); err != nil {
-			c.JSON(400, gin.H{"error": "Invalid request body"})
+			c.Error(invalidRequestBodyError(err))
 			return
 		}
 
-		artifactCommand := service.CreateArtifactCommand{
-			ArtifactSet: createArtifactRequestParam.ArtifactSet,
-			Type:        createArtifactRequestParam.Type,
-			Level:       createArtifactRequestParam.Level,
-			PrimaryStat: service.StatCommand{
-				Type:  createArtifactRequestParam.PrimaryStat.Type,
-				Value: createArtifactRequestParam.PrimaryStat.Value,
-			},
-			Substats: make([]service.StatCommand, len(createArtifactRequestParam.Substats)),
+		artifactCommand := toCreateArtifactCommand(createArtifactRequestParam)
+		artifactCommand.DuplicatePolicy = service.DuplicatePolicy(c.Query("on_duplicate"))
+
+		artifact, err := artifactService.CreateArtifact(artifactCommand)
+		if err != nil {
+			c.Error(err)
+			return
 		}
 
-		for i, substat := range createArtifactRequestParam.Substats {
-			artifactCommand.Substats[i] = service.StatCommand{
-				Type:  substat.Type,
-				Value: substat.Value,
+		c.Header("Location", artifactLocation(c, artifact.ID))
+		c.JSON(201, artifact)
+	}
+}
+
+func UpdateArtifact(artifactService service.UpdateArtifactServiceInterface) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		artifactID := c.Param("id")
+
+		var updateArtifactRequestParam CreateArtifactRequestParam
+		if err := c.ShouldBindJSON(&updateArtifactRequestParam); err != nil {
+			c.Error(invalidRequestBodyError(err))
+			return
+		}
+
+		artifactCommand := toCreateArtifactCommand(updateArtifactRequestParam)
+
+		artifact, err := artifactService.UpdateArtifact(artifactID, artifactCommand)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		c.JSON(200, artifact)
+	}
+}
+
+func PatchArtifact(artifactService service.PatchArtifactServiceInterface) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		artifactID := c.Param("id")
+
+		data, err := c.GetRawData()
+		if err != nil {
+			c.Error(service.NewValidationError("invalid_request_body", "", fmt.Errorf("%w: %w", ErrInvalidRequestBody, err)))
+			return
+		}
+		var patchArtifactRequestParam PatchArtifactRequestParam
+		if err := json.Unmarshal(data, &patchArtifactRequestParam); err != nil {
+			c.Error(invalidRequestBodyError(err))
+			return
+		}
+		if err := applyPatchNulls(data, &patchArtifactRequestParam); err != nil {
+			c.Error(err)
+			return
+		}
+
+		patchCommand := service.PatchArtifactCommand{
+			ArtifactSet: patchArtifactRequestParam.ArtifactSet,
+			Type:        patchArtifactRequestParam.Type,
+			Rarity:      patchArtifactRequestParam.Rarity,
+			Level:       patchArtifactRequestParam.Level,
+			Locked:      patchArtifactRequestParam.Locked,
+			Location:    patchArtifactRequestParam.Location,
+		}
+		if patchArtifactRequestParam.PrimaryStat != nil {
+			patchCommand.PrimaryStat = &service.PatchStatCommand{
+				Type:  patchArtifactRequestParam.PrimaryStat.Type,
+				Value: patchArtifactRequestParam.PrimaryStat.Value,
 			}
 		}
+		if patchArtifactRequestParam.Substats != nil {
+			substats := toStatCommands(*patchArtifactRequestParam.Substats)
+			patchCommand.Substats = &substats
+		}
+
+		artifact, err := artifactService.PatchArtifact(artifactID, patchCommand)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		c.JSON(200, artifact)
+	}
+}
+
+// patchFields と patchStatFields は PatchArtifactRequestParam と PatchStatRequestParam の JSON の項目名。
+var (
+	patchFields     = []string{"artifact_set", "type", "rarity", "level", "primary_stat", "substats", "locked", "location"}
+	patchStatFields = []string{"type", "value"}
+)
+
+// applyPatchNulls は data で null を指定した項目を patch に反映する。location の null は装備を外し、
+// 他の項目の null は検証エラーにする。data は patch にデコードできた JSON であること。
+func applyPatchNulls(data []byte, patch *PatchArtifactRequestParam) error {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return invalidRequestBodyError(err)
+	}
+	var statMembers map[string]json.RawMessage
+	if primaryStat, ok := members["primary_stat"]; ok && !isJSONNull(primaryStat) {
+		if err := json.Unmarshal(primaryStat, &statMembers); err != nil {
+			return invalidRequestBodyError(err)
+		}
+	}
+
+	for _, field := range patchFields {
+		if value, ok := members[field]; !ok || !isJSONNull(value) {
+			continue
+		}
+		if field != "location" {
+			return service.NewValidationError("null_not_allowed", field, fmt.Errorf("%w: %s cannot be removed", ErrNullNotAllowed, field))
+		}
+		unequipped := ""
+		patch.Location = &unequipped
+	}
+	for _, field := range patchStatFields {
+		if value, ok := statMembers[field]; ok && isJSONNull(value) {
+			field = "primary_stat." + field
+			return service.NewValidationError("null_not_allowed", field, fmt.Errorf("%w: %s cannot be removed", ErrNullNotAllowed, field))
+		}
+	}
+	return nil
+}
+
+func isJSONNull(value json.RawMessage) bool {
+	return string(value) == "null"
+}
+
+func DeleteArtifact(artifactService service.DeleteArtifactServiceInterface) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		artifactID := c.Param("id")
 
-		if err := artifactService.CreateArtifact(artifactCommand); err != nil {
-			c.JSON(500, gin.H{"error": fmt.Sprintf(InternalServerErrorTemplate, err.Error())})
+		if err := artifactService.DeleteArtifact(artifactID); err != nil {
+			c.Error(err)
 			return
 		}
 
-		c.JSON(201, gin.H{"message": "Artifact created successfully"})
+		c.JSON(200, gin.H{"message": "Artifact deleted successfully"})
+	}
+}
+
+func toCreateArtifactCommand(param CreateArtifactRequestParam) service.CreateArtifactCommand {
+	return service.CreateArtifactCommand{
+		ID:          param.ID,
+		ArtifactSet: param.ArtifactSet,
+		Type:        param.Type,
+		Rarity:      param.Rarity,
+		Level:       param.Level,
+		PrimaryStat: service.PrimaryStatCommand{
+			Type:  param.PrimaryStat.Type,
+			Value: param.PrimaryStat.Value,
+		},
+		Substats: toStatCommands(param.Substats),
+		Locked:   param.Locked,
+		Location: param.Location,
+	}
+}
+
+func toStatCommands(params []StatRequestParam) []service.StatCommand {
+	commands := make([]service.StatCommand, len(params))
+	for i, param := range params {
+		commands[i] = service.StatCommand{
+			Type:  param.Type,
+			Value: param.Value,
+		}
+	}
+	return commands
+}
+
+// artifactLocation はリクエストが :account を含むルートであれば、同じアカウント配下の URL を返す。
+func artifactLocation(c *gin.Context, id string) string {
+	if account := c.Param("account"); account != "" {
+		return fmt.Sprintf(AccountArtifactLocationTemplate, account, id)
 	}
+	return fmt.Sprintf(ArtifactLocationTemplate, id)
 }
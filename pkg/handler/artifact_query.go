@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// QueryArtifacts は GET /artifacts のクエリ文字列で聖遺物を検索する。
+// 複数の値を取るパラメータは繰り返し指定してもカンマ区切りで指定してもよい。
+// 例: /artifacts?type=SANDS,GOBLET&substat=CRIT_RATE&min_substat=CRIT_DMG:20&sort=-crit_value&limit=20
+// 装備していない聖遺物は equipped_by=none で絞り込む。例: /artifacts?type=CIRCLET&equipped_by=none
+// profile を指定すると、各聖遺物にそのスコアプロファイルによる点数 score を付ける。例: /artifacts?type=FLOWER&profile=HuTao
+func QueryArtifacts(artifactService service.QueryArtifactsServiceInterface) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		queryCommand, err := toQueryArtifactsCommand(c)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		queryCommand.Profile = c.Query("profile")
+
+		artifactList, err := artifactService.QueryArtifacts(*queryCommand)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		c.JSON(200, artifactList)
+	}
+}
+
+func toQueryArtifactsCommand(c *gin.Context) (*service.QueryArtifactsCommand, error) {
+	queryCommand := &service.QueryArtifactsCommand{
+		Types:            queryList(c, "type"),
+		Sets:             queryList(c, "set"),
+		PrimaryStats:     queryList(c, "main_stat"),
+		RequiredSubstats: queryList(c, "substat"),
+		EquippedBy:       queryList(c, "equipped_by"),
+		Sort:             c.Query("sort"),
+		Cursor:           c.Query("cursor"),
+	}
+
+	for _, rarity := range queryList(c, "rarity") {
+		rarityValue, err := strconv.Atoi(rarity)
+		if err != nil {
+			return nil, invalidQueryParamError("rarity", fmt.Errorf("%w: rarity %s", ErrInvalidQueryParam, rarity))
+		}
+		queryCommand.Rarities = append(queryCommand.Rarities, rarityValue)
+	}
+
+	var err error
+	if queryCommand.MinLevel, err = queryInt(c, "min_level"); err != nil {
+		return nil, err
+	}
+	if queryCommand.MaxLevel, err = queryInt(c, "max_level"); err != nil {
+		return nil, err
+	}
+	if limit, err := queryInt(c, "limit"); err != nil {
+		return nil, err
+	} else if limit != nil {
+		queryCommand.Limit = *limit
+	}
+	if offset, err := queryInt(c, "offset"); err != nil {
+		return nil, err
+	} else if offset != nil {
+		queryCommand.Offset = *offset
+	}
+
+	// min_substat は "CRIT_DMG:20" の形式で指定する
+	for _, minSubstat := range queryList(c, "min_substat") {
+		substatType, value, ok := strings.Cut(minSubstat, ":")
+		if !ok {
+			return nil, invalidQueryParamError("min_substat", fmt.Errorf("%w: min_substat must be TYPE:VALUE, got %s", ErrInvalidQueryParam, minSubstat))
+		}
+		minValue, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, invalidQueryParamError("min_substat", fmt.Errorf("%w: min_substat %s", ErrInvalidQueryParam, minSubstat))
+		}
+		if queryCommand.MinSubstatValues == nil {
+			queryCommand.MinSubstatValues = make(map[string]float64)
+		}
+		queryCommand.MinSubstatValues[substatType] = minValue
+	}
+
+	return queryCommand, nil
+}
+
+// queryList は繰り返し指定とカンマ区切りの両方を受け付け、空の値を除いて返す。
+func queryList(c *gin.Context, key string) []string {
+	var values []string
+	for _, value := range c.QueryArray(key) {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				values = append(values, item)
+			}
+		}
+	}
+	return values
+}
+
+func queryInt(c *gin.Context, key string) (*int, error) {
+	value, ok := c.GetQuery(key)
+	if !ok || value == "" {
+		return nil, nil
+	}
+	intValue, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, invalidQueryParamError(key, fmt.Errorf("%w: %s %s", ErrInvalidQueryParam, key, value))
+	}
+	return &intValue, nil
+}
+
+func invalidQueryParamError(field string, err error) error {
+	return service.NewValidationError("invalid_query_parameter", field, err)
+}
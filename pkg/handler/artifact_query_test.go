@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/repository"
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestQueryArtifacts(t *testing.T) {
+	minLevel := 16
+	score := 1.5
+
+	tests := []struct {
+		name string
+
+		// GIVEN
+		mockArtifactList *service.ArtifactListDTO
+		mockQueryError   error
+
+		// WHEN
+		url string
+
+		// THEN
+		expectedStatusCode int
+		expectedResponse   string
+		expectedCommand    *service.QueryArtifactsCommand
+	}{
+		{
+			name: "ShouldQueryArtifactsSuccessfully",
+
+			mockArtifactList: &service.ArtifactListDTO{
+				Artifacts:  []*service.ArtifactDTO{},
+				Total:      5,
+				NextCursor: "next",
+			},
+
+			url: "/artifacts?type=SANDS,GOBLET&type=CIRCLET&set=GladiatorsFinale&main_stat=ATK_PERCENT&rarity=4,5" +
+				"&min_level=16&substat=CRIT_RATE&min_substat=CRIT_DMG:20.5&sort=-crit_value&limit=2",
+
+			expectedStatusCode: 200,
+			expectedResponse:   `{"artifacts":[],"total":5,"next_cursor":"next"}`,
+			expectedCommand: &service.QueryArtifactsCommand{
+				Types:            []string{"SANDS", "GOBLET", "CIRCLET"},
+				Sets:             []string{"GladiatorsFinale"},
+				PrimaryStats:     []string{"ATK_PERCENT"},
+				Rarities:         []int{4, 5},
+				MinLevel:         &minLevel,
+				RequiredSubstats: []string{"CRIT_RATE"},
+				MinSubstatValues: map[string]float64{"CRIT_DMG": 20.5},
+				Sort:             "-crit_value",
+				Limit:            2,
+			},
+		},
+		{
+			name: "ShouldPassProfileAndReturnScores",
+
+			mockArtifactList: &service.ArtifactListDTO{
+				Artifacts: []*service.ArtifactDTO{{ID: "test-id", SubStat: []service.StatusDTO{}, Score: &score}},
+				Total:     1,
+			},
+
+			url: "/artifacts?type=FLOWER&profile=HuTao",
+
+			expectedStatusCode: 200,
+			expectedResponse:   `{"artifacts":[{"id":"test-id","set":"","type":"","rarity":0,"level":0,"primary_stat":{"type":"","value":0},"sub_stat":[],"crit_value":0,"roll_value":0,"locked":false,"score":1.5}],"total":1}`,
+			expectedCommand: &service.QueryArtifactsCommand{
+				Types:   []string{"FLOWER"},
+				Profile: "HuTao",
+			},
+		},
+		{
+			name: "ShouldReturnBadRequestWhenLimitIsNotNumber",
+
+			url: "/artifacts?limit=ten",
+
+			expectedStatusCode: 400,
+			expectedResponse:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid query parameter: limit ten","instance":"/artifacts","code":"invalid_query_parameter","errors":[{"field":"limit","code":"invalid_query_parameter","message":"invalid query parameter: limit ten"}]}`,
+		},
+		{
+			name: "ShouldReturnBadRequestWhenMinSubstatHasNoValue",
+
+			url: "/artifacts?min_substat=CRIT_DMG",
+
+			expectedStatusCode: 400,
+			expectedResponse:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid query parameter: min_substat must be TYPE:VALUE, got CRIT_DMG","instance":"/artifacts","code":"invalid_query_parameter","errors":[{"field":"min_substat","code":"invalid_query_parameter","message":"invalid query parameter: min_substat must be TYPE:VALUE, got CRIT_DMG"}]}`,
+		},
+		{
+			name: "ShouldReturnBadRequestWhenQueryIsInvalid",
+
+			mockQueryError: repository.ErrInvalidCursor,
+
+			url: "/artifacts?cursor=broken",
+
+			expectedStatusCode: 400,
+			expectedResponse:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid cursor","instance":"/artifacts","code":"invalid_cursor","errors":[{"field":"cursor","code":"invalid_cursor","message":"invalid cursor"}]}`,
+			expectedCommand: &service.QueryArtifactsCommand{
+				Cursor: "broken",
+			},
+		},
+		{
+			name: "ShouldReturnErrorWhenQueryArtifactsFails",
+
+			mockQueryError: errors.New("internal server error"),
+
+			url: "/artifacts",
+
+			expectedStatusCode: 500,
+			expectedResponse:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"Internal server error","instance":"/artifacts","code":"internal"}`,
+			expectedCommand:    &service.QueryArtifactsCommand{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			artifactService := &service.MockQueryArtifactsService{
+				MockArtifactList:        tt.mockArtifactList,
+				MockQueryArtifactsError: tt.mockQueryError,
+			}
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(ErrorHandler())
+			r.GET("/artifacts", QueryArtifacts(artifactService))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", tt.url, nil)
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatusCode, w.Code)
+			}
+
+			if diff := cmp.Diff(tt.expectedResponse, w.Body.String()); diff != "" {
+				t.Errorf("Response mismatch (-want +got):\n%s", diff)
+			}
+
+			if tt.expectedCommand != nil {
+				if diff := cmp.Diff(*tt.expectedCommand, artifactService.QueriedCommand); diff != "" {
+					t.Errorf("Command mismatch (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+func GetArtifactSets(artifactSetService service.GetArtifactSetsServiceInterface) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		lang := c.Query("lang")
+
+		c.JSON(200, artifactSetService.GetArtifactSets(lang))
+	}
+}
+
+func GetArtifactSet(artifactSetService service.GetArtifactSetServiceInterface) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		key := c.Param("key")
+		lang := c.Query("lang")
+
+		artifactSet, err := artifactSetService.GetArtifactSet(key, lang)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		c.JSON(200, artifactSet)
+	}
+}
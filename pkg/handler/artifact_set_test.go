@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGetArtifactSets(t *testing.T) {
+	testArtifactSets := []*service.ArtifactSetDTO{
+		{
+			Key:       "GladiatorsFinale",
+			Name:      "Gladiator's Finale",
+			Names:     map[string]string{"en": "Gladiator's Finale"},
+			MaxRarity: 5,
+			Bonuses: []service.SetBonusDTO{
+				{
+					Pieces:      2,
+					Description: "ATK +18%.",
+					Stats:       []service.SetBonusStatDTO{{Type: "ATK_PERCENT", Value: 18}},
+				},
+			},
+		},
+	}
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.GET("/sets", GetArtifactSets(&service.MockGetArtifactSetsService{
+		MockArtifactSets: testArtifactSets,
+	}))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/sets?lang=en", nil)
+	r.ServeHTTP(w, req)
+
+	if w.Code != 200 {
+		t.Errorf("Expected status code %d, got %d", 200, w.Code)
+	}
+
+	expectedResponse, _ := json.Marshal(testArtifactSets)
+	if diff := cmp.Diff(string(expectedResponse), w.Body.String()); diff != "" {
+		t.Errorf("Response mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGetArtifactSet(t *testing.T) {
+	testArtifactSet := &service.ArtifactSetDTO{
+		Key:       "GladiatorsFinale",
+		Name:      "剣闘士のフィナーレ",
+		Names:     map[string]string{"en": "Gladiator's Finale", "ja": "剣闘士のフィナーレ"},
+		MaxRarity: 5,
+		Bonuses:   []service.SetBonusDTO{},
+	}
+
+	tests := []struct {
+		name string
+
+		// GIVEN
+		mockArtifactSet         *service.ArtifactSetDTO
+		mockGetArtifactSetError error
+
+		// WHEN
+		key string
+
+		// THEN
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name: "ShouldGetArtifactSetSuccessfully",
+
+			mockArtifactSet: testArtifactSet,
+
+			key: "GladiatorsFinale",
+
+			expectedStatusCode: 200,
+			expectedResponse: func() string {
+				response, _ := json.Marshal(testArtifactSet)
+				return string(response)
+			}(),
+		},
+		{
+			name: "ShouldReturnErrorWhenArtifactSetNotFound",
+
+			mockGetArtifactSetError: service.ErrArtifactSetNotFound,
+
+			key: "INVALID_SET",
+
+			expectedStatusCode: 404,
+			expectedResponse:   `{"type":"about:blank","title":"Not Found","status":404,"detail":"artifact set not found","instance":"/sets/INVALID_SET","code":"artifact_set_not_found"}`,
+		},
+		{
+			name: "ShouldReturnErrorWhenGetArtifactSetFails",
+
+			mockGetArtifactSetError: errors.New("internal server error"),
+
+			key: "GladiatorsFinale",
+
+			expectedStatusCode: 500,
+			expectedResponse:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"Internal server error","instance":"/sets/GladiatorsFinale","code":"internal"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &service.MockGetArtifactSetService{
+				MockArtifactSet:         tt.mockArtifactSet,
+				MockGetArtifactSetError: tt.mockGetArtifactSetError,
+			}
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(ErrorHandler())
+			r.GET("/sets/:key", GetArtifactSet(service))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", fmt.Sprintf("/sets/%s?lang=ja", tt.key), nil)
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatusCode, w.Code)
+			}
+
+			if diff := cmp.Diff(tt.expectedResponse, w.Body.String()); diff != "" {
+				t.Errorf("Response mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -6,8 +6,12 @@ import (
 	"errors"
 	"fmt"
 	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
 	"github.com/YutoOkawa/genshin-artifact-db/pkg/repository"
 	"github.com/YutoOkawa/genshin-artifact-db/pkg/service"
 
@@ -17,6 +21,7 @@ import (
 
 func TestGetArtifact(t *testing.T) {
 	testArtifactDTO := &service.ArtifactDTO{
+		ID:    "test-id",
 		Set:   "test-set",
 		Type:  "test-type",
 		Level: 20,
@@ -68,7 +73,7 @@ func TestGetArtifact(t *testing.T) {
 			artifactID: "non-existent-id",
 
 			expectedStatusCode: 404,
-			expectedResponse:   `{"error":"artifact not found"}`,
+			expectedResponse:   `{"type":"about:blank","title":"Not Found","status":404,"detail":"artifact not found","instance":"/artifact/non-existent-id","code":"artifact_not_found"}`,
 		},
 		{
 			name: "ShouldReturnErrorWhenGetArtifactByIDFails",
@@ -79,7 +84,7 @@ func TestGetArtifact(t *testing.T) {
 			artifactID: "test-id",
 
 			expectedStatusCode: 500,
-			expectedResponse:   `{"error":"Internal server error: internal server error"}`,
+			expectedResponse:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"Internal server error","instance":"/artifact/test-id","code":"internal"}`,
 		},
 	}
 
@@ -91,6 +96,7 @@ func TestGetArtifact(t *testing.T) {
 			}
 			gin.SetMode(gin.TestMode)
 			r := gin.Default()
+			r.Use(ErrorHandler())
 			r.GET("/artifact/:id", GetArtifact(artifactService))
 
 			w := httptest.NewRecorder()
@@ -111,6 +117,7 @@ func TestGetArtifact(t *testing.T) {
 func TestGetArtifactsByType(t *testing.T) {
 	testArtifacts := []*service.ArtifactDTO{
 		{
+			ID:    "test-id-1",
 			Set:   "test-set-1",
 			Type:  "test-type-1",
 			Level: 20,
@@ -152,15 +159,24 @@ func TestGetArtifactsByType(t *testing.T) {
 			}(),
 		},
 		{
-			name: "ShouldReturnErrorWhenArtifactsNotFound",
+			name: "ShouldReturnEmptyListWhenNoArtifactsMatch",
 
-			mockArtifacts:               nil,
-			mockGetArtifactsByTypeError: repository.ErrArtifactNotFound,
+			mockArtifacts: []*service.ArtifactDTO{},
 
-			artifactType: "non-existent-type",
+			artifactType: "FLOWER",
 
-			expectedStatusCode: 404,
-			expectedResponse:   `{"error":"artifact not found"}`,
+			expectedStatusCode: 200,
+			expectedResponse:   `[]`,
+		},
+		{
+			name: "ShouldReturnBadRequestWhenTypeIsUnknown",
+
+			mockGetArtifactsByTypeError: service.NewValidationError("invalid_artifact_type", "type", fmt.Errorf("%w: RING", entity.ErrInvalidArtifactType)),
+
+			artifactType: "RING",
+
+			expectedStatusCode: 400,
+			expectedResponse:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid artifact type: RING","instance":"/artifacts/type/RING","code":"invalid_artifact_type","errors":[{"field":"type","code":"invalid_artifact_type","message":"invalid artifact type: RING"}]}`,
 		},
 		{
 			name: "ShouldReturnErrorWhenGetArtifactsByTypeFails",
@@ -171,7 +187,7 @@ func TestGetArtifactsByType(t *testing.T) {
 			artifactType: "test-type",
 
 			expectedStatusCode: 500,
-			expectedResponse:   `{"error":"Internal server error: internal server error"}`,
+			expectedResponse:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"Internal server error","instance":"/artifacts/type/test-type","code":"internal"}`,
 		},
 	}
 
@@ -183,6 +199,7 @@ func TestGetArtifactsByType(t *testing.T) {
 			}
 			gin.SetMode(gin.TestMode)
 			r := gin.Default()
+			r.Use(ErrorHandler())
 			r.GET("/artifacts/type/:type", GetArtifactsByType(service))
 
 			w := httptest.NewRecorder()
@@ -202,6 +219,7 @@ func TestGetArtifactsByType(t *testing.T) {
 
 func TestGetArtifactsBySet(t *testing.T) {
 	testArtifact := &service.ArtifactDTO{
+		ID:    "test-id",
 		Set:   "test-set",
 		Type:  "test-type",
 		Level: 0,
@@ -242,15 +260,24 @@ func TestGetArtifactsBySet(t *testing.T) {
 			}(),
 		},
 		{
-			name: "ShouldReturnErrorWhenArtifactsNotFound",
+			name: "ShouldReturnEmptyListWhenNoArtifactsMatch",
 
-			mockGetArtifactsBySetResponse: nil,
-			mockGetArtifactsBySetError:    repository.ErrArtifactNotFound,
+			mockGetArtifactsBySetResponse: []*service.ArtifactDTO{},
+
+			artifactSet: "GladiatorsFinale",
+
+			expectedStatusCode: 200,
+			expectedResponse:   `[]`,
+		},
+		{
+			name: "ShouldReturnBadRequestWhenSetIsUnknown",
+
+			mockGetArtifactsBySetError: service.NewValidationError("invalid_artifact_set", "set", fmt.Errorf("%w: non-existent-set", entity.ErrInvalidArtifactSet)),
 
 			artifactSet: "non-existent-set",
 
-			expectedStatusCode: 404,
-			expectedResponse:   `{"error":"artifact not found"}`,
+			expectedStatusCode: 400,
+			expectedResponse:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid artifact set: non-existent-set","instance":"/artifacts/set/non-existent-set","code":"invalid_artifact_set","errors":[{"field":"set","code":"invalid_artifact_set","message":"invalid artifact set: non-existent-set"}]}`,
 		},
 		{
 			name: "ShouldReturnErrorWhenGetArtifactsBySetFails",
@@ -261,7 +288,7 @@ func TestGetArtifactsBySet(t *testing.T) {
 			artifactSet: "test-set",
 
 			expectedStatusCode: 500,
-			expectedResponse:   `{"error":"Internal server error: internal server error"}`,
+			expectedResponse:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"Internal server error","instance":"/artifacts/set/test-set","code":"internal"}`,
 		},
 	}
 
@@ -273,6 +300,7 @@ func TestGetArtifactsBySet(t *testing.T) {
 			}
 			gin.SetMode(gin.TestMode)
 			r := gin.Default()
+			r.Use(ErrorHandler())
 			r.GET("/artifacts/set/:set", GetArtifactsBySet(service))
 
 			w := httptest.NewRecorder()
@@ -292,6 +320,7 @@ func TestGetArtifactsBySet(t *testing.T) {
 
 func TestGetArtifactsByTypeAndSet(t *testing.T) {
 	testArtifact := &service.ArtifactDTO{
+		ID:    "test-id",
 		Set:   "test-set",
 		Type:  "test-type",
 		Level: 0,
@@ -334,16 +363,26 @@ func TestGetArtifactsByTypeAndSet(t *testing.T) {
 			}(),
 		},
 		{
-			name: "ShouldReturnErrorWhenArtifactNotFound",
+			name: "ShouldReturnEmptyListWhenNoArtifactsMatch",
 
-			mockGetArtifactByTypeAndSetResponse: nil,
-			mockGetArtifactByTypeAndSetError:    repository.ErrArtifactNotFound,
+			mockGetArtifactByTypeAndSetResponse: []*service.ArtifactDTO{},
+
+			artifactType: "GOBLET",
+			artifactSet:  "GladiatorsFinale",
+
+			expectedStatusCode: 200,
+			expectedResponse:   `[]`,
+		},
+		{
+			name: "ShouldReturnBadRequestWhenTypeIsUnknown",
+
+			mockGetArtifactByTypeAndSetError: service.NewValidationError("invalid_artifact_type", "type", fmt.Errorf("%w: non-existent-type", entity.ErrInvalidArtifactType)),
 
 			artifactType: "non-existent-type",
-			artifactSet:  "non-existent-set",
+			artifactSet:  "GladiatorsFinale",
 
-			expectedStatusCode: 404,
-			expectedResponse:   `{"error":"artifact not found"}`,
+			expectedStatusCode: 400,
+			expectedResponse:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid artifact type: non-existent-type","instance":"/artifacts/type/non-existent-type/set/GladiatorsFinale","code":"invalid_artifact_type","errors":[{"field":"type","code":"invalid_artifact_type","message":"invalid artifact type: non-existent-type"}]}`,
 		},
 		{
 			name: "ShouldReturnErrorWhenGetArtifactByTypeAndSetFails",
@@ -355,7 +394,7 @@ func TestGetArtifactsByTypeAndSet(t *testing.T) {
 			artifactSet:  "test-set",
 
 			expectedStatusCode: 500,
-			expectedResponse:   `{"error":"Internal server error: internal server error"}`,
+			expectedResponse:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"Internal server error","instance":"/artifacts/type/test-type/set/test-set","code":"internal"}`,
 		},
 	}
 
@@ -367,6 +406,7 @@ func TestGetArtifactsByTypeAndSet(t *testing.T) {
 			}
 			gin.SetMode(gin.TestMode)
 			r := gin.Default()
+			r.Use(ErrorHandler())
 			r.GET("/artifacts/type/:type/set/:set", GetArtifacts(service))
 
 			w := httptest.NewRecorder()
@@ -389,11 +429,27 @@ func TestCreateArtifact(t *testing.T) {
 		ArtifactSet: "Gladiator's Finale",
 		Type:        "FLOWER",
 		Level:       0,
-		PrimaryStat: StatRequestParam{
+		PrimaryStat: PrimaryStatRequestParam{
+			Type: "ATK_PERCENT",
+		},
+		Substats: []StatRequestParam{
+			{
+				Type:  "ATK_PERCENT",
+				Value: 0,
+			},
+		},
+	}
+
+	testArtifactDTO := &service.ArtifactDTO{
+		ID:    "test-id",
+		Set:   "Gladiator",
+		Type:  "FLOWER",
+		Level: 0,
+		PrimaryStat: service.StatusDTO{
 			Type:  "ATK_PERCENT",
 			Value: 0,
 		},
-		Substats: []StatRequestParam{
+		SubStat: []service.StatusDTO{
 			{
 				Type:  "ATK_PERCENT",
 				Value: 0,
@@ -405,6 +461,7 @@ func TestCreateArtifact(t *testing.T) {
 		name string
 
 		// GIVEN
+		mockArtifactDTO        *service.ArtifactDTO
 		mockArtifactSaverError error
 
 		// WHEN
@@ -412,6 +469,7 @@ func TestCreateArtifact(t *testing.T) {
 
 		// THEN
 		expectedStatusCode int
+		expectedLocation   string
 		expectedResponse   string
 	}{
 		{
@@ -422,8 +480,14 @@ func TestCreateArtifact(t *testing.T) {
 				return body
 			}(),
 
+			mockArtifactDTO: testArtifactDTO,
+
 			expectedStatusCode: 201,
-			expectedResponse:   `{"message":"Artifact created successfully"}`,
+			expectedLocation:   "/artifact/test-id",
+			expectedResponse: func() string {
+				response, _ := json.Marshal(testArtifactDTO)
+				return string(response)
+			}(),
 		},
 		{
 			name: "ShouldReturnErrorWhenCreateArtifactRequestParamIsInvalid",
@@ -431,7 +495,7 @@ func TestCreateArtifact(t *testing.T) {
 			createArtifactRequestParamByte: []byte(`invalid`),
 
 			expectedStatusCode: 400,
-			expectedResponse:   `{"error":"Invalid request body"}`,
+			expectedResponse:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid request body: invalid character 'i' looking for beginning of value","instance":"/artifacts","code":"invalid_request_body"}`,
 		},
 		{
 			name: "ShouldReturnErrorWhenArtifactSaverFails",
@@ -444,18 +508,20 @@ func TestCreateArtifact(t *testing.T) {
 			mockArtifactSaverError: errors.New("artifact saver error"),
 
 			expectedStatusCode: 500,
-			expectedResponse:   `{"error":"Internal server error: artifact saver error"}`,
+			expectedResponse:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"Internal server error","instance":"/artifacts","code":"internal"}`,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			service := &service.MockCreateArtifactService{
+				MockArtifact:            tt.mockArtifactDTO,
 				MockCreateArtifactError: tt.mockArtifactSaverError,
 			}
 
 			gin.SetMode(gin.TestMode)
 			r := gin.Default()
+			r.Use(ErrorHandler())
 			r.POST("/artifacts", CreateArtifact(service))
 
 			w := httptest.NewRecorder()
@@ -468,6 +534,403 @@ func TestCreateArtifact(t *testing.T) {
 				t.Errorf("Expected status code %d, got %d", tt.expectedStatusCode, w.Code)
 			}
 
+			if location := w.Header().Get("Location"); location != tt.expectedLocation {
+				t.Errorf("Expected Location header %q, got %q", tt.expectedLocation, location)
+			}
+
+			if diff := cmp.Diff(tt.expectedResponse, w.Body.String()); diff != "" {
+				t.Errorf("Response mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCreateArtifactConcurrentDuplicates(t *testing.T) {
+	// GIVEN
+	const requests = 16
+	artifactRepository := repository.NewInMemoryArtifactRepository()
+	artifactGetter := &barrierArtifactGetter{
+		ArtifactGetter: artifactRepository,
+		parties:        requests,
+		release:        make(chan struct{}),
+	}
+	artifactService := service.NewUpdateArtifactService(artifactGetter, artifactRepository, artifactRepository, artifactRepository, artifactRepository)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(ErrorHandler())
+	r.POST("/artifacts", CreateArtifact(artifactService))
+
+	body := []byte(`{"artifact_set":"GladiatorsFinale","type":"FLOWER","rarity":5,"level":0,"primary_stat":{"type":"HP_FLAT"},"substats":[{"type":"CRIT_RATE","value":3.9},{"type":"CRIT_DMG","value":7.8},{"type":"ATK_PERCENT","value":5.8}]}`)
+
+	// WHEN
+	statusCodes := make(chan int, requests)
+	var wg sync.WaitGroup
+	for range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/artifacts?on_duplicate=reject", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			r.ServeHTTP(w, req)
+			if w.Code == 409 && !strings.Contains(w.Body.String(), `"code":"duplicate_artifact"`) {
+				t.Errorf("unexpected conflict response: %s", w.Body.String())
+			}
+			statusCodes <- w.Code
+		}()
+	}
+	wg.Wait()
+	close(statusCodes)
+
+	// THEN
+	counts := map[int]int{}
+	for statusCode := range statusCodes {
+		counts[statusCode]++
+	}
+	if diff := cmp.Diff(map[int]int{201: 1, 409: requests - 1}, counts); diff != "" {
+		t.Errorf("status code counts mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// barrierArtifactGetter は QueryArtifacts の最初の parties 回の呼び出しを、全員がそろうまで待たせる。
+// サービスの重複確認がすべて保存より先に終わる状況を再現するために使う。
+type barrierArtifactGetter struct {
+	repository.ArtifactGetter
+
+	parties int32
+	arrived atomic.Int32
+	release chan struct{}
+}
+
+func (b *barrierArtifactGetter) QueryArtifacts(query repository.ArtifactQuery) (*repository.ArtifactQueryResult, error) {
+	result, err := b.ArtifactGetter.QueryArtifacts(query)
+	switch arrived := b.arrived.Add(1); {
+	case arrived == b.parties:
+		close(b.release)
+	case arrived < b.parties:
+		<-b.release
+	}
+	return result, err
+}
+
+func TestUpdateArtifact(t *testing.T) {
+	primaryValue := 46.6
+	testUpdateArtifactRequestParam := CreateArtifactRequestParam{
+		ArtifactSet: "Gladiator",
+		Type:        "FLOWER",
+		Level:       20,
+		PrimaryStat: PrimaryStatRequestParam{
+			Type:  "ATK_PERCENT",
+			Value: &primaryValue,
+		},
+		Substats: []StatRequestParam{
+			{
+				Type:  "CRIT_RATE",
+				Value: 3.9,
+			},
+		},
+	}
+
+	testArtifactDTO := &service.ArtifactDTO{
+		ID:    "test-id",
+		Set:   "Gladiator",
+		Type:  "FLOWER",
+		Level: 20,
+		PrimaryStat: service.StatusDTO{
+			Type:  "ATK_PERCENT",
+			Value: 46.6,
+		},
+		SubStat: []service.StatusDTO{
+			{
+				Type:  "CRIT_RATE",
+				Value: 3.9,
+			},
+		},
+	}
+	testArtifactResponse, _ := json.Marshal(testArtifactDTO)
+
+	tests := []struct {
+		name string
+
+		// GIVEN
+		mockArtifactDTO         *service.ArtifactDTO
+		mockUpdateArtifactError error
+
+		// WHEN
+		updateArtifactRequestParamByte []byte
+
+		// THEN
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name: "ShouldUpdateArtifactSuccessfully",
+
+			updateArtifactRequestParamByte: func() []byte {
+				body, _ := json.Marshal(testUpdateArtifactRequestParam)
+				return body
+			}(),
+
+			mockArtifactDTO: testArtifactDTO,
+
+			expectedStatusCode: 200,
+			expectedResponse:   string(testArtifactResponse),
+		},
+		{
+			name: "ShouldReturnErrorWhenUpdateArtifactRequestParamIsInvalid",
+
+			updateArtifactRequestParamByte: []byte(`invalid`),
+
+			expectedStatusCode: 400,
+			expectedResponse:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid request body: invalid character 'i' looking for beginning of value","instance":"/artifact/test-id","code":"invalid_request_body"}`,
+		},
+		{
+			name: "ShouldReturnErrorWhenArtifactNotFound",
+
+			updateArtifactRequestParamByte: func() []byte {
+				body, _ := json.Marshal(testUpdateArtifactRequestParam)
+				return body
+			}(),
+
+			mockUpdateArtifactError: repository.ErrArtifactNotFound,
+
+			expectedStatusCode: 404,
+			expectedResponse:   `{"type":"about:blank","title":"Not Found","status":404,"detail":"artifact not found","instance":"/artifact/test-id","code":"artifact_not_found"}`,
+		},
+		{
+			name: "ShouldReturnErrorWhenUpdateArtifactFails",
+
+			updateArtifactRequestParamByte: func() []byte {
+				body, _ := json.Marshal(testUpdateArtifactRequestParam)
+				return body
+			}(),
+
+			mockUpdateArtifactError: errors.New("artifact updater error"),
+
+			expectedStatusCode: 500,
+			expectedResponse:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"Internal server error","instance":"/artifact/test-id","code":"internal"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &service.MockUpdateArtifactService{
+				MockArtifact:            tt.mockArtifactDTO,
+				MockUpdateArtifactError: tt.mockUpdateArtifactError,
+			}
+
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(ErrorHandler())
+			r.PUT("/artifact/:id", UpdateArtifact(service))
+
+			w := httptest.NewRecorder()
+
+			req := httptest.NewRequest("PUT", "/artifact/test-id", bytes.NewBuffer(tt.updateArtifactRequestParamByte))
+			req.Header.Set("Content-Type", "application/json")
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatusCode, w.Code)
+			}
+
+			if diff := cmp.Diff(tt.expectedResponse, w.Body.String()); diff != "" {
+				t.Errorf("Response mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPatchArtifact(t *testing.T) {
+	testArtifactDTO := &service.ArtifactDTO{
+		ID:    "test-id",
+		Set:   "Gladiator",
+		Type:  "FLOWER",
+		Level: 20,
+		PrimaryStat: service.StatusDTO{
+			Type:  "ATK_PERCENT",
+			Value: 46.6,
+		},
+		SubStat: []service.StatusDTO{},
+	}
+	testArtifactResponse, _ := json.Marshal(testArtifactDTO)
+
+	tests := []struct {
+		name string
+
+		// GIVEN
+		mockArtifactDTO        *service.ArtifactDTO
+		mockPatchArtifactError error
+
+		// WHEN
+		patchArtifactRequestParamByte []byte
+
+		// THEN
+		expectedStatusCode   int
+		expectedResponse     string
+		expectedPatchCommand *service.PatchArtifactCommand
+	}{
+		{
+			name: "ShouldPatchArtifactSuccessfully",
+
+			patchArtifactRequestParamByte: []byte(`{"level":20,"primary_stat":{"value":46.6}}`),
+
+			mockArtifactDTO: testArtifactDTO,
+
+			expectedStatusCode: 200,
+			expectedResponse:   string(testArtifactResponse),
+		},
+		{
+			name: "ShouldUnequipArtifactWhenLocationIsNull",
+
+			patchArtifactRequestParamByte: []byte(`{"locked":true,"location":null}`),
+
+			mockArtifactDTO: testArtifactDTO,
+
+			expectedStatusCode: 200,
+			expectedResponse:   string(testArtifactResponse),
+			expectedPatchCommand: &service.PatchArtifactCommand{
+				Locked:   func() *bool { locked := true; return &locked }(),
+				Location: func() *string { unequipped := ""; return &unequipped }(),
+			},
+		},
+		{
+			name: "ShouldReturnErrorWhenRequiredFieldIsNull",
+
+			patchArtifactRequestParamByte: []byte(`{"level":null}`),
+
+			expectedStatusCode: 400,
+			expectedResponse:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"null is not allowed: level cannot be removed","instance":"/artifact/test-id","code":"null_not_allowed","errors":[{"field":"level","code":"null_not_allowed","message":"null is not allowed: level cannot be removed"}]}`,
+		},
+		{
+			name: "ShouldReturnErrorWhenPrimaryStatValueIsNull",
+
+			patchArtifactRequestParamByte: []byte(`{"primary_stat":{"type":"HP_FLAT","value":null}}`),
+
+			expectedStatusCode: 400,
+			expectedResponse:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"null is not allowed: primary_stat.value cannot be removed","instance":"/artifact/test-id","code":"null_not_allowed","errors":[{"field":"primary_stat.value","code":"null_not_allowed","message":"null is not allowed: primary_stat.value cannot be removed"}]}`,
+		},
+		{
+			name: "ShouldReturnErrorWhenPatchArtifactRequestParamIsInvalid",
+
+			patchArtifactRequestParamByte: []byte(`invalid`),
+
+			expectedStatusCode: 400,
+			expectedResponse:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid request body: invalid character 'i' looking for beginning of value","instance":"/artifact/test-id","code":"invalid_request_body"}`,
+		},
+		{
+			name: "ShouldReturnErrorWhenArtifactNotFound",
+
+			patchArtifactRequestParamByte: []byte(`{"level":20}`),
+
+			mockPatchArtifactError: repository.ErrArtifactNotFound,
+
+			expectedStatusCode: 404,
+			expectedResponse:   `{"type":"about:blank","title":"Not Found","status":404,"detail":"artifact not found","instance":"/artifact/test-id","code":"artifact_not_found"}`,
+		},
+		{
+			name: "ShouldReturnErrorWhenPatchArtifactFails",
+
+			patchArtifactRequestParamByte: []byte(`{"level":20}`),
+
+			mockPatchArtifactError: errors.New("artifact updater error"),
+
+			expectedStatusCode: 500,
+			expectedResponse:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"Internal server error","instance":"/artifact/test-id","code":"internal"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &service.MockPatchArtifactService{
+				MockArtifact:           tt.mockArtifactDTO,
+				MockPatchArtifactError: tt.mockPatchArtifactError,
+			}
+
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(ErrorHandler())
+			r.PATCH("/artifact/:id", PatchArtifact(service))
+
+			w := httptest.NewRecorder()
+
+			req := httptest.NewRequest("PATCH", "/artifact/test-id", bytes.NewBuffer(tt.patchArtifactRequestParamByte))
+			req.Header.Set("Content-Type", "application/merge-patch+json")
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatusCode, w.Code)
+			}
+
+			if diff := cmp.Diff(tt.expectedResponse, w.Body.String()); diff != "" {
+				t.Errorf("Response mismatch (-want +got):\n%s", diff)
+			}
+
+			if tt.expectedPatchCommand != nil {
+				if diff := cmp.Diff(*tt.expectedPatchCommand, service.PatchCommand); diff != "" {
+					t.Errorf("PatchCommand mismatch (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestDeleteArtifact(t *testing.T) {
+	tests := []struct {
+		name string
+
+		// GIVEN
+		mockDeleteArtifactError error
+
+		// THEN
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name: "ShouldDeleteArtifactSuccessfully",
+
+			expectedStatusCode: 200,
+			expectedResponse:   `{"message":"Artifact deleted successfully"}`,
+		},
+		{
+			name: "ShouldReturnErrorWhenArtifactNotFound",
+
+			mockDeleteArtifactError: repository.ErrArtifactNotFound,
+
+			expectedStatusCode: 404,
+			expectedResponse:   `{"type":"about:blank","title":"Not Found","status":404,"detail":"artifact not found","instance":"/artifact/test-id","code":"artifact_not_found"}`,
+		},
+		{
+			name: "ShouldReturnErrorWhenDeleteArtifactFails",
+
+			mockDeleteArtifactError: errors.New("artifact deleter error"),
+
+			expectedStatusCode: 500,
+			expectedResponse:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"Internal server error","instance":"/artifact/test-id","code":"internal"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &service.MockDeleteArtifactService{
+				MockDeleteArtifactError: tt.mockDeleteArtifactError,
+			}
+
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(ErrorHandler())
+			r.DELETE("/artifact/:id", DeleteArtifact(service))
+
+			w := httptest.NewRecorder()
+
+			req := httptest.NewRequest("DELETE", "/artifact/test-id", nil)
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatusCode, w.Code)
+			}
+
 			if diff := cmp.Diff(tt.expectedResponse, w.Body.String()); diff != "" {
 				t.Errorf("Response mismatch (-want +got):\n%s", diff)
 			}
@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+const APIKeyHeader = "X-API-Key"
+
+// principalContextKey は認証された API キーの権限を gin.Context に保存するキー。
+const principalContextKey = "principal"
+
+// Authenticate は Authorization: Bearer ヘッダーか X-API-Key ヘッダーの API キーを検証する。
+// 鍵のないリクエストは、読み取りかつ anonymousRead が true の場合のみ通し、それ以外は 401 にする。
+// 書き込みのリクエストは read-write の鍵でなければ 403 にする。
+// アカウントごとの権限は AuthorizeAccount と RequireAllAccounts で判定する。
+func Authenticate(authenticator service.AuthenticateServiceInterface, anonymousRead bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key, presented := apiKeyFromRequest(c)
+		if !presented {
+			if anonymousRead && isReadMethod(c.Request.Method) {
+				c.Next()
+				return
+			}
+			abortUnauthenticated(c, service.ClassifyError(service.ErrAuthenticationRequired))
+			return
+		}
+
+		principal, err := authenticator.Authenticate(key)
+		if err != nil {
+			abortUnauthenticated(c, err)
+			return
+		}
+		if !isReadMethod(c.Request.Method) {
+			if err := principal.AuthorizeWrite(); err != nil {
+				c.Error(err)
+				c.Abort()
+				return
+			}
+		}
+
+		c.Set(principalContextKey, principal)
+		c.Next()
+	}
+}
+
+// AuthorizeAccount はパスの :account のアカウントを扱えない API キーのリクエストを 403 にする。
+// 鍵のないリクエストは Authenticate が許可した読み取りで、publicAccounts のアカウントのみ通し、それ以外は 401 にする。
+// 特定のアカウントに限った鍵でも、鍵を外せば他のアカウントを読めてしまうことがないようにするため。
+func AuthorizeAccount(publicAccounts []string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := PrincipalFromContext(c)
+		if !ok {
+			if !canReadAccount(c, publicAccounts, c.Param("account")) {
+				abortUnauthenticated(c, service.ClassifyError(service.ErrAuthenticationRequired))
+				return
+			}
+			c.Next()
+			return
+		}
+		if err := principal.AuthorizeAccount(c.Param("account")); err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// canReadAccount はリクエストの API キーでアカウントを扱えるかを返す。鍵のないリクエストでは publicAccounts にあるかを返す。
+func canReadAccount(c *gin.Context, publicAccounts []string, account string) bool {
+	principal, ok := PrincipalFromContext(c)
+	if !ok {
+		return slices.Contains(publicAccounts, account)
+	}
+	return principal.AuthorizeAccount(account) == nil
+}
+
+// RequireAllAccounts はアカウントの作成や共有のスコアプロファイルの変更など、
+// 特定のアカウントに属さない操作をすべてのアカウントを扱える API キーに限る。
+func RequireAllAccounts() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := PrincipalFromContext(c)
+		if !ok {
+			abortUnauthenticated(c, service.ClassifyError(service.ErrAuthenticationRequired))
+			return
+		}
+		if err := principal.AuthorizeAllAccounts(); err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// PrincipalFromContext は Authenticate が検証した API キーの権限を返す。鍵のないリクエストでは false を返す。
+func PrincipalFromContext(c *gin.Context) (*service.Principal, bool) {
+	value, exists := c.Get(principalContextKey)
+	if !exists {
+		return nil, false
+	}
+	principal, ok := value.(*service.Principal)
+	return principal, ok
+}
+
+func apiKeyFromRequest(c *gin.Context) (string, bool) {
+	if authorization := c.GetHeader("Authorization"); authorization != "" {
+		scheme, key, _ := strings.Cut(authorization, " ")
+		if !strings.EqualFold(scheme, "Bearer") {
+			// 鍵として扱えない値を送ってきた場合も、鍵がないのではなく不正な鍵として拒否する
+			return "", true
+		}
+		return strings.TrimSpace(key), true
+	}
+	if key := c.GetHeader(APIKeyHeader); key != "" {
+		return key, true
+	}
+	return "", false
+}
+
+func isReadMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// abortUnauthenticated は認証の失敗であれば WWW-Authenticate ヘッダーを付けて中断する。
+func abortUnauthenticated(c *gin.Context, err error) {
+	if service.ClassifyError(err).Kind == service.ERROR_KIND_UNAUTHENTICATED {
+		c.Header("WWW-Authenticate", `Bearer realm="genshin-artifact-db"`)
+	}
+	c.Error(err)
+	c.Abort()
+}
@@ -0,0 +1,269 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAuthenticate(t *testing.T) {
+	readWriteAlt := &service.Principal{KeyID: "rw", Scope: entity.API_KEY_SCOPE_READ_WRITE, Accounts: []string{"alt"}}
+	readOnlyAll := &service.Principal{KeyID: "ro", Scope: entity.API_KEY_SCOPE_READ_ONLY, Accounts: []string{entity.APIKeyAllAccounts}}
+	readWriteAll := &service.Principal{KeyID: "admin", Scope: entity.API_KEY_SCOPE_READ_WRITE, Accounts: []string{entity.APIKeyAllAccounts}}
+
+	tests := []struct {
+		name string
+
+		// GIVEN
+		mockPrincipal         *service.Principal
+		mockAuthenticateError error
+		anonymousRead         bool
+		publicAccounts        []string
+
+		// WHEN
+		method string
+		path   string
+		header map[string]string
+
+		// THEN
+		expectedStatusCode      int
+		expectedCode            string
+		expectedKey             string
+		expectedWWWAuthenticate bool
+	}{
+		{
+			name: "ShouldAllowAnonymousReadOfPublicAccount",
+
+			anonymousRead:  true,
+			publicAccounts: []string{"alt"},
+
+			method: "GET",
+			path:   "/accounts/alt/artifacts",
+
+			expectedStatusCode: 200,
+		},
+		{
+			name: "ShouldRejectAnonymousReadOfNonPublicAccount",
+
+			anonymousRead: true,
+
+			method: "GET",
+			path:   "/accounts/alt/artifacts",
+
+			expectedStatusCode:      401,
+			expectedCode:            "authentication_required",
+			expectedWWWAuthenticate: true,
+		},
+		{
+			// alt に限った鍵の持ち主が鍵を外しても、公開されていない default は読めない
+			name: "ShouldNotReachOtherAccountByOmittingKey",
+
+			anonymousRead:  true,
+			publicAccounts: []string{"alt"},
+
+			method: "GET",
+			path:   "/accounts/default/artifacts",
+
+			expectedStatusCode:      401,
+			expectedCode:            "authentication_required",
+			expectedWWWAuthenticate: true,
+		},
+		{
+			name: "ShouldRejectAnonymousReadWhenRequired",
+
+			method: "GET",
+			path:   "/accounts/alt/artifacts",
+
+			expectedStatusCode:      401,
+			expectedCode:            "authentication_required",
+			expectedWWWAuthenticate: true,
+		},
+		{
+			name: "ShouldRejectAnonymousWrite",
+
+			anonymousRead:  true,
+			publicAccounts: []string{"alt"},
+
+			method: "POST",
+			path:   "/accounts/alt/artifact",
+
+			expectedStatusCode:      401,
+			expectedCode:            "authentication_required",
+			expectedWWWAuthenticate: true,
+		},
+		{
+			name: "ShouldAllowWriteWithBearerKey",
+
+			mockPrincipal: readWriteAlt,
+
+			method: "POST",
+			path:   "/accounts/alt/artifact",
+			header: map[string]string{"Authorization": "Bearer gadb_rw_secret"},
+
+			expectedStatusCode: 200,
+			expectedKey:        "gadb_rw_secret",
+		},
+		{
+			name: "ShouldAllowWriteWithAPIKeyHeader",
+
+			mockPrincipal: readWriteAlt,
+
+			method: "DELETE",
+			path:   "/accounts/alt/artifact/id",
+			header: map[string]string{APIKeyHeader: "gadb_rw_secret"},
+
+			expectedStatusCode: 200,
+			expectedKey:        "gadb_rw_secret",
+		},
+		{
+			name: "ShouldRejectInvalidKey",
+
+			mockAuthenticateError: service.ClassifyError(service.ErrInvalidAPIKey),
+			anonymousRead:         true,
+
+			method: "GET",
+			path:   "/accounts/alt/artifacts",
+			header: map[string]string{"Authorization": "Bearer wrong"},
+
+			expectedStatusCode:      401,
+			expectedCode:            "invalid_api_key",
+			expectedKey:             "wrong",
+			expectedWWWAuthenticate: true,
+		},
+		{
+			name: "ShouldRejectNonBearerAuthorization",
+
+			mockAuthenticateError: service.ClassifyError(service.ErrInvalidAPIKey),
+			anonymousRead:         true,
+
+			method: "GET",
+			path:   "/accounts/alt/artifacts",
+			header: map[string]string{"Authorization": "Basic dXNlcjpwYXNz"},
+
+			expectedStatusCode:      401,
+			expectedCode:            "invalid_api_key",
+			expectedWWWAuthenticate: true,
+		},
+		{
+			name: "ShouldRejectWriteWithReadOnlyKey",
+
+			mockPrincipal: readOnlyAll,
+
+			method: "PATCH",
+			path:   "/accounts/alt/artifact/id",
+			header: map[string]string{"Authorization": "Bearer gadb_ro_secret"},
+
+			expectedStatusCode: 403,
+			expectedCode:       "insufficient_scope",
+			expectedKey:        "gadb_ro_secret",
+		},
+		{
+			name: "ShouldRejectOtherAccount",
+
+			mockPrincipal: readWriteAlt,
+
+			method: "GET",
+			path:   "/accounts/default/artifacts",
+			header: map[string]string{"Authorization": "Bearer gadb_rw_secret"},
+
+			expectedStatusCode: 403,
+			expectedCode:       "account_forbidden",
+			expectedKey:        "gadb_rw_secret",
+		},
+		{
+			name: "ShouldRejectAccountCreationWithoutAllAccounts",
+
+			mockPrincipal: readWriteAlt,
+
+			method: "POST",
+			path:   "/accounts",
+			header: map[string]string{"Authorization": "Bearer gadb_rw_secret"},
+
+			expectedStatusCode: 403,
+			expectedCode:       "account_forbidden",
+			expectedKey:        "gadb_rw_secret",
+		},
+		{
+			name: "ShouldAllowAccountCreationWithAllAccounts",
+
+			mockPrincipal: readWriteAll,
+
+			method: "POST",
+			path:   "/accounts",
+			header: map[string]string{"Authorization": "Bearer gadb_admin_secret"},
+
+			expectedStatusCode: 200,
+			expectedKey:        "gadb_admin_secret",
+		},
+		{
+			name: "ShouldReturnInternalErrorWhenKeysCannotBeRead",
+
+			mockAuthenticateError: errors.New("permission denied"),
+
+			method: "GET",
+			path:   "/accounts/alt/artifacts",
+			header: map[string]string{"Authorization": "Bearer gadb_rw_secret"},
+
+			expectedStatusCode: 500,
+			expectedCode:       "internal",
+			expectedKey:        "gadb_rw_secret",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authenticator := &service.MockAuthenticateService{
+				MockPrincipal:         tt.mockPrincipal,
+				MockAuthenticateError: tt.mockAuthenticateError,
+			}
+			ok := func(c *gin.Context) { c.Status(200) }
+
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(ErrorHandler())
+			r.Use(Authenticate(authenticator, tt.anonymousRead))
+			r.POST("/accounts", RequireAllAccounts(), ok)
+			account := r.Group("/accounts/:account", AuthorizeAccount(tt.publicAccounts))
+			account.GET("/artifacts", ok)
+			account.POST("/artifact", ok)
+			account.PATCH("/artifact/:id", ok)
+			account.DELETE("/artifact/:id", ok)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			for name, value := range tt.header {
+				req.Header.Set(name, value)
+			}
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d: %s", tt.expectedStatusCode, w.Code, w.Body.String())
+			}
+
+			if tt.expectedCode != "" {
+				var problem Problem
+				if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+					t.Fatalf("failed to decode response: %v", err)
+				}
+				if diff := cmp.Diff(tt.expectedCode, problem.Code); diff != "" {
+					t.Errorf("Code mismatch (-want +got):\n%s", diff)
+				}
+			}
+
+			if diff := cmp.Diff(tt.expectedKey, authenticator.QueriedKey); diff != "" {
+				t.Errorf("Key mismatch (-want +got):\n%s", diff)
+			}
+
+			if got := w.Header().Get("WWW-Authenticate") != ""; got != tt.expectedWWWAuthenticate {
+				t.Errorf("Expected WWW-Authenticate header: %v, got %q", tt.expectedWWWAuthenticate, w.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
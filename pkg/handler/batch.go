@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"fmt"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CustomMethods は "/artifacts:batch" のようなカスタムメソッドを振り分ける。
+// gin はパス中の ':' をパラメータとして扱うため、"/artifacts:method" として登録し、
+// パラメータの値 (":batch") で methods からハンドラーを選ぶ。
+func CustomMethods(param string, methods map[string]gin.HandlerFunc) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		method, ok := methods[c.Param(param)]
+		if !ok {
+			c.Error(&service.Error{
+				Kind: service.ERROR_KIND_NOT_FOUND,
+				Code: "route_not_found",
+				Err:  fmt.Errorf("%w: %s %s", ErrRouteNotFound, c.Request.Method, c.Request.URL.Path),
+			})
+			return
+		}
+		method(c)
+	}
+}
+
+// BatchCreateArtifacts は POST /artifacts:batch?mode=atomic|best_effort で聖遺物をまとめて作成する。
+// ?on_duplicate=warn|reject|allow で内容が同じ聖遺物がある場合の扱いを CreateArtifact と同じように指定できる。
+// ボディは POST /artifact と同じ形式の配列で、各要素を CreateArtifact と同じ経路で検証する。
+// best_effort モードでは一部が失敗しても 200 を返し、要素ごとの結果を results に含める。
+func BatchCreateArtifacts(artifactService service.BatchCreateArtifactsServiceInterface) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		var createArtifactRequestParams []CreateArtifactRequestParam
+		if err := c.ShouldBindJSON(&createArtifactRequestParams); err != nil {
+			c.Error(invalidRequestBodyError(err))
+			return
+		}
+
+		artifactCommands := make([]service.CreateArtifactCommand, 0, len(createArtifactRequestParams))
+		for _, param := range createArtifactRequestParams {
+			artifactCommands = append(artifactCommands, toCreateArtifactCommand(param))
+		}
+
+		result, err := artifactService.BatchCreateArtifacts(c.Query("mode"), service.DuplicatePolicy(c.Query("on_duplicate")), artifactCommands)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		status := 201
+		if result.Failed > 0 {
+			status = 200
+		}
+		c.JSON(status, result)
+	}
+}
@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBatchCreateArtifacts(t *testing.T) {
+	tests := []struct {
+		name string
+
+		// GIVEN
+		mockBatchCreateResult         *service.BatchCreateResultDTO
+		mockBatchCreateArtifactsError error
+
+		// WHEN
+		url         string
+		requestBody string
+
+		// THEN
+		expectedStatusCode int
+		expectedResponse   string
+		expectedMode       string
+		expectedPolicy     service.DuplicatePolicy
+		expectedCommands   []service.CreateArtifactCommand
+	}{
+		{
+			name: "ShouldCreateArtifactsInBatchSuccessfully",
+
+			mockBatchCreateResult: &service.BatchCreateResultDTO{
+				Mode:    service.BATCH_MODE_ATOMIC,
+				Created: 1,
+				Results: []*service.BatchItemResultDTO{{Index: 0, ID: "new-id", DuplicateOf: []string{"old-id"}}},
+			},
+
+			url:         "/artifacts:batch?mode=atomic&on_duplicate=warn",
+			requestBody: `[{"artifact_set":"GladiatorsFinale","type":"FLOWER","rarity":5,"level":0,"primary_stat":{"type":"HP_FLAT"},"substats":[{"type":"CRIT_RATE","value":3.9}]}]`,
+
+			expectedStatusCode: 201,
+			expectedResponse:   `{"mode":"atomic","created":1,"failed":0,"results":[{"index":0,"id":"new-id","duplicate_of":["old-id"]}]}`,
+			expectedMode:       "atomic",
+			expectedPolicy:     service.DUPLICATE_POLICY_WARN,
+			expectedCommands: []service.CreateArtifactCommand{
+				{
+					ArtifactSet: "GladiatorsFinale",
+					Type:        "FLOWER",
+					Rarity:      5,
+					Level:       0,
+					PrimaryStat: service.PrimaryStatCommand{Type: "HP_FLAT"},
+					Substats:    []service.StatCommand{{Type: "CRIT_RATE", Value: 3.9}},
+				},
+			},
+		},
+		{
+			name: "ShouldReturnOKWithPerIndexErrorsWhenSomeArtifactsFail",
+
+			mockBatchCreateResult: &service.BatchCreateResultDTO{
+				Mode:    service.BATCH_MODE_BEST_EFFORT,
+				Created: 1,
+				Failed:  1,
+				Results: []*service.BatchItemResultDTO{
+					{Index: 0, ID: "new-id"},
+					{Index: 1, Error: &service.BatchItemErrorDTO{Code: "duplicate_artifact", Message: "duplicate artifact: same content as new-id"}},
+				},
+			},
+
+			url:         "/artifacts:batch?mode=best_effort&on_duplicate=reject",
+			requestBody: `[{},{}]`,
+
+			expectedStatusCode: 200,
+			expectedResponse:   `{"mode":"best_effort","created":1,"failed":1,"results":[{"index":0,"id":"new-id"},{"index":1,"error":{"code":"duplicate_artifact","message":"duplicate artifact: same content as new-id"}}]}`,
+			expectedMode:       "best_effort",
+			expectedPolicy:     service.DUPLICATE_POLICY_REJECT,
+		},
+		{
+			name: "ShouldReturnBadRequestWhenAtomicBatchIsRejected",
+
+			mockBatchCreateArtifactsError: &service.Error{
+				Kind:   service.ERROR_KIND_VALIDATION,
+				Code:   "batch_items_rejected",
+				Fields: []service.FieldError{{Field: "[1].level", Code: "invalid_level", Message: "invalid level"}},
+				Err:    fmt.Errorf("%w: 1 of 2 artifacts are invalid", service.ErrBatchItemsRejected),
+			},
+
+			url:         "/artifacts:batch",
+			requestBody: `[{},{}]`,
+
+			expectedStatusCode: 400,
+			expectedResponse:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"batch items rejected: 1 of 2 artifacts are invalid","instance":"/artifacts:batch","code":"batch_items_rejected","errors":[{"field":"[1].level","code":"invalid_level","message":"invalid level"}]}`,
+		},
+		{
+			name: "ShouldReturnBadRequestWhenBodyIsNotArray",
+
+			url:         "/artifacts:batch",
+			requestBody: `{}`,
+
+			expectedStatusCode: 400,
+			expectedResponse:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid request body: json: cannot unmarshal object into Go value of type []handler.CreateArtifactRequestParam","instance":"/artifacts:batch","code":"invalid_request_body"}`,
+		},
+		{
+			name: "ShouldReturnNotFoundWhenCustomMethodIsUnknown",
+
+			url:         "/artifacts:delete",
+			requestBody: `[]`,
+
+			expectedStatusCode: 404,
+			expectedResponse:   `{"type":"about:blank","title":"Not Found","status":404,"detail":"route not found: POST /artifacts:delete","instance":"/artifacts:delete","code":"route_not_found"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			artifactService := &service.MockBatchCreateArtifactsService{
+				MockBatchCreateResult:         tt.mockBatchCreateResult,
+				MockBatchCreateArtifactsError: tt.mockBatchCreateArtifactsError,
+			}
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(ErrorHandler())
+			r.POST("/artifacts:method", CustomMethods("method", map[string]gin.HandlerFunc{
+				":batch": BatchCreateArtifacts(artifactService),
+			}))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", tt.url, bytes.NewBufferString(tt.requestBody))
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatusCode, w.Code)
+			}
+
+			if diff := cmp.Diff(tt.expectedResponse, w.Body.String()); diff != "" {
+				t.Errorf("Response mismatch (-want +got):\n%s", diff)
+			}
+
+			if artifactService.QueriedMode != tt.expectedMode {
+				t.Errorf("Expected mode %s, got %s", tt.expectedMode, artifactService.QueriedMode)
+			}
+
+			if artifactService.QueriedPolicy != tt.expectedPolicy {
+				t.Errorf("Expected duplicate policy %s, got %s", tt.expectedPolicy, artifactService.QueriedPolicy)
+			}
+
+			if tt.expectedCommands != nil {
+				if diff := cmp.Diff(tt.expectedCommands, artifactService.QueriedCommands); diff != "" {
+					t.Errorf("Commands mismatch (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
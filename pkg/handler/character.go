@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+func GetCharacters(characterService service.GetCharactersServiceInterface) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		lang := c.Query("lang")
+
+		c.JSON(200, characterService.GetCharacters(lang))
+	}
+}
+
+func GetCharacter(characterService service.GetCharacterServiceInterface) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		key := c.Param("key")
+		lang := c.Query("lang")
+
+		character, err := characterService.GetCharacter(key, lang)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		c.JSON(200, character)
+	}
+}
@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGetCharacters(t *testing.T) {
+	testCharacters := []*service.CharacterDTO{
+		{
+			Key:           "RaidenShogun",
+			Name:          "Raiden Shogun",
+			Names:         map[string]string{"en": "Raiden Shogun"},
+			Element:       "ELECTRO",
+			WeaponType:    "POLEARM",
+			Rarity:        5,
+			BaseStats:     service.CharacterBaseStatsDTO{HP: 12907, ATK: 337, DEF: 789},
+			AscensionStat: service.StatusDTO{Type: "ENERGY_RECHARGE", Value: 32},
+		},
+	}
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.GET("/characters", GetCharacters(&service.MockGetCharactersService{
+		MockCharacters: testCharacters,
+	}))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/characters?lang=en", nil)
+	r.ServeHTTP(w, req)
+
+	if w.Code != 200 {
+		t.Errorf("Expected status code %d, got %d", 200, w.Code)
+	}
+
+	expectedResponse, _ := json.Marshal(testCharacters)
+	if diff := cmp.Diff(string(expectedResponse), w.Body.String()); diff != "" {
+		t.Errorf("Response mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGetCharacter(t *testing.T) {
+	testCharacter := &service.CharacterDTO{
+		Key:           "RaidenShogun",
+		Name:          "雷電将軍",
+		Names:         map[string]string{"en": "Raiden Shogun", "ja": "雷電将軍"},
+		Element:       "ELECTRO",
+		WeaponType:    "POLEARM",
+		Rarity:        5,
+		BaseStats:     service.CharacterBaseStatsDTO{HP: 12907, ATK: 337, DEF: 789},
+		AscensionStat: service.StatusDTO{Type: "ENERGY_RECHARGE", Value: 32},
+	}
+
+	tests := []struct {
+		name string
+
+		// GIVEN
+		mockCharacter         *service.CharacterDTO
+		mockGetCharacterError error
+
+		// WHEN
+		key string
+
+		// THEN
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name: "ShouldGetCharacterSuccessfully",
+
+			mockCharacter: testCharacter,
+
+			key: "RaidenShogun",
+
+			expectedStatusCode: 200,
+			expectedResponse: func() string {
+				response, _ := json.Marshal(testCharacter)
+				return string(response)
+			}(),
+		},
+		{
+			name: "ShouldReturnErrorWhenCharacterNotFound",
+
+			mockGetCharacterError: service.ErrCharacterNotFound,
+
+			key: "Raiden",
+
+			expectedStatusCode: 404,
+			expectedResponse:   `{"type":"about:blank","title":"Not Found","status":404,"detail":"character not found","instance":"/characters/Raiden","code":"character_not_found"}`,
+		},
+		{
+			name: "ShouldReturnErrorWhenGetCharacterFails",
+
+			mockGetCharacterError: errors.New("internal server error"),
+
+			key: "RaidenShogun",
+
+			expectedStatusCode: 500,
+			expectedResponse:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"Internal server error","instance":"/characters/RaidenShogun","code":"internal"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &service.MockGetCharacterService{
+				MockCharacter:         tt.mockCharacter,
+				MockGetCharacterError: tt.mockGetCharacterError,
+			}
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(ErrorHandler())
+			r.GET("/characters/:key", GetCharacter(service))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", fmt.Sprintf("/characters/%s?lang=ja", tt.key), nil)
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatusCode, w.Code)
+			}
+
+			if diff := cmp.Diff(tt.expectedResponse, w.Body.String()); diff != "" {
+				t.Errorf("Response mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
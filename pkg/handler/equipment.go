@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type EquipArtifactRequestParam struct {
+	ArtifactID string `json:"artifact_id"`
+}
+
+// GetLoadout は GET /characters/:character/loadout でキャラクターが部位ごとに装備している聖遺物を返す。
+func GetLoadout(equipmentService service.GetLoadoutServiceInterface) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		character := c.Param("character")
+
+		loadout, err := equipmentService.GetLoadout(character)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		c.JSON(200, loadout)
+	}
+}
+
+// EquipArtifact は POST /characters/:character/loadout でボディの artifact_id の聖遺物を装備させ、装備後の状態を返す。
+// 同じ部位に装備していた聖遺物は、装備させた聖遺物の元の持ち主と入れ替える。
+func EquipArtifact(equipmentService service.EquipArtifactServiceInterface) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		character := c.Param("character")
+
+		var equipArtifactRequestParam EquipArtifactRequestParam
+		if err := c.ShouldBindJSON(&equipArtifactRequestParam); err != nil {
+			c.Error(invalidRequestBodyError(err))
+			return
+		}
+
+		loadout, err := equipmentService.EquipArtifact(character, service.EquipArtifactCommand{
+			ArtifactID: equipArtifactRequestParam.ArtifactID,
+		})
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		c.JSON(200, loadout)
+	}
+}
+
+// UnequipArtifact は DELETE /characters/:character/loadout/:type で部位の聖遺物を外し、外した後の状態を返す。
+func UnequipArtifact(equipmentService service.UnequipArtifactServiceInterface) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		character := c.Param("character")
+		artifactType := c.Param("type")
+
+		loadout, err := equipmentService.UnequipArtifact(character, artifactType)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		c.JSON(200, loadout)
+	}
+}
@@ -0,0 +1,250 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-cmp/cmp"
+)
+
+var testLoadout = &service.LoadoutDTO{
+	Character: "RaidenShogun",
+	Flower: &service.ArtifactDTO{
+		ID:       "raiden-flower",
+		Type:     "FLOWER",
+		Set:      "EmblemOfSeveredFate",
+		Rarity:   5,
+		Location: "RaidenShogun",
+	},
+}
+
+func TestGetLoadout(t *testing.T) {
+	tests := []struct {
+		name string
+
+		// GIVEN
+		mockLoadout         *service.LoadoutDTO
+		mockGetLoadoutError error
+
+		// WHEN
+		character string
+
+		// THEN
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name: "ShouldGetLoadoutSuccessfully",
+
+			mockLoadout: testLoadout,
+
+			character: "RaidenShogun",
+
+			expectedStatusCode: 200,
+			expectedResponse: func() string {
+				response, _ := json.Marshal(testLoadout)
+				return string(response)
+			}(),
+		},
+		{
+			name: "ShouldReturnErrorWhenCharacterNotFound",
+
+			mockGetLoadoutError: service.ErrCharacterNotFound,
+
+			character: "Raiden",
+
+			expectedStatusCode: 404,
+			expectedResponse:   `{"type":"about:blank","title":"Not Found","status":404,"detail":"character not found","instance":"/characters/Raiden/loadout","code":"character_not_found"}`,
+		},
+		{
+			name: "ShouldReturnErrorWhenGetLoadoutFails",
+
+			mockGetLoadoutError: errors.New("internal server error"),
+
+			character: "RaidenShogun",
+
+			expectedStatusCode: 500,
+			expectedResponse:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"Internal server error","instance":"/characters/RaidenShogun/loadout","code":"internal"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &service.MockGetLoadoutService{
+				MockLoadout:         tt.mockLoadout,
+				MockGetLoadoutError: tt.mockGetLoadoutError,
+			}
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(ErrorHandler())
+			r.GET("/characters/:character/loadout", GetLoadout(service))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/characters/"+tt.character+"/loadout", nil)
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatusCode, w.Code)
+			}
+
+			if diff := cmp.Diff(tt.expectedResponse, w.Body.String()); diff != "" {
+				t.Errorf("Response mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEquipArtifact(t *testing.T) {
+	tests := []struct {
+		name string
+
+		// GIVEN
+		mockLoadout            *service.LoadoutDTO
+		mockEquipArtifactError error
+
+		// WHEN
+		requestBody string
+
+		// THEN
+		expectedStatusCode int
+		expectedResponse   string
+		expectedCommand    service.EquipArtifactCommand
+	}{
+		{
+			name: "ShouldEquipArtifactSuccessfully",
+
+			mockLoadout: testLoadout,
+
+			requestBody: `{"artifact_id":"raiden-flower"}`,
+
+			expectedStatusCode: 200,
+			expectedResponse: func() string {
+				response, _ := json.Marshal(testLoadout)
+				return string(response)
+			}(),
+			expectedCommand: service.EquipArtifactCommand{ArtifactID: "raiden-flower"},
+		},
+		{
+			name: "ShouldReturnErrorWhenRequestBodyIsInvalid",
+
+			requestBody: `invalid`,
+
+			expectedStatusCode: 400,
+			expectedResponse:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid request body: invalid character 'i' looking for beginning of value","instance":"/characters/RaidenShogun/loadout","code":"invalid_request_body"}`,
+		},
+		{
+			name: "ShouldReturnErrorWhenEquipArtifactFails",
+
+			mockEquipArtifactError: errors.New("internal server error"),
+
+			requestBody: `{"artifact_id":"raiden-flower"}`,
+
+			expectedStatusCode: 500,
+			expectedResponse:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"Internal server error","instance":"/characters/RaidenShogun/loadout","code":"internal"}`,
+			expectedCommand:    service.EquipArtifactCommand{ArtifactID: "raiden-flower"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &service.MockEquipArtifactService{
+				MockLoadout:            tt.mockLoadout,
+				MockEquipArtifactError: tt.mockEquipArtifactError,
+			}
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(ErrorHandler())
+			r.POST("/characters/:character/loadout", EquipArtifact(service))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/characters/RaidenShogun/loadout", strings.NewReader(tt.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatusCode, w.Code)
+			}
+
+			if diff := cmp.Diff(tt.expectedResponse, w.Body.String()); diff != "" {
+				t.Errorf("Response mismatch (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tt.expectedCommand, service.QueriedCommand); diff != "" {
+				t.Errorf("Command mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUnequipArtifact(t *testing.T) {
+	tests := []struct {
+		name string
+
+		// GIVEN
+		mockLoadout              *service.LoadoutDTO
+		mockUnequipArtifactError error
+
+		// WHEN
+		artifactType string
+
+		// THEN
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name: "ShouldUnequipArtifactSuccessfully",
+
+			mockLoadout: &service.LoadoutDTO{Character: "RaidenShogun"},
+
+			artifactType: "FLOWER",
+
+			expectedStatusCode: 200,
+			expectedResponse:   `{"character":"RaidenShogun","flower":null,"plume":null,"sands":null,"goblet":null,"circlet":null}`,
+		},
+		{
+			name: "ShouldReturnErrorWhenUnequipArtifactFails",
+
+			mockUnequipArtifactError: errors.New("internal server error"),
+
+			artifactType: "FLOWER",
+
+			expectedStatusCode: 500,
+			expectedResponse:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"Internal server error","instance":"/characters/RaidenShogun/loadout/FLOWER","code":"internal"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &service.MockUnequipArtifactService{
+				MockLoadout:              tt.mockLoadout,
+				MockUnequipArtifactError: tt.mockUnequipArtifactError,
+			}
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(ErrorHandler())
+			r.DELETE("/characters/:character/loadout/:type", UnequipArtifact(service))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", "/characters/RaidenShogun/loadout/"+tt.artifactType, nil)
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatusCode, w.Code)
+			}
+
+			if diff := cmp.Diff(tt.expectedResponse, w.Body.String()); diff != "" {
+				t.Errorf("Response mismatch (-want +got):\n%s", diff)
+			}
+
+			if service.QueriedCharacter != "RaidenShogun" || service.QueriedArtifactType != tt.artifactType {
+				t.Errorf("Expected RaidenShogun %s, got %s %s", tt.artifactType, service.QueriedCharacter, service.QueriedArtifactType)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+const ProblemContentType = "application/problem+json"
+
+var (
+	ErrInvalidRequestBody = errors.New("invalid request body")
+	ErrNullNotAllowed     = errors.New("null is not allowed")
+	ErrInvalidQueryParam  = errors.New("invalid query parameter")
+	ErrRouteNotFound      = errors.New("route not found")
+)
+
+// Problem は RFC 7807 の problem details で、エラー時のレスポンスボディになる。
+// Code は機械可読なエラーコード、Errors は検証エラーの項目ごとの詳細。
+type Problem struct {
+	Type     string               `json:"type"`
+	Title    string               `json:"title"`
+	Status   int                  `json:"status"`
+	Detail   string               `json:"detail,omitempty"`
+	Instance string               `json:"instance,omitempty"`
+	Code     string               `json:"code"`
+	Errors   []service.FieldError `json:"errors,omitempty"`
+}
+
+var errorKindStatuses = map[service.ErrorKind]int{
+	service.ERROR_KIND_VALIDATION:      http.StatusBadRequest,
+	service.ERROR_KIND_NOT_FOUND:       http.StatusNotFound,
+	service.ERROR_KIND_CONFLICT:        http.StatusConflict,
+	service.ERROR_KIND_UNAUTHENTICATED: http.StatusUnauthorized,
+	service.ERROR_KIND_FORBIDDEN:       http.StatusForbidden,
+	service.ERROR_KIND_TOO_LARGE:       http.StatusRequestEntityTooLarge,
+	service.ERROR_KIND_INTERNAL:        http.StatusInternalServerError,
+}
+
+// ErrorHandler はハンドラーが c.Error で登録したエラーを problem+json のレスポンスに変換する。
+// ハンドラーはエラー時にレスポンスを書かず、c.Error を呼んで return するだけにする。
+// 内部エラーの詳細はクライアントに返さず、gin のロガーにのみ出力される。
+func ErrorHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		problem := newProblem(service.ClassifyError(c.Errors.Last().Err))
+		problem.Instance = c.Request.URL.Path
+		c.Header("Content-Type", ProblemContentType)
+		c.JSON(problem.Status, problem)
+	}
+}
+
+func newProblem(err *service.Error) *Problem {
+	status, ok := errorKindStatuses[err.Kind]
+	if !ok {
+		status = http.StatusInternalServerError
+	}
+
+	problem := &Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: err.Error(),
+		Code:   err.Code,
+		Errors: err.Fields,
+	}
+	if status == http.StatusInternalServerError {
+		problem.Detail = "Internal server error"
+	}
+	return problem
+}
+
+// invalidRequestBodyError は JSON のデコードエラーを検証エラーに変換する。
+// 型の不一致であれば、どの項目が誤っているかを含める。
+func invalidRequestBodyError(err error) error {
+	field := ""
+	var typeError *json.UnmarshalTypeError
+	if errors.As(err, &typeError) {
+		field = typeError.Field
+	}
+	return service.NewValidationError("invalid_request_body", field, fmt.Errorf("%w: %w", ErrInvalidRequestBody, err))
+}
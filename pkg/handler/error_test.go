@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/repository"
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestErrorHandler(t *testing.T) {
+	tests := []struct {
+		name string
+
+		// GIVEN
+		mockCreateArtifactError error
+
+		// WHEN
+		requestBody string
+
+		// THEN
+		expectedStatusCode  int
+		expectedContentType string
+		expectedResponse    string
+	}{
+		{
+			name: "ShouldMapValidationErrorToBadRequestWithFieldDetails",
+
+			mockCreateArtifactError: service.ClassifyError(entity.ErrInvalidArtifactSet),
+
+			requestBody: `{}`,
+
+			expectedStatusCode:  400,
+			expectedContentType: ProblemContentType,
+			expectedResponse:    `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid artifact set","instance":"/artifact","code":"invalid_artifact_set","errors":[{"field":"artifact_set","code":"invalid_artifact_set","message":"invalid artifact set"}]}`,
+		},
+		{
+			name: "ShouldMapConflictErrorToConflict",
+
+			mockCreateArtifactError: service.ClassifyError(repository.ErrArtifactAlreadyExists),
+
+			requestBody: `{}`,
+
+			expectedStatusCode:  409,
+			expectedContentType: ProblemContentType,
+			expectedResponse:    `{"type":"about:blank","title":"Conflict","status":409,"detail":"artifact already exists","instance":"/artifact","code":"artifact_already_exists"}`,
+		},
+		{
+			name: "ShouldClassifyUntypedErrorFromService",
+
+			mockCreateArtifactError: entity.ErrInvalidLevel,
+
+			requestBody: `{}`,
+
+			expectedStatusCode:  400,
+			expectedContentType: ProblemContentType,
+			expectedResponse:    `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid level","instance":"/artifact","code":"invalid_level","errors":[{"field":"level","code":"invalid_level","message":"invalid level"}]}`,
+		},
+		{
+			name: "ShouldHideInternalErrorDetails",
+
+			mockCreateArtifactError: errors.New("disk full"),
+
+			requestBody: `{}`,
+
+			expectedStatusCode:  500,
+			expectedContentType: ProblemContentType,
+			expectedResponse:    `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"Internal server error","instance":"/artifact","code":"internal"}`,
+		},
+		{
+			name: "ShouldReportFieldOfMistypedRequestBody",
+
+			requestBody: `{"rarity":"five"}`,
+
+			expectedStatusCode:  400,
+			expectedContentType: ProblemContentType,
+			expectedResponse:    `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid request body: json: cannot unmarshal string into Go struct field CreateArtifactRequestParam.rarity of type int","instance":"/artifact","code":"invalid_request_body","errors":[{"field":"rarity","code":"invalid_request_body","message":"invalid request body: json: cannot unmarshal string into Go struct field CreateArtifactRequestParam.rarity of type int"}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			artifactService := &service.MockCreateArtifactService{
+				MockCreateArtifactError: tt.mockCreateArtifactError,
+			}
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(ErrorHandler())
+			r.POST("/artifact", CreateArtifact(artifactService))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/artifact", bytes.NewBufferString(tt.requestBody))
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatusCode, w.Code)
+			}
+
+			if contentType := w.Header().Get("Content-Type"); contentType != tt.expectedContentType {
+				t.Errorf("Expected content type %s, got %s", tt.expectedContentType, contentType)
+			}
+
+			if diff := cmp.Diff(tt.expectedResponse, w.Body.String()); diff != "" {
+				t.Errorf("Response mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ExportSkippedTrailer は書き出さなかった聖遺物の数を返すトレーラー。
+const ExportSkippedTrailer = "X-Export-Skipped"
+
+// ExportArtifacts は GET /export?format=good|csv|jsonl で聖遺物を書き出す。
+// 絞り込みと並び順は GET /artifacts と同じクエリパラメータで指定でき、ページングの指定は無視する。
+// 聖遺物はページごとに読みながら書き出すため、GOOD 形式で表現できず書き出さなかった聖遺物の数は
+// 書き出しの後に X-Export-Skipped トレーラーで返す。
+func ExportArtifacts(exportService service.ExportArtifactsServiceInterface) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		queryCommand, err := toQueryArtifactsCommand(c)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		export, err := exportService.ExportArtifacts(c.Query("format"), *queryCommand)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		c.Header("Content-Type", export.ContentType)
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", export.FileName))
+		c.Header("Trailer", ExportSkippedTrailer)
+		c.Status(200)
+		// 書き出しを始めた後はステータスを変更できないため、エラーはログにのみ記録される
+		if err := export.Stream(c.Writer); err != nil {
+			c.Error(err)
+			return
+		}
+		c.Writer.Header().Set(ExportSkippedTrailer, strconv.Itoa(export.Skipped))
+	}
+}
@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/repository"
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExportArtifacts(t *testing.T) {
+	testArtifact := &entity.Artifact{
+		ID:          "test-id",
+		ArtifactSet: entity.ARTIFACT_SET_GLADIATORS_FINALOFFERING,
+		Type:        entity.ARTIFACT_TYPE_PLUME,
+		Rarity:      5,
+		Level:       0,
+		PrimaryStat: entity.PrimaryStat{Type: entity.ATK_FLAT, Value: 47},
+		Substats:    []entity.Substat{},
+	}
+
+	tests := []struct {
+		name string
+
+		// WHEN
+		url string
+
+		// THEN
+		expectedStatusCode         int
+		expectedContentType        string
+		expectedContentDisposition string
+		expectedResponse           string
+		expectedSkipped            string
+		expectedQuery              *repository.ArtifactQuery
+	}{
+		{
+			name: "ShouldExportGOODWithListFilters",
+
+			url: "/export?format=good&type=PLUME&limit=1",
+
+			expectedStatusCode:         200,
+			expectedContentType:        "application/json",
+			expectedContentDisposition: `attachment; filename="artifacts.json"`,
+			expectedResponse: `{"format":"GOOD","version":2,"source":"genshin-artifact-db","artifacts":[` +
+				`{"setKey":"GladiatorsFinale","slotKey":"plume","level":0,"rarity":5,"mainStatKey":"atk","location":"","lock":false,"substats":[]}]}` + "\n",
+			expectedSkipped: "0",
+			expectedQuery: &repository.ArtifactQuery{
+				Types: []entity.ArtifactType{entity.ARTIFACT_TYPE_PLUME},
+				Limit: service.ExportPageSize,
+			},
+		},
+		{
+			name: "ShouldExportCSV",
+
+			url: "/export?format=csv",
+
+			expectedStatusCode:         200,
+			expectedContentType:        "text/csv; charset=utf-8",
+			expectedContentDisposition: `attachment; filename="artifacts.csv"`,
+			expectedResponse: "id,set,type,rarity,level,main_stat,main_stat_value,HP_FLAT,ATK_FLAT,DEF_FLAT,HP_PERCENT,ATK_PERCENT,DEF_PERCENT,ELEMENTAL_MASTERY,ENERGY_RECHARGE,CRIT_RATE,CRIT_DMG,crit_value,roll_value,locked,location\n" +
+				"test-id,GladiatorsFinale,PLUME,5,0,ATK_FLAT,47,,,,,,,,,,,0,0,false,\n",
+			expectedSkipped: "0",
+		},
+		{
+			name: "ShouldReturnBadRequestWhenFormatIsUnknown",
+
+			url: "/export?format=xml",
+
+			expectedStatusCode:  400,
+			expectedContentType: ProblemContentType,
+			expectedResponse:    `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid export format: \"xml\"","instance":"/export","code":"invalid_export_format","errors":[{"field":"format","code":"invalid_export_format","message":"invalid export format: \"xml\""}]}`,
+		},
+		{
+			name: "ShouldReturnBadRequestWhenFilterIsInvalid",
+
+			url: "/export?format=jsonl&min_level=high",
+
+			expectedStatusCode:  400,
+			expectedContentType: ProblemContentType,
+			expectedResponse:    `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid query parameter: min_level high","instance":"/export","code":"invalid_query_parameter","errors":[{"field":"min_level","code":"invalid_query_parameter","message":"invalid query parameter: min_level high"}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockArtifactGetter := &repository.MockArtifactGetter{
+				QueryArtifactsResponse: &repository.ArtifactQueryResult{
+					Artifacts: []*entity.Artifact{testArtifact},
+					Total:     1,
+				},
+			}
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(ErrorHandler())
+			r.GET("/export", ExportArtifacts(service.NewExportService(mockArtifactGetter)))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", tt.url, nil)
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatusCode, w.Code)
+			}
+
+			if contentType := w.Header().Get("Content-Type"); contentType != tt.expectedContentType {
+				t.Errorf("Expected content type %s, got %s", tt.expectedContentType, contentType)
+			}
+
+			if contentDisposition := w.Header().Get("Content-Disposition"); contentDisposition != tt.expectedContentDisposition {
+				t.Errorf("Expected content disposition %s, got %s", tt.expectedContentDisposition, contentDisposition)
+			}
+
+			if diff := cmp.Diff(tt.expectedResponse, w.Body.String()); diff != "" {
+				t.Errorf("Response mismatch (-want +got):\n%s", diff)
+			}
+
+			if skipped := w.Result().Trailer.Get(ExportSkippedTrailer); skipped != tt.expectedSkipped {
+				t.Errorf("Expected skipped trailer %q, got %q", tt.expectedSkipped, skipped)
+			}
+
+			if tt.expectedQuery != nil {
+				if diff := cmp.Diff(*tt.expectedQuery, mockArtifactGetter.QueriedArtifactQuery); diff != "" {
+					t.Errorf("Query mismatch (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
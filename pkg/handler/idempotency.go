@@ -0,0 +1,274 @@
+package handler
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	IdempotencyKeyHeader     = "Idempotency-Key"
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+	maxIdempotencyKeyLength  = 255
+)
+
+var (
+	ErrInvalidIdempotencyKey = errors.New("invalid idempotency key")
+	ErrIdempotencyKeyReused  = errors.New("idempotency key was used for a different request")
+	ErrIdempotencyKeyInUse   = errors.New("request with the same idempotency key is in progress")
+	ErrRequestBodyTooLarge   = errors.New("request body is too large")
+)
+
+// idempotentResponseHeaders は保存して再送時に返すレスポンスヘッダー。
+var idempotentResponseHeaders = []string{"Content-Type", "Location"}
+
+type idempotencyEntry struct {
+	fingerprint string
+	expiresAt   time.Time
+	completed   bool
+
+	status int
+	header http.Header
+	body   []byte
+}
+
+// IdempotencyLimits は IdempotencyStore が保持する量と、Idempotency-Key 付きのリクエストボディの大きさの上限。
+type IdempotencyLimits struct {
+	// MaxEntries は保持するキーの数の上限で、超える場合は古いキーから忘れる
+	MaxEntries int
+	// MaxBytes は保持するレスポンスボディの合計の上限で、超える場合は古いキーから忘れる。
+	// これより大きいレスポンスは保存せず、同じキーの再送も処理し直す
+	MaxBytes int
+	// MaxRequestBytes は Idempotency-Key 付きのリクエストボディの上限で、超えると 413 を返す
+	MaxRequestBytes int64
+}
+
+type idempotencyExpiry struct {
+	key       string
+	expiresAt time.Time
+}
+
+// IdempotencyStore は Idempotency-Key ごとの成功したレスポンスを window の間だけメモリに保持する。
+// 保持する量は limits までで、超える場合は期限を待たずに古いキーから忘れる。
+// 複数の goroutine から同時に利用できる。
+type IdempotencyStore struct {
+	mu      sync.Mutex
+	window  time.Duration
+	limits  IdempotencyLimits
+	now     func() time.Time
+	entries map[string]*idempotencyEntry
+	// expiries は登録順 (= 期限順) のキューで、期限切れや上限を超えたエントリを先頭から削除するのに使う
+	expiries []idempotencyExpiry
+	// bytes は保持しているレスポンスボディの合計
+	bytes int
+}
+
+func NewIdempotencyStore(window time.Duration, limits IdempotencyLimits) *IdempotencyStore {
+	return &IdempotencyStore{
+		window:  window,
+		limits:  limits,
+		now:     time.Now,
+		entries: make(map[string]*idempotencyEntry),
+	}
+}
+
+// begin はキーの処理を開始する。保存済みのレスポンスがあればそれを返す。
+func (s *IdempotencyStore) begin(key, fingerprint string) (*idempotencyEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.expire(now)
+
+	if entry, exists := s.entries[key]; exists {
+		switch {
+		case entry.fingerprint != fingerprint:
+			return nil, ErrIdempotencyKeyReused
+		case !entry.completed:
+			return nil, ErrIdempotencyKeyInUse
+		}
+		return entry, nil
+	}
+
+	for len(s.entries) >= s.limits.MaxEntries && len(s.expiries) > 0 {
+		s.evictOldest()
+	}
+
+	expiresAt := now.Add(s.window)
+	s.entries[key] = &idempotencyEntry{fingerprint: fingerprint, expiresAt: expiresAt}
+	s.expiries = append(s.expiries, idempotencyExpiry{key: key, expiresAt: expiresAt})
+	return nil, nil
+}
+
+func (s *IdempotencyStore) complete(key string, status int, header http.Header, body []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, exists := s.entries[key]
+	if !exists {
+		return
+	}
+	if len(body) > s.limits.MaxBytes {
+		s.remove(key)
+		return
+	}
+
+	entry.completed = true
+	entry.status = status
+	entry.header = header
+	entry.body = body
+	s.bytes += len(body)
+	for s.bytes > s.limits.MaxBytes && len(s.expiries) > 0 {
+		s.evictOldest()
+	}
+}
+
+// release は失敗したリクエストのキーを解放し、同じキーでの再試行を受け付けられるようにする。
+func (s *IdempotencyStore) release(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.remove(key)
+}
+
+// expire は呼び出し元が mu を保持していることを前提とする。
+func (s *IdempotencyStore) expire(now time.Time) {
+	for len(s.expiries) > 0 && !s.expiries[0].expiresAt.After(now) {
+		s.evictOldest()
+	}
+}
+
+// evictOldest はキューの先頭のエントリを削除する。呼び出し元が mu を保持していることを前提とする。
+func (s *IdempotencyStore) evictOldest() {
+	expiry := s.expiries[0]
+	s.expiries = s.expiries[1:]
+	// 解放後に同じキーで登録し直されたエントリは削除しない
+	if entry, exists := s.entries[expiry.key]; exists && entry.expiresAt.Equal(expiry.expiresAt) {
+		s.remove(expiry.key)
+	}
+}
+
+// remove は呼び出し元が mu を保持していることを前提とする。
+func (s *IdempotencyStore) remove(key string) {
+	if entry, exists := s.entries[key]; exists {
+		s.bytes -= len(entry.body)
+		delete(s.entries, key)
+	}
+}
+
+type idempotencyResponseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *idempotencyResponseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *idempotencyResponseRecorder) WriteString(data string) (int, error) {
+	w.body.WriteString(data)
+	return w.ResponseWriter.WriteString(data)
+}
+
+// idempotencyScope はキーを区別する範囲で、API キーとアカウントごとに分ける。
+// 別の API キーやアカウントが同じキーを使っても、互いのレスポンスを受け取ることはない。
+func idempotencyScope(c *gin.Context) string {
+	keyID := ""
+	if principal, ok := PrincipalFromContext(c); ok {
+		keyID = principal.KeyID
+	}
+	return fmt.Sprintf("%q %q", keyID, c.Param("account"))
+}
+
+// Idempotency は Idempotency-Key ヘッダー付きのリクエストを重複して処理しないようにする。
+// 同じキーの再送には保存済みのレスポンスを Idempotent-Replayed ヘッダー付きで返す。
+// キーは API キーとアカウントごとに区別する。
+// 保存するのは 2xx のレスポンスのみで、失敗した場合は同じキーで再試行できる。
+// 同じキーを異なるメソッド・パス・ボディのリクエストに使うと 400 になる。
+// ボディが store の MaxRequestBytes を超える場合は読み込まずに 413 を返す。
+func Idempotency(store *IdempotencyStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			c.Error(service.NewValidationError("invalid_idempotency_key", IdempotencyKeyHeader, fmt.Errorf("%w: must be at most %d characters", ErrInvalidIdempotencyKey, maxIdempotencyKeyLength)))
+			c.Abort()
+			return
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, store.limits.MaxRequestBytes)
+		body, err := io.ReadAll(c.Request.Body)
+		var maxBytesError *http.MaxBytesError
+		switch {
+		case errors.As(err, &maxBytesError):
+			c.Error(&service.Error{Kind: service.ERROR_KIND_TOO_LARGE, Code: "request_body_too_large", Err: fmt.Errorf("%w: must be at most %d bytes", ErrRequestBodyTooLarge, maxBytesError.Limit)})
+			c.Abort()
+			return
+		case err != nil:
+			c.Error(service.NewValidationError("invalid_request_body", "", fmt.Errorf("%w: %w", ErrInvalidRequestBody, err)))
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		fingerprint := sha256.New()
+		fmt.Fprintf(fingerprint, "%s %s\n", c.Request.Method, c.Request.URL.RequestURI())
+		fingerprint.Write(body)
+
+		key = idempotencyScope(c) + " " + key
+		entry, err := store.begin(key, hex.EncodeToString(fingerprint.Sum(nil)))
+		switch {
+		case errors.Is(err, ErrIdempotencyKeyReused):
+			c.Error(service.NewValidationError("idempotency_key_reused", IdempotencyKeyHeader, err))
+			c.Abort()
+			return
+		case errors.Is(err, ErrIdempotencyKeyInUse):
+			c.Error(&service.Error{Kind: service.ERROR_KIND_CONFLICT, Code: "idempotency_key_in_use", Err: err})
+			c.Abort()
+			return
+		case entry != nil:
+			for name, values := range entry.header {
+				for _, value := range values {
+					c.Writer.Header().Add(name, value)
+				}
+			}
+			c.Header(IdempotentReplayedHeader, "true")
+			c.Data(entry.status, entry.header.Get("Content-Type"), entry.body)
+			c.Abort()
+			return
+		}
+
+		recorder := &idempotencyResponseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+		c.Writer = recorder.ResponseWriter
+
+		status := recorder.Status()
+		if len(c.Errors) > 0 || !recorder.Written() || status < 200 || status >= 300 {
+			store.release(key)
+			return
+		}
+
+		header := make(http.Header)
+		for _, name := range idempotentResponseHeaders {
+			if value := recorder.Header().Get(name); value != "" {
+				header.Set(name, value)
+			}
+		}
+		store.complete(key, status, header, recorder.body.Bytes())
+	}
+}
@@ -0,0 +1,304 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestIdempotency(t *testing.T) {
+	type request struct {
+		key         string
+		apiKeyID    string
+		url         string
+		requestBody string
+		advance     time.Duration
+	}
+	defaultLimits := IdempotencyLimits{MaxEntries: 100, MaxBytes: 1 << 20, MaxRequestBytes: 1 << 20}
+
+	tests := []struct {
+		name string
+
+		// GIVEN
+		failFirst bool
+		limits    *IdempotencyLimits
+
+		// WHEN
+		requests []request
+
+		// THEN
+		expectedStatusCodes []int
+		expectedResponses   []string
+		expectedReplayed    []string
+	}{
+		{
+			name: "ShouldReplayResponseForSameKey",
+
+			requests: []request{
+				{key: "key-1", url: "/artifact", requestBody: `{"rarity":5}`},
+				{key: "key-1", url: "/artifact", requestBody: `{"rarity":5}`},
+			},
+
+			expectedStatusCodes: []int{201, 201},
+			expectedResponses:   []string{`{"call":1}`, `{"call":1}`},
+			expectedReplayed:    []string{"", "true"},
+		},
+		{
+			name: "ShouldProcessEveryRequestWithoutKey",
+
+			requests: []request{
+				{url: "/artifact", requestBody: `{"rarity":5}`},
+				{url: "/artifact", requestBody: `{"rarity":5}`},
+			},
+
+			expectedStatusCodes: []int{201, 201},
+			expectedResponses:   []string{`{"call":1}`, `{"call":2}`},
+			expectedReplayed:    []string{"", ""},
+		},
+		{
+			name: "ShouldRejectSameKeyForDifferentBody",
+
+			requests: []request{
+				{key: "key-1", url: "/artifact", requestBody: `{"rarity":5}`},
+				{key: "key-1", url: "/artifact", requestBody: `{"rarity":4}`},
+			},
+
+			expectedStatusCodes: []int{201, 400},
+			expectedResponses: []string{
+				`{"call":1}`,
+				`{"type":"about:blank","title":"Bad Request","status":400,"detail":"idempotency key was used for a different request","instance":"/artifact","code":"idempotency_key_reused","errors":[{"field":"Idempotency-Key","code":"idempotency_key_reused","message":"idempotency key was used for a different request"}]}`,
+			},
+			expectedReplayed: []string{"", ""},
+		},
+		{
+			name: "ShouldRejectSameKeyForDifferentQuery",
+
+			requests: []request{
+				{key: "key-1", url: "/artifact", requestBody: `{"rarity":5}`},
+				{key: "key-1", url: "/artifact?on_duplicate=reject", requestBody: `{"rarity":5}`},
+			},
+
+			expectedStatusCodes: []int{201, 400},
+			expectedResponses: []string{
+				`{"call":1}`,
+				`{"type":"about:blank","title":"Bad Request","status":400,"detail":"idempotency key was used for a different request","instance":"/artifact","code":"idempotency_key_reused","errors":[{"field":"Idempotency-Key","code":"idempotency_key_reused","message":"idempotency key was used for a different request"}]}`,
+			},
+			expectedReplayed: []string{"", ""},
+		},
+		{
+			name: "ShouldProcessAgainAfterWindowExpires",
+
+			requests: []request{
+				{key: "key-1", url: "/artifact", requestBody: `{"rarity":5}`},
+				{key: "key-1", url: "/artifact", requestBody: `{"rarity":5}`, advance: time.Hour},
+			},
+
+			expectedStatusCodes: []int{201, 201},
+			expectedResponses:   []string{`{"call":1}`, `{"call":2}`},
+			expectedReplayed:    []string{"", ""},
+		},
+		{
+			name: "ShouldAllowRetryAfterFailure",
+
+			failFirst: true,
+
+			requests: []request{
+				{key: "key-1", url: "/artifact", requestBody: `{"rarity":5}`},
+				{key: "key-1", url: "/artifact", requestBody: `{"rarity":5}`},
+			},
+
+			expectedStatusCodes: []int{500, 201},
+			expectedResponses: []string{
+				`{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"Internal server error","instance":"/artifact","code":"internal"}`,
+				`{"call":2}`,
+			},
+			expectedReplayed: []string{"", ""},
+		},
+		{
+			name: "ShouldNotReplayResponseForAnotherAPIKey",
+
+			requests: []request{
+				{key: "key-1", apiKeyID: "key-a", url: "/artifact", requestBody: `{"rarity":5}`},
+				{key: "key-1", apiKeyID: "key-b", url: "/artifact", requestBody: `{"rarity":5}`},
+				{key: "key-1", apiKeyID: "key-a", url: "/artifact", requestBody: `{"rarity":5}`},
+			},
+
+			expectedStatusCodes: []int{201, 201, 201},
+			expectedResponses:   []string{`{"call":1}`, `{"call":2}`, `{"call":1}`},
+			expectedReplayed:    []string{"", "", "true"},
+		},
+		{
+			name: "ShouldNotReplayResponseForAnotherAccount",
+
+			requests: []request{
+				{key: "key-1", url: "/accounts/main/artifact", requestBody: `{"rarity":5}`},
+				{key: "key-1", url: "/accounts/alt/artifact", requestBody: `{"rarity":5}`},
+			},
+
+			expectedStatusCodes: []int{201, 201},
+			expectedResponses:   []string{`{"call":1}`, `{"call":2}`},
+			expectedReplayed:    []string{"", ""},
+		},
+		{
+			name: "ShouldForgetOldestKeyWhenEntriesExceedLimit",
+
+			limits: &IdempotencyLimits{MaxEntries: 1, MaxBytes: 1 << 20, MaxRequestBytes: 1 << 20},
+
+			requests: []request{
+				{key: "key-1", url: "/artifact", requestBody: `{"rarity":5}`},
+				{key: "key-2", url: "/artifact", requestBody: `{"rarity":5}`},
+				{key: "key-2", url: "/artifact", requestBody: `{"rarity":5}`},
+				{key: "key-1", url: "/artifact", requestBody: `{"rarity":5}`},
+			},
+
+			expectedStatusCodes: []int{201, 201, 201, 201},
+			expectedResponses:   []string{`{"call":1}`, `{"call":2}`, `{"call":2}`, `{"call":3}`},
+			expectedReplayed:    []string{"", "", "true", ""},
+		},
+		{
+			name: "ShouldForgetOldestKeyWhenBytesExceedLimit",
+
+			// レスポンス 1 件分 (10 バイト) しか保持できない
+			limits: &IdempotencyLimits{MaxEntries: 100, MaxBytes: 15, MaxRequestBytes: 1 << 20},
+
+			requests: []request{
+				{key: "key-1", url: "/artifact", requestBody: `{"rarity":5}`},
+				{key: "key-2", url: "/artifact", requestBody: `{"rarity":5}`},
+				{key: "key-2", url: "/artifact", requestBody: `{"rarity":5}`},
+				{key: "key-1", url: "/artifact", requestBody: `{"rarity":5}`},
+			},
+
+			expectedStatusCodes: []int{201, 201, 201, 201},
+			expectedResponses:   []string{`{"call":1}`, `{"call":2}`, `{"call":2}`, `{"call":3}`},
+			expectedReplayed:    []string{"", "", "true", ""},
+		},
+		{
+			name: "ShouldNotStoreResponseLargerThanLimit",
+
+			limits: &IdempotencyLimits{MaxEntries: 100, MaxBytes: 5, MaxRequestBytes: 1 << 20},
+
+			requests: []request{
+				{key: "key-1", url: "/artifact", requestBody: `{"rarity":5}`},
+				{key: "key-1", url: "/artifact", requestBody: `{"rarity":5}`},
+			},
+
+			expectedStatusCodes: []int{201, 201},
+			expectedResponses:   []string{`{"call":1}`, `{"call":2}`},
+			expectedReplayed:    []string{"", ""},
+		},
+		{
+			name: "ShouldRejectTooLargeBody",
+
+			limits: &IdempotencyLimits{MaxEntries: 100, MaxBytes: 1 << 20, MaxRequestBytes: 8},
+
+			requests: []request{
+				{key: "key-1", url: "/artifact", requestBody: `{"rarity":5}`},
+			},
+
+			expectedStatusCodes: []int{413},
+			expectedResponses: []string{
+				`{"type":"about:blank","title":"Request Entity Too Large","status":413,"detail":"request body is too large: must be at most 8 bytes","instance":"/artifact","code":"request_body_too_large"}`,
+			},
+			expectedReplayed: []string{""},
+		},
+		{
+			name: "ShouldRejectTooLongKey",
+
+			requests: []request{
+				{key: strings.Repeat("k", 256), url: "/artifact", requestBody: `{}`},
+			},
+
+			expectedStatusCodes: []int{400},
+			expectedResponses: []string{
+				`{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid idempotency key: must be at most 255 characters","instance":"/artifact","code":"invalid_idempotency_key","errors":[{"field":"Idempotency-Key","code":"invalid_idempotency_key","message":"invalid idempotency key: must be at most 255 characters"}]}`,
+			},
+			expectedReplayed: []string{""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			limits := defaultLimits
+			if tt.limits != nil {
+				limits = *tt.limits
+			}
+			store := NewIdempotencyStore(30*time.Minute, limits)
+			store.now = func() time.Time { return now }
+
+			calls := 0
+			create := func(c *gin.Context) {
+				calls++
+				if tt.failFirst && calls == 1 {
+					c.Error(errors.New("disk full"))
+					return
+				}
+				c.Header("Location", fmt.Sprintf(ArtifactLocationTemplate, "new-id"))
+				c.JSON(201, gin.H{"call": calls})
+			}
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(ErrorHandler())
+			// テストでは認証の代わりに X-Test-Key-ID の API キーで認証したものとして扱う
+			r.Use(func(c *gin.Context) {
+				if keyID := c.GetHeader("X-Test-Key-ID"); keyID != "" {
+					c.Set(principalContextKey, &service.Principal{KeyID: keyID})
+				}
+			})
+			r.POST("/artifact", Idempotency(store), create)
+			r.POST("/accounts/:account/artifact", Idempotency(store), create)
+
+			for i, request := range tt.requests {
+				now = now.Add(request.advance)
+
+				w := httptest.NewRecorder()
+				req := httptest.NewRequest("POST", request.url, bytes.NewBufferString(request.requestBody))
+				if request.key != "" {
+					req.Header.Set(IdempotencyKeyHeader, request.key)
+				}
+				if request.apiKeyID != "" {
+					req.Header.Set("X-Test-Key-ID", request.apiKeyID)
+				}
+				r.ServeHTTP(w, req)
+
+				if w.Code != tt.expectedStatusCodes[i] {
+					t.Errorf("request %d: expected status code %d, got %d", i, tt.expectedStatusCodes[i], w.Code)
+				}
+				if diff := cmp.Diff(tt.expectedResponses[i], w.Body.String()); diff != "" {
+					t.Errorf("request %d: response mismatch (-want +got):\n%s", i, diff)
+				}
+				if replayed := w.Header().Get(IdempotentReplayedHeader); replayed != tt.expectedReplayed[i] {
+					t.Errorf("request %d: expected replayed header %q, got %q", i, tt.expectedReplayed[i], replayed)
+				}
+				if w.Code == 201 && w.Header().Get("Location") != "/artifact/new-id" {
+					t.Errorf("request %d: expected location header, got %q", i, w.Header().Get("Location"))
+				}
+			}
+		})
+	}
+}
+
+func TestIdempotencyStoreRejectsKeyInUse(t *testing.T) {
+	// GIVEN
+	store := NewIdempotencyStore(time.Minute, IdempotencyLimits{MaxEntries: 100, MaxBytes: 1 << 20, MaxRequestBytes: 1 << 20})
+	if _, err := store.begin("key-1", "fingerprint"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// WHEN
+	_, err := store.begin("key-1", "fingerprint")
+
+	// THEN
+	if !errors.Is(err, ErrIdempotencyKeyInUse) {
+		t.Errorf("expected error: %v, got: %v", ErrIdempotencyKeyInUse, err)
+	}
+}
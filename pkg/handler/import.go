@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"fmt"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ImportGOOD は POST /import/good のボディを GOOD 形式のインベントリとして取り込む。
+// 一部の聖遺物が拒否されても 200 を返し、拒否の理由はレスポンスの rejections に含める。
+// ?on_duplicate=warn|reject|allow で内容が同じ聖遺物がある場合の扱いを CreateArtifact と同じように指定できる。
+func ImportGOOD(importService service.ImportGOODServiceInterface) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		data, err := c.GetRawData()
+		if err != nil {
+			c.Error(service.NewValidationError("invalid_request_body", "", fmt.Errorf("%w: %w", ErrInvalidRequestBody, err)))
+			return
+		}
+
+		result, err := importService.ImportGOOD(data, service.DuplicatePolicy(c.Query("on_duplicate")))
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		c.JSON(200, result)
+	}
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestImportGOOD(t *testing.T) {
+	tests := []struct {
+		name string
+
+		// GIVEN
+		mockImportResult    *service.ImportResultDTO
+		mockImportGOODError error
+
+		// WHEN
+		query       string
+		requestBody string
+
+		// THEN
+		expectedStatusCode int
+		expectedResponse   string
+		expectedPolicy     service.DuplicatePolicy
+	}{
+		{
+			name: "ShouldImportGOODSuccessfully",
+
+			mockImportResult: &service.ImportResultDTO{
+				Imported:  0,
+				Rejected:  1,
+				Artifacts: []*service.ArtifactDTO{},
+				Rejections: []*service.ImportRejectionDTO{
+					{Index: 0, Code: "invalid_artifact_set", Reason: "invalid artifact set"},
+				},
+				Duplicates: []*service.ImportDuplicateDTO{},
+			},
+
+			requestBody: `{"format":"GOOD","artifacts":[{"setKey":"UnknownSet"}]}`,
+
+			expectedStatusCode: 200,
+			expectedResponse:   `{"imported":0,"rejected":1,"artifacts":[],"rejections":[{"index":0,"code":"invalid_artifact_set","reason":"invalid artifact set"}],"duplicates":[]}`,
+		},
+		{
+			name: "ShouldPassDuplicatePolicyToService",
+
+			mockImportResult: &service.ImportResultDTO{
+				Imported:   0,
+				Rejected:   1,
+				Artifacts:  []*service.ArtifactDTO{},
+				Rejections: []*service.ImportRejectionDTO{{Index: 0, Code: "duplicate_artifact", Reason: "duplicate artifact: same content as old-id"}},
+				Duplicates: []*service.ImportDuplicateDTO{},
+			},
+
+			query:       "?on_duplicate=reject",
+			requestBody: `{"format":"GOOD","artifacts":[{"setKey":"GladiatorsFinale"}]}`,
+
+			expectedStatusCode: 200,
+			expectedResponse:   `{"imported":0,"rejected":1,"artifacts":[],"rejections":[{"index":0,"code":"duplicate_artifact","reason":"duplicate artifact: same content as old-id"}],"duplicates":[]}`,
+			expectedPolicy:     service.DUPLICATE_POLICY_REJECT,
+		},
+		{
+			name: "ShouldReturnBadRequestWhenDocumentIsInvalid",
+
+			mockImportGOODError: service.NewValidationError("invalid_good_document", "format", fmt.Errorf("%w: format must be GOOD, got \"\"", service.ErrInvalidGOODDocument)),
+
+			requestBody: `{}`,
+
+			expectedStatusCode: 400,
+			expectedResponse:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid GOOD document: format must be GOOD, got \"\"","instance":"/import/good","code":"invalid_good_document","errors":[{"field":"format","code":"invalid_good_document","message":"invalid GOOD document: format must be GOOD, got \"\""}]}`,
+		},
+		{
+			name: "ShouldReturnErrorWhenImportFails",
+
+			mockImportGOODError: errors.New("disk full"),
+
+			requestBody: `{"format":"GOOD","artifacts":[]}`,
+
+			expectedStatusCode: 500,
+			expectedResponse:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"Internal server error","instance":"/import/good","code":"internal"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			importService := &service.MockImportGOODService{
+				MockImportResult:    tt.mockImportResult,
+				MockImportGOODError: tt.mockImportGOODError,
+			}
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(ErrorHandler())
+			r.POST("/import/good", ImportGOOD(importService))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/import/good"+tt.query, bytes.NewBufferString(tt.requestBody))
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatusCode, w.Code)
+			}
+
+			if diff := cmp.Diff(tt.expectedResponse, w.Body.String()); diff != "" {
+				t.Errorf("Response mismatch (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tt.requestBody, string(importService.ImportedData)); diff != "" {
+				t.Errorf("Request body mismatch (-want +got):\n%s", diff)
+			}
+
+			if importService.QueriedPolicy != tt.expectedPolicy {
+				t.Errorf("Expected duplicate policy %s, got %s", tt.expectedPolicy, importService.QueriedPolicy)
+			}
+		})
+	}
+}
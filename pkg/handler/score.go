@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SaveScoreProfileRequestParam struct {
+	Weights map[string]float64 `json:"weights"`
+}
+
+func GetArtifactScore(scoreService service.GetArtifactScoreServiceInterface) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		artifactID := c.Param("id")
+		profileName := c.Query("profile")
+
+		score, err := scoreService.GetArtifactScore(artifactID, profileName)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		c.JSON(200, score)
+	}
+}
+
+func GetScoreProfiles(scoreService service.GetScoreProfilesServiceInterface) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		profiles, err := scoreService.GetScoreProfiles()
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		c.JSON(200, profiles)
+	}
+}
+
+func GetScoreProfile(scoreService service.GetScoreProfileServiceInterface) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		name := c.Param("name")
+
+		profile, err := scoreService.GetScoreProfile(name)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		c.JSON(200, profile)
+	}
+}
+
+func SaveScoreProfile(scoreService service.SaveScoreProfileServiceInterface) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		name := c.Param("name")
+
+		var saveScoreProfileRequestParam SaveScoreProfileRequestParam
+		if err := c.ShouldBindJSON(&saveScoreProfileRequestParam); err != nil {
+			c.Error(invalidRequestBodyError(err))
+			return
+		}
+
+		profile, err := scoreService.SaveScoreProfile(service.SaveScoreProfileCommand{
+			Name:    name,
+			Weights: saveScoreProfileRequestParam.Weights,
+		})
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		c.JSON(200, profile)
+	}
+}
+
+func DeleteScoreProfile(scoreService service.DeleteScoreProfileServiceInterface) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		name := c.Param("name")
+
+		if err := scoreService.DeleteScoreProfile(name); err != nil {
+			c.Error(err)
+			return
+		}
+
+		c.JSON(200, gin.H{"message": "Score profile deleted successfully"})
+	}
+}
@@ -0,0 +1,240 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/repository"
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGetArtifactScore(t *testing.T) {
+	profileScore := 1.5
+	testScore := &service.ScoreDTO{
+		ArtifactID:   "test-id",
+		CritValue:    15.6,
+		RollValue:    300,
+		Profile:      "HuTao",
+		ProfileScore: &profileScore,
+	}
+
+	tests := []struct {
+		name string
+
+		// GIVEN
+		mockScore                 *service.ScoreDTO
+		mockGetArtifactScoreError error
+
+		// THEN
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name: "ShouldGetArtifactScoreSuccessfully",
+
+			mockScore: testScore,
+
+			expectedStatusCode: 200,
+			expectedResponse:   `{"artifact_id":"test-id","crit_value":15.6,"roll_value":300,"profile":"HuTao","profile_score":1.5}`,
+		},
+		{
+			name: "ShouldReturnErrorWhenArtifactNotFound",
+
+			mockGetArtifactScoreError: repository.ErrArtifactNotFound,
+
+			expectedStatusCode: 404,
+			expectedResponse:   `{"type":"about:blank","title":"Not Found","status":404,"detail":"artifact not found","instance":"/artifact/test-id/score","code":"artifact_not_found"}`,
+		},
+		{
+			name: "ShouldReturnErrorWhenScoreProfileNotFound",
+
+			mockGetArtifactScoreError: repository.ErrScoreProfileNotFound,
+
+			expectedStatusCode: 404,
+			expectedResponse:   `{"type":"about:blank","title":"Not Found","status":404,"detail":"score profile not found","instance":"/artifact/test-id/score","code":"score_profile_not_found"}`,
+		},
+		{
+			name: "ShouldReturnErrorWhenGetArtifactScoreFails",
+
+			mockGetArtifactScoreError: errors.New("internal server error"),
+
+			expectedStatusCode: 500,
+			expectedResponse:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"Internal server error","instance":"/artifact/test-id/score","code":"internal"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &service.MockGetArtifactScoreService{
+				MockScore:                 tt.mockScore,
+				MockGetArtifactScoreError: tt.mockGetArtifactScoreError,
+			}
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(ErrorHandler())
+			r.GET("/artifact/:id/score", GetArtifactScore(service))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/artifact/test-id/score?profile=HuTao", nil)
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatusCode, w.Code)
+			}
+
+			if diff := cmp.Diff(tt.expectedResponse, w.Body.String()); diff != "" {
+				t.Errorf("Response mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSaveScoreProfile(t *testing.T) {
+	testProfile := &service.ScoreProfileDTO{
+		Name:    "HuTao",
+		Weights: map[string]float64{"CRIT_DMG": 1},
+	}
+
+	tests := []struct {
+		name string
+
+		// GIVEN
+		mockScoreProfile          *service.ScoreProfileDTO
+		mockSaveScoreProfileError error
+
+		// WHEN
+		requestBody string
+
+		// THEN
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name: "ShouldSaveScoreProfileSuccessfully",
+
+			mockScoreProfile: testProfile,
+
+			requestBody: `{"weights":{"CRIT_DMG":1}}`,
+
+			expectedStatusCode: 200,
+			expectedResponse: func() string {
+				response, _ := json.Marshal(testProfile)
+				return string(response)
+			}(),
+		},
+		{
+			name: "ShouldReturnBadRequestWhenWeightIsInvalid",
+
+			mockSaveScoreProfileError: fmt.Errorf("%w: CRIT_DMG -1", entity.ErrInvalidScoreProfileWeight),
+
+			requestBody: `{"weights":{"CRIT_DMG":-1}}`,
+
+			expectedStatusCode: 400,
+			expectedResponse:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid score profile weight: CRIT_DMG -1","instance":"/profiles/HuTao","code":"invalid_score_profile_weight","errors":[{"field":"weights","code":"invalid_score_profile_weight","message":"invalid score profile weight: CRIT_DMG -1"}]}`,
+		},
+		{
+			name: "ShouldReturnBadRequestWhenBodyIsInvalid",
+
+			requestBody: `{"weights":`,
+
+			expectedStatusCode: 400,
+			expectedResponse:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid request body: unexpected EOF","instance":"/profiles/HuTao","code":"invalid_request_body"}`,
+		},
+		{
+			name: "ShouldReturnErrorWhenSaveScoreProfileFails",
+
+			mockSaveScoreProfileError: errors.New("internal server error"),
+
+			requestBody: `{"weights":{"CRIT_DMG":1}}`,
+
+			expectedStatusCode: 500,
+			expectedResponse:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"Internal server error","instance":"/profiles/HuTao","code":"internal"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &service.MockSaveScoreProfileService{
+				MockScoreProfile:          tt.mockScoreProfile,
+				MockSaveScoreProfileError: tt.mockSaveScoreProfileError,
+			}
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(ErrorHandler())
+			r.PUT("/profiles/:name", SaveScoreProfile(service))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PUT", "/profiles/HuTao", strings.NewReader(tt.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatusCode, w.Code)
+			}
+
+			if diff := cmp.Diff(tt.expectedResponse, w.Body.String()); diff != "" {
+				t.Errorf("Response mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDeleteScoreProfile(t *testing.T) {
+	tests := []struct {
+		name string
+
+		// GIVEN
+		mockDeleteScoreProfileError error
+
+		// THEN
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name: "ShouldDeleteScoreProfileSuccessfully",
+
+			expectedStatusCode: 200,
+			expectedResponse:   `{"message":"Score profile deleted successfully"}`,
+		},
+		{
+			name: "ShouldReturnErrorWhenScoreProfileNotFound",
+
+			mockDeleteScoreProfileError: repository.ErrScoreProfileNotFound,
+
+			expectedStatusCode: 404,
+			expectedResponse:   `{"type":"about:blank","title":"Not Found","status":404,"detail":"score profile not found","instance":"/profiles/HuTao","code":"score_profile_not_found"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &service.MockDeleteScoreProfileService{
+				MockDeleteScoreProfileError: tt.mockDeleteScoreProfileError,
+			}
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(ErrorHandler())
+			r.DELETE("/profiles/:name", DeleteScoreProfile(service))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", "/profiles/HuTao", nil)
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatusCode, w.Code)
+			}
+
+			if diff := cmp.Diff(tt.expectedResponse, w.Body.String()); diff != "" {
+				t.Errorf("Response mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,245 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sync"
+)
+
+// DefaultAccount はアカウントを導入する前から存在したデータを持つアカウント。常に存在する。
+const DefaultAccount = "default"
+
+var (
+	ErrInvalidAccountName   = errors.New("invalid account name")
+	ErrAccountNotFound      = errors.New("account not found")
+	ErrAccountAlreadyExists = errors.New("account already exists")
+)
+
+// accountNamePattern はアカウント名をそのままディレクトリ名と URL のパスに使えるよう制限する。
+var accountNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+func ValidateAccountName(account string) error {
+	if !accountNamePattern.MatchString(account) {
+		return fmt.Errorf("%w: %q: must be 1 to 64 lowercase letters, digits, '_' or '-' starting with a letter or digit", ErrInvalidAccountName, account)
+	}
+	return nil
+}
+
+// ArtifactStore は聖遺物リポジトリのすべての操作と、永続化したファイルの整理・クローズを持つ。
+type ArtifactStore interface {
+	ArtifactGetter
+	ArtifactSaver
+	ArtifactBatchSaver
+	ArtifactUpdater
+	ArtifactBatchUpdater
+	ArtifactModifier
+	ArtifactEquipper
+	ArtifactDeleter
+	// Compact は定期的および終了時に呼ばれ、永続化したファイルを整理する
+	Compact() error
+	Close() error
+}
+
+// ArtifactStoreOpener はアカウントの聖遺物リポジトリを開く。dir はアカウント専用のディレクトリ。
+type ArtifactStoreOpener func(account, dir string) (ArtifactStore, error)
+
+// AccountArtifactRepositories はアカウントごとに分割した聖遺物リポジトリを管理する。
+// DefaultAccount 以外のアカウントは root 直下の同名のディレクトリで、その中にアカウントのデータを保存する。
+// リポジトリは最初に使われたときに開き、Close まで開いたままにする。
+// 複数の goroutine から同時に利用できる。mu は stores の参照と更新の間だけ保持し、
+// リポジトリを開く・整理するといった時間のかかる処理は mu の外で行うため、他のアカウントへのリクエストを止めない。
+type AccountArtifactRepositories struct {
+	mu     sync.Mutex
+	root   string
+	open   ArtifactStoreOpener
+	stores map[string]*accountStoreEntry
+}
+
+// accountStoreEntry は開いた、または開いている途中のアカウントのリポジトリ。
+// 同じアカウントを同時に開こうとした呼び出しは、最初の呼び出しが開き終えるまで ready で待ち、その結果を共有する。
+type accountStoreEntry struct {
+	ready chan struct{}
+	// store と err は ready が閉じられてから読む
+	store ArtifactStore
+	err   error
+}
+
+// opened は開き終えていればリポジトリを返し、開いている途中か開けなかった場合は nil を返す。
+func (e *accountStoreEntry) opened() ArtifactStore {
+	select {
+	case <-e.ready:
+		return e.store
+	default:
+		return nil
+	}
+}
+
+func NewAccountArtifactRepositories(root string, open ArtifactStoreOpener) *AccountArtifactRepositories {
+	return &AccountArtifactRepositories{
+		root:   root,
+		open:   open,
+		stores: make(map[string]*accountStoreEntry),
+	}
+}
+
+func (r *AccountArtifactRepositories) accountDir(account string) string {
+	return filepath.Join(r.root, account)
+}
+
+// exists は mu を保持せずに呼べる。CreateAccount は mu を保持して呼び、同じアカウントの作成が重ならないようにする。
+func (r *AccountArtifactRepositories) exists(account string) (bool, error) {
+	if account == DefaultAccount {
+		return true, nil
+	}
+	info, err := os.Stat(r.accountDir(account))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return info.IsDir(), nil
+}
+
+// GetAccounts は DefaultAccount を含むすべてのアカウント名を昇順で返す。
+func (r *AccountArtifactRepositories) GetAccounts() ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	accounts := []string{DefaultAccount}
+	entries, err := os.ReadDir(r.root)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, entry := range entries {
+		// 手作業で置かれたファイルなど、アカウント名として不正なものは無視する
+		if !entry.IsDir() || entry.Name() == DefaultAccount || ValidateAccountName(entry.Name()) != nil {
+			continue
+		}
+		accounts = append(accounts, entry.Name())
+	}
+	slices.Sort(accounts)
+	return accounts, nil
+}
+
+// GetAccountArtifacts はアカウントの聖遺物リポジトリを返す。
+// アカウントが存在しなければ ErrAccountNotFound を返す。
+func (r *AccountArtifactRepositories) GetAccountArtifacts(account string) (ArtifactStore, error) {
+	if err := ValidateAccountName(account); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	entry, ok := r.stores[account]
+	if ok {
+		r.mu.Unlock()
+		<-entry.ready
+		return entry.store, entry.err
+	}
+	entry = &accountStoreEntry{ready: make(chan struct{})}
+	r.stores[account] = entry
+	r.mu.Unlock()
+
+	entry.store, entry.err = r.openAccount(account)
+	if entry.err != nil {
+		// 開けなかったアカウントは覚えず、次の呼び出しで開き直す
+		r.mu.Lock()
+		if r.stores[account] == entry {
+			delete(r.stores, account)
+		}
+		r.mu.Unlock()
+	}
+	close(entry.ready)
+	return entry.store, entry.err
+}
+
+// openAccount は mu を保持せずに呼ぶ。スナップショットと WAL の読み込みなど、開くのに時間がかかることがあるため。
+func (r *AccountArtifactRepositories) openAccount(account string) (ArtifactStore, error) {
+	exists, err := r.exists(account)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrAccountNotFound, account)
+	}
+
+	store, err := r.open(account, r.accountDir(account))
+	if err != nil {
+		return nil, fmt.Errorf("account %s: %w", account, err)
+	}
+	return store, nil
+}
+
+// CreateAccount はアカウントのディレクトリを作成する。リポジトリは最初に使われたときに開く。
+func (r *AccountArtifactRepositories) CreateAccount(account string) error {
+	if err := ValidateAccountName(account); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	exists, err := r.exists(account)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("%w: %s", ErrAccountAlreadyExists, account)
+	}
+
+	if err := os.MkdirAll(r.root, 0755); err != nil {
+		return err
+	}
+	if err := os.Mkdir(r.accountDir(account), 0755); err != nil {
+		if os.IsExist(err) {
+			return fmt.Errorf("%w: %s", ErrAccountAlreadyExists, account)
+		}
+		return err
+	}
+	return syncDir(r.root)
+}
+
+// Compact は開いているすべてのリポジトリを整理する。失敗したアカウントがあっても残りは続ける。
+// 整理はスナップショットの書き出しと fsync を伴うため、mu の外で行う。開いている途中のリポジトリは整理しない。
+func (r *AccountArtifactRepositories) Compact() error {
+	r.mu.Lock()
+	stores := make(map[string]ArtifactStore, len(r.stores))
+	for account, entry := range r.stores {
+		if store := entry.opened(); store != nil {
+			stores[account] = store
+		}
+	}
+	r.mu.Unlock()
+
+	var errs []error
+	for account, store := range stores {
+		if err := store.Compact(); err != nil {
+			errs = append(errs, fmt.Errorf("account %s: %w", account, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Close は開いているすべてのリポジトリを閉じる。開いている途中のリポジトリは開き終えるのを待ってから閉じる。
+func (r *AccountArtifactRepositories) Close() error {
+	r.mu.Lock()
+	entries := r.stores
+	r.stores = make(map[string]*accountStoreEntry)
+	r.mu.Unlock()
+
+	var errs []error
+	for account, entry := range entries {
+		<-entry.ready
+		if entry.store == nil {
+			continue
+		}
+		if err := entry.store.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("account %s: %w", account, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,328 @@
+package repository
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
+	"github.com/google/go-cmp/cmp"
+)
+
+// newTestAccountArtifactRepositories は既定のアカウントを root の外、それ以外をアカウントのディレクトリに置く KV リポジトリで管理する。
+func newTestAccountArtifactRepositories(t *testing.T, dir string) *AccountArtifactRepositories {
+	t.Helper()
+	accounts := NewAccountArtifactRepositories(filepath.Join(dir, "accounts"), func(account, accountDir string) (ArtifactStore, error) {
+		if account == DefaultAccount {
+			return OpenKVArtifactRepository(filepath.Join(dir, "artifacts.db"))
+		}
+		return OpenKVArtifactRepository(filepath.Join(accountDir, "artifacts.db"))
+	})
+	t.Cleanup(func() { _ = accounts.Close() })
+	return accounts
+}
+
+func TestAccountArtifactRepositories(t *testing.T) {
+	tests := []struct {
+		name string
+
+		// GIVEN
+		setup func(t *testing.T, dir string, accounts *AccountArtifactRepositories)
+
+		// WHEN
+		account string
+		create  bool
+
+		// THEN
+		expectedError    error
+		expectedAccounts []string
+	}{
+		{
+			name: "ShouldAlwaysHaveDefaultAccount",
+
+			account: DefaultAccount,
+
+			expectedAccounts: []string{DefaultAccount},
+		},
+		{
+			name: "ShouldCreateAccount",
+
+			account: "alt",
+			create:  true,
+
+			expectedAccounts: []string{"alt", DefaultAccount},
+		},
+		{
+			name: "ShouldReturnErrorWhenAccountNotFound",
+
+			account: "missing",
+
+			expectedError:    ErrAccountNotFound,
+			expectedAccounts: []string{DefaultAccount},
+		},
+		{
+			name: "ShouldReturnErrorWhenAccountAlreadyExists",
+
+			setup: func(t *testing.T, dir string, accounts *AccountArtifactRepositories) {
+				if err := accounts.CreateAccount("alt"); err != nil {
+					t.Fatal(err)
+				}
+			},
+
+			account: "alt",
+			create:  true,
+
+			expectedError:    ErrAccountAlreadyExists,
+			expectedAccounts: []string{"alt", DefaultAccount},
+		},
+		{
+			name: "ShouldReturnErrorWhenCreatingDefaultAccount",
+
+			account: DefaultAccount,
+			create:  true,
+
+			expectedError:    ErrAccountAlreadyExists,
+			expectedAccounts: []string{DefaultAccount},
+		},
+		{
+			name: "ShouldRejectAccountNameEscapingRoot",
+
+			account: "../alt",
+			create:  true,
+
+			expectedError:    ErrInvalidAccountName,
+			expectedAccounts: []string{DefaultAccount},
+		},
+		{
+			name: "ShouldIgnoreFilesAndInvalidNamesInRoot",
+
+			setup: func(t *testing.T, dir string, accounts *AccountArtifactRepositories) {
+				root := filepath.Join(dir, "accounts")
+				if err := os.MkdirAll(filepath.Join(root, "Upper"), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(filepath.Join(root, "notes"), nil, 0644); err != nil {
+					t.Fatal(err)
+				}
+			},
+
+			account: "notes",
+
+			expectedError:    ErrAccountNotFound,
+			expectedAccounts: []string{DefaultAccount},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			dir := t.TempDir()
+			accounts := newTestAccountArtifactRepositories(t, dir)
+			if tt.setup != nil {
+				tt.setup(t, dir, accounts)
+			}
+
+			// WHEN
+			var err error
+			if tt.create {
+				err = accounts.CreateAccount(tt.account)
+			} else {
+				_, err = accounts.GetAccountArtifacts(tt.account)
+			}
+
+			// THEN
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error: %v, got: %v", tt.expectedError, err)
+			}
+			got, err := accounts.GetAccounts()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.expectedAccounts, got); diff != "" {
+				t.Errorf("accounts mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAccountArtifactRepositoriesPartitionsArtifacts(t *testing.T) {
+	// GIVEN
+	dir := t.TempDir()
+	accounts := newTestAccountArtifactRepositories(t, dir)
+	if err := accounts.CreateAccount("alt"); err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+	defaultStore, err := accounts.GetAccountArtifacts(DefaultAccount)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	altStore, err := accounts.GetAccountArtifacts("alt")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// WHEN
+	artifact := &entity.Artifact{ID: "shared-id", Type: entity.ARTIFACT_TYPE_FLOWER, ArtifactSet: entity.ARTIFACT_SET_GLADIATORS_FINALOFFERING}
+	if err := altStore.SaveArtifact(artifact); err != nil {
+		t.Fatalf("failed to save artifact: %v", err)
+	}
+	if err := accounts.Compact(); err != nil {
+		t.Fatalf("failed to compact: %v", err)
+	}
+	if err := accounts.Close(); err != nil {
+		t.Fatalf("failed to close: %v", err)
+	}
+
+	// THEN
+	if _, err := defaultStore.GetArtifactByID("shared-id"); err == nil {
+		t.Errorf("expected closed store to fail")
+	}
+	reopened := newTestAccountArtifactRepositories(t, dir)
+	reopenedDefault, err := reopened.GetAccountArtifacts(DefaultAccount)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := reopenedDefault.GetArtifactByID("shared-id"); !errors.Is(err, ErrArtifactNotFound) {
+		t.Errorf("expected error: %v, got: %v", ErrArtifactNotFound, err)
+	}
+	reopenedAlt, err := reopened.GetAccountArtifacts("alt")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := reopenedAlt.GetArtifactByID("shared-id")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(artifact, got); diff != "" {
+		t.Errorf("artifact mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// stubArtifactStore は Compact と Close だけを持つ ArtifactStore。compact が nil の場合は何もしない。
+type stubArtifactStore struct {
+	ArtifactStore
+	compact func() error
+}
+
+func (s *stubArtifactStore) Compact() error {
+	if s.compact == nil {
+		return nil
+	}
+	return s.compact()
+}
+
+func (s *stubArtifactStore) Close() error {
+	return nil
+}
+
+// getAccountArtifactsWithin は time.Second 以内にリポジトリを取得できなければテストを失敗させる。
+func getAccountArtifactsWithin(t *testing.T, accounts *AccountArtifactRepositories, account string) ArtifactStore {
+	t.Helper()
+	type result struct {
+		store ArtifactStore
+		err   error
+	}
+	done := make(chan result, 1)
+	go func() {
+		store, err := accounts.GetAccountArtifacts(account)
+		done <- result{store, err}
+	}()
+	select {
+	case r := <-done:
+		if r.err != nil {
+			t.Fatalf("unexpected error: %v", r.err)
+		}
+		return r.store
+	case <-time.After(time.Second):
+		t.Fatalf("GetAccountArtifacts(%q) is blocked", account)
+		return nil
+	}
+}
+
+func TestAccountArtifactRepositoriesCompactDoesNotBlockRequests(t *testing.T) {
+	// GIVEN
+	compacting := make(chan struct{})
+	release := make(chan struct{})
+	accounts := NewAccountArtifactRepositories(filepath.Join(t.TempDir(), "accounts"), func(account, _ string) (ArtifactStore, error) {
+		if account == DefaultAccount {
+			return &stubArtifactStore{compact: func() error {
+				close(compacting)
+				<-release
+				return nil
+			}}, nil
+		}
+		return &stubArtifactStore{}, nil
+	})
+	if err := accounts.CreateAccount("alt"); err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+	getAccountArtifactsWithin(t, accounts, DefaultAccount)
+
+	// WHEN
+	compacted := make(chan error, 1)
+	go func() { compacted <- accounts.Compact() }()
+	<-compacting
+
+	// THEN
+	getAccountArtifactsWithin(t, accounts, DefaultAccount)
+	getAccountArtifactsWithin(t, accounts, "alt")
+	close(release)
+	if err := <-compacted; err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestAccountArtifactRepositoriesOpenDoesNotBlockOtherAccounts(t *testing.T) {
+	// GIVEN
+	opening := make(chan struct{})
+	release := make(chan struct{})
+	var altOpens atomic.Int32
+	accounts := NewAccountArtifactRepositories(filepath.Join(t.TempDir(), "accounts"), func(account, _ string) (ArtifactStore, error) {
+		if account == "alt" {
+			if altOpens.Add(1) == 1 {
+				close(opening)
+			}
+			<-release
+		}
+		return &stubArtifactStore{}, nil
+	})
+	if err := accounts.CreateAccount("alt"); err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+
+	// WHEN
+	const callers = 8
+	stores := make(chan ArtifactStore, callers)
+	var wg sync.WaitGroup
+	for range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			store, err := accounts.GetAccountArtifacts("alt")
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			stores <- store
+		}()
+	}
+	<-opening
+
+	// THEN
+	getAccountArtifactsWithin(t, accounts, DefaultAccount)
+	close(release)
+	wg.Wait()
+	close(stores)
+	first := <-stores
+	for store := range stores {
+		if store != first {
+			t.Errorf("expected every caller to share the same store")
+		}
+	}
+	if got := altOpens.Load(); got != 1 {
+		t.Errorf("expected alt to be opened once, got %d", got)
+	}
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"slices"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
+)
+
+type artifactIDSet map[string]struct{}
+
+// artifactIndex は部位・セット・メインステータス・サブステータスの有無・装備しているキャラクターから聖遺物 ID を引く二次索引。
+// 検索の計算量が全件数ではなく該当件数に比例するよう、保存・更新・削除のたびに差分で更新する。
+type artifactIndex struct {
+	byType        map[entity.ArtifactType]artifactIDSet
+	bySet         map[entity.ArtifactSet]artifactIDSet
+	byPrimaryStat map[entity.PrimaryStatType]artifactIDSet
+	bySubstat     map[entity.SubstatType]artifactIDSet
+	// byEquippedBy は装備している聖遺物だけを持つ。装備していない聖遺物は大半を占めるため索引にしない
+	byEquippedBy map[entity.CharacterKey]artifactIDSet
+}
+
+func newArtifactIndex(artifacts map[string]*entity.Artifact) *artifactIndex {
+	index := &artifactIndex{
+		byType:        make(map[entity.ArtifactType]artifactIDSet),
+		bySet:         make(map[entity.ArtifactSet]artifactIDSet),
+		byPrimaryStat: make(map[entity.PrimaryStatType]artifactIDSet),
+		bySubstat:     make(map[entity.SubstatType]artifactIDSet),
+		byEquippedBy:  make(map[entity.CharacterKey]artifactIDSet),
+	}
+	for _, artifact := range artifacts {
+		index.add(artifact)
+	}
+	return index
+}
+
+func (index *artifactIndex) add(artifact *entity.Artifact) {
+	addToIndex(index.byType, artifact.Type, artifact.ID)
+	addToIndex(index.bySet, artifact.ArtifactSet, artifact.ID)
+	addToIndex(index.byPrimaryStat, artifact.PrimaryStat.Type, artifact.ID)
+	for _, substat := range artifact.Substats {
+		addToIndex(index.bySubstat, substat.Type, artifact.ID)
+	}
+	if artifact.EquippedBy != "" {
+		addToIndex(index.byEquippedBy, artifact.EquippedBy, artifact.ID)
+	}
+}
+
+func (index *artifactIndex) remove(artifact *entity.Artifact) {
+	removeFromIndex(index.byType, artifact.Type, artifact.ID)
+	removeFromIndex(index.bySet, artifact.ArtifactSet, artifact.ID)
+	removeFromIndex(index.byPrimaryStat, artifact.PrimaryStat.Type, artifact.ID)
+	for _, substat := range artifact.Substats {
+		removeFromIndex(index.bySubstat, substat.Type, artifact.ID)
+	}
+	if artifact.EquippedBy != "" {
+		removeFromIndex(index.byEquippedBy, artifact.EquippedBy, artifact.ID)
+	}
+}
+
+func addToIndex[K comparable](index map[K]artifactIDSet, key K, id string) {
+	ids, ok := index[key]
+	if !ok {
+		ids = make(artifactIDSet)
+		index[key] = ids
+	}
+	ids[id] = struct{}{}
+}
+
+// removeFromIndex は空になったキーを削除し、使われなくなった値で索引が膨らまないようにする。
+func removeFromIndex[K comparable](index map[K]artifactIDSet, key K, id string) {
+	ids, ok := index[key]
+	if !ok {
+		return
+	}
+	delete(ids, id)
+	if len(ids) == 0 {
+		delete(index, key)
+	}
+}
+
+// union は複数のキーに該当する ID の和集合を返す。
+func union[K comparable](index map[K]artifactIDSet, keys []K) artifactIDSet {
+	if len(keys) == 1 {
+		return index[keys[0]]
+	}
+	result := make(artifactIDSet)
+	for _, key := range keys {
+		for id := range index[key] {
+			result[id] = struct{}{}
+		}
+	}
+	return result
+}
+
+// candidates は検索条件のうち索引で引ける条件ごとに候補を求め、最も小さい候補集合を返す。
+// 残りの条件は呼び出し側で絞り込むこと。索引で引ける条件がなければ ok は false になる。
+func (index *artifactIndex) candidates(query ArtifactQuery) (artifactIDSet, bool) {
+	var smallest artifactIDSet
+	found := false
+	consider := func(ids artifactIDSet) {
+		if !found || len(ids) < len(smallest) {
+			smallest = ids
+			found = true
+		}
+	}
+
+	if len(query.Types) > 0 {
+		consider(union(index.byType, query.Types))
+	}
+	if len(query.Sets) > 0 {
+		consider(union(index.bySet, query.Sets))
+	}
+	if len(query.PrimaryStats) > 0 {
+		consider(union(index.byPrimaryStat, query.PrimaryStats))
+	}
+	for _, substatType := range query.RequiredSubstats {
+		consider(index.bySubstat[substatType])
+	}
+	for substatType := range query.MinSubstatValues {
+		consider(index.bySubstat[substatType])
+	}
+	if len(query.EquippedBy) > 0 && !slices.Contains(query.EquippedBy, "") {
+		consider(union(index.byEquippedBy, query.EquippedBy))
+	}
+	return smallest, found
+}
@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestInMemoryArtifactRepositoryIndexFollowsWrites(t *testing.T) {
+	repo := NewInMemoryArtifactRepository()
+	flower := &entity.Artifact{
+		ID: "flower", ArtifactSet: entity.ARTIFACT_SET_GLADIATORS_FINALOFFERING, Type: entity.ARTIFACT_TYPE_FLOWER,
+		PrimaryStat: entity.PrimaryStat{Type: entity.HP_FLAT},
+		Substats:    []entity.Substat{{Type: entity.SUBSTAT_CRIT_RATE, Value: 3.9}},
+		EquippedBy:  "Diluc",
+	}
+	if err := repo.SaveArtifact(flower); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// 部位・セット・サブステータス・装備するキャラクターを変更した更新後は、古いキーから引けなくなる
+	updated := &entity.Artifact{
+		ID: "flower", ArtifactSet: entity.ARTIFACT_SET_NOBLESSE_OBLIGE, Type: entity.ARTIFACT_TYPE_PLUME,
+		PrimaryStat: entity.PrimaryStat{Type: entity.ATK_FLAT},
+		Substats:    []entity.Substat{{Type: entity.SUBSTAT_CRIT_DMG, Value: 7.8}},
+		EquippedBy:  "Bennett",
+	}
+	if err := repo.UpdateArtifact(updated); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if artifacts, _ := repo.GetArtifactByType(entity.ARTIFACT_TYPE_FLOWER); len(artifacts) != 0 {
+		t.Errorf("expected no artifacts for old type, got: %d", len(artifacts))
+	}
+	if artifacts, _ := repo.GetArtifactBySet(entity.ARTIFACT_SET_GLADIATORS_FINALOFFERING); len(artifacts) != 0 {
+		t.Errorf("expected no artifacts for old set, got: %d", len(artifacts))
+	}
+	artifacts, err := repo.GetArtifactByTypeAndSet(entity.ARTIFACT_TYPE_PLUME, entity.ARTIFACT_SET_NOBLESSE_OBLIGE)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]*entity.Artifact{updated}, artifacts); diff != "" {
+		t.Errorf("GetArtifactByTypeAndSet() mismatch (-want +got):\n%s", diff)
+	}
+	result, err := repo.QueryArtifacts(ArtifactQuery{RequiredSubstats: []entity.SubstatType{entity.SUBSTAT_CRIT_RATE}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Total != 0 {
+		t.Errorf("expected no artifact with old substat, got: %d", result.Total)
+	}
+	if ids, _ := repo.index.candidates(ArtifactQuery{EquippedBy: []entity.CharacterKey{"Diluc"}}); len(ids) != 0 {
+		t.Errorf("expected no artifact for old character, got: %v", ids)
+	}
+	ids, ok := repo.index.candidates(ArtifactQuery{EquippedBy: []entity.CharacterKey{"Bennett"}})
+	if diff := cmp.Diff(artifactIDSet{"flower": {}}, ids); !ok || diff != "" {
+		t.Errorf("candidates() mismatch (-want +got):\n%s", diff)
+	}
+
+	if err := repo.DeleteArtifactByID("flower"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if artifacts, _ := repo.GetArtifactByType(entity.ARTIFACT_TYPE_PLUME); len(artifacts) != 0 {
+		t.Errorf("expected no artifacts after delete, got: %d", len(artifacts))
+	}
+	if len(repo.index.byType) != 0 || len(repo.index.bySet) != 0 || len(repo.index.byPrimaryStat) != 0 || len(repo.index.bySubstat) != 0 || len(repo.index.byEquippedBy) != 0 {
+		t.Errorf("expected empty index after delete, got: %+v", repo.index)
+	}
+}
+
+func TestInMemoryArtifactRepositoryIndexRebuiltOnLoad(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "artifacts.json")
+	source := newQueryTestRepository()
+	if err := source.SaveJSONFile(filename); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	repo := NewInMemoryArtifactRepository()
+	if err := repo.LoadJSONFile(filename); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	artifacts, err := repo.GetArtifactBySet(entity.ARTIFACT_SET_NOBLESSE_OBLIGE)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ids := artifactIDs(artifacts)
+	slices.Sort(ids)
+	if diff := cmp.Diff([]string{"b", "d"}, ids); diff != "" {
+		t.Errorf("GetArtifactBySet() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+var benchmarkInventorySizes = []int{1_000, 10_000, 100_000}
+
+// benchmarkTargetCount は検索で該当する件数で、全件数に関わらず一定にする
+const benchmarkTargetCount = 10
+
+const benchmarkTargetSet entity.ArtifactSet = "BenchmarkTarget"
+
+// newBenchmarkRepository は size 件の聖遺物のうち benchmarkTargetCount 件だけが
+// benchmarkTargetSet の FLOWER になるリポジトリを作る。
+func newBenchmarkRepository(b *testing.B, size int) *InMemoryArtifactRepository {
+	b.Helper()
+	types := []entity.ArtifactType{
+		entity.ARTIFACT_TYPE_PLUME, entity.ARTIFACT_TYPE_SANDS, entity.ARTIFACT_TYPE_GOBLET, entity.ARTIFACT_TYPE_CIRCLET,
+	}
+
+	repo := NewInMemoryArtifactRepository()
+	for i := range size {
+		artifact := &entity.Artifact{
+			ID:          fmt.Sprintf("artifact-%d", i),
+			ArtifactSet: entity.ArtifactSet(fmt.Sprintf("set-%d", i%50)),
+			Type:        types[i%len(types)],
+			PrimaryStat: entity.PrimaryStat{Type: entity.ATK_PERCENT},
+			Substats:    []entity.Substat{{Type: entity.SUBSTAT_CRIT_DMG, Value: 7.8}},
+		}
+		if i < benchmarkTargetCount {
+			artifact.ArtifactSet = benchmarkTargetSet
+			artifact.Type = entity.ARTIFACT_TYPE_FLOWER
+			artifact.PrimaryStat.Type = entity.HP_FLAT
+		}
+		if err := repo.SaveArtifact(artifact); err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+	}
+	return repo
+}
+
+func BenchmarkInMemoryArtifactRepositoryGetArtifactByType(b *testing.B) {
+	for _, size := range benchmarkInventorySizes {
+		b.Run(fmt.Sprintf("size=%d", size), func(b *testing.B) {
+			repo := newBenchmarkRepository(b, size)
+			b.ResetTimer()
+			for range b.N {
+				if _, err := repo.GetArtifactByType(entity.ARTIFACT_TYPE_FLOWER); err != nil {
+					b.Fatalf("unexpected error: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkInMemoryArtifactRepositoryGetArtifactBySet(b *testing.B) {
+	for _, size := range benchmarkInventorySizes {
+		b.Run(fmt.Sprintf("size=%d", size), func(b *testing.B) {
+			repo := newBenchmarkRepository(b, size)
+			b.ResetTimer()
+			for range b.N {
+				if _, err := repo.GetArtifactBySet(benchmarkTargetSet); err != nil {
+					b.Fatalf("unexpected error: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkInMemoryArtifactRepositoryGetArtifactByTypeAndSet(b *testing.B) {
+	for _, size := range benchmarkInventorySizes {
+		b.Run(fmt.Sprintf("size=%d", size), func(b *testing.B) {
+			repo := newBenchmarkRepository(b, size)
+			b.ResetTimer()
+			for range b.N {
+				if _, err := repo.GetArtifactByTypeAndSet(entity.ARTIFACT_TYPE_FLOWER, benchmarkTargetSet); err != nil {
+					b.Fatalf("unexpected error: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkInMemoryArtifactRepositoryQueryArtifactsByPrimaryStat(b *testing.B) {
+	for _, size := range benchmarkInventorySizes {
+		b.Run(fmt.Sprintf("size=%d", size), func(b *testing.B) {
+			repo := newBenchmarkRepository(b, size)
+			query := ArtifactQuery{
+				PrimaryStats: []entity.PrimaryStatType{entity.HP_FLAT},
+				SortKey:      SORT_BY_CRIT_VALUE,
+			}
+			b.ResetTimer()
+			for range b.N {
+				if _, err := repo.QueryArtifacts(query); err != nil {
+					b.Fatalf("unexpected error: %v", err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package repository_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/repository"
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/repository/repositorytest"
+)
+
+// walArtifactRepository は InMemoryArtifactRepository をスナップショットと先行書き込みログで永続化する。
+// snapshotOnClose が false の場合は Close でスナップショットを書き出さず、ログだけから復元させる。
+type walArtifactRepository struct {
+	*repository.InMemoryArtifactRepository
+	dataFilePath    string
+	snapshotOnClose bool
+}
+
+func (r *walArtifactRepository) Close() error {
+	if r.snapshotOnClose {
+		if err := r.Compact(r.dataFilePath); err != nil {
+			return err
+		}
+	}
+	return r.CloseWAL()
+}
+
+func inMemoryArtifactRepositoryFactory(snapshotOnClose bool) repositorytest.Factory {
+	return func(t *testing.T, dir string) repositorytest.Repository {
+		dataFilePath := filepath.Join(dir, "artifacts.json")
+		repo := repository.NewInMemoryArtifactRepository()
+		if _, err := repo.RestoreJSONFile(dataFilePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			t.Fatalf("failed to restore snapshot: %v", err)
+		}
+		if err := repo.OpenWAL(filepath.Join(dir, "artifacts.wal")); err != nil {
+			t.Fatalf("failed to open WAL: %v", err)
+		}
+		return &walArtifactRepository{
+			InMemoryArtifactRepository: repo,
+			dataFilePath:               dataFilePath,
+			snapshotOnClose:            snapshotOnClose,
+		}
+	}
+}
+
+func TestInMemoryArtifactRepositoryConformance(t *testing.T) {
+	t.Run("SnapshotOnClose", func(t *testing.T) {
+		repositorytest.Run(t, inMemoryArtifactRepositoryFactory(true))
+	})
+	t.Run("WALOnly", func(t *testing.T) {
+		repositorytest.Run(t, inMemoryArtifactRepositoryFactory(false))
+	})
+}
+
+func TestKVArtifactRepositoryConformance(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T, dir string) repositorytest.Repository {
+		repo, err := repository.OpenKVArtifactRepository(filepath.Join(dir, "artifacts.db"))
+		if err != nil {
+			t.Fatalf("failed to open repository: %v", err)
+		}
+		return repo
+	})
+}
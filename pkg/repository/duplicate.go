@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
+)
+
+var (
+	ErrDuplicateArtifact = errors.New("duplicate artifact")
+)
+
+// DuplicateQuery は artifact と内容が同じ聖遺物の候補を、部位・セットなどの索引で絞り込む検索条件を返す。
+// 内容が同じかどうかは、候補ごとに entity.Artifact.SameContent で確かめること。
+func DuplicateQuery(artifact *entity.Artifact) ArtifactQuery {
+	query := ArtifactQuery{
+		Types:        []entity.ArtifactType{artifact.Type},
+		Sets:         []entity.ArtifactSet{artifact.ArtifactSet},
+		PrimaryStats: []entity.PrimaryStatType{artifact.PrimaryStat.Type},
+		Rarities:     []int{artifact.Rarity},
+		MinLevel:     &artifact.Level,
+		MaxLevel:     &artifact.Level,
+	}
+	for _, substat := range artifact.Substats {
+		query.RequiredSubstats = append(query.RequiredSubstats, substat.Type)
+	}
+	return query
+}
+
+// duplicateCandidates は書き込み前の保存内容から、検索条件の索引で絞り込んだ候補を返す。
+// リポジトリは書き込みロックやトランザクションの中で渡すこと。
+type duplicateCandidates func(query ArtifactQuery) ([]*entity.Artifact, error)
+
+// checkDuplicates は artifacts のそれぞれが、保存済みの聖遺物とも artifacts の先の聖遺物とも内容が異なることを確かめる。
+func checkDuplicates(candidates duplicateCandidates, artifacts []*entity.Artifact) error {
+	for i, artifact := range artifacts {
+		if err := checkDuplicate(candidates, artifacts[:i], artifact); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkDuplicate は artifact と内容が同じ聖遺物が、保存済みの聖遺物か同じ呼び出しで先に保存する earlier にあれば ErrDuplicateArtifact を返す。
+func checkDuplicate(candidates duplicateCandidates, earlier []*entity.Artifact, artifact *entity.Artifact) error {
+	stored, err := candidates(DuplicateQuery(artifact))
+	if err != nil {
+		return err
+	}
+
+	var duplicateIDs []string
+	for _, other := range append(stored, earlier...) {
+		if other.ID != artifact.ID && other.SameContent(artifact) {
+			duplicateIDs = append(duplicateIDs, other.ID)
+		}
+	}
+	if len(duplicateIDs) > 0 {
+		return fmt.Errorf("%w: same content as %s", ErrDuplicateArtifact, strings.Join(duplicateIDs, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
+)
+
+var (
+	ErrEquipmentSlotOccupied = errors.New("equipment slot is occupied")
+)
+
+type equipmentSlot struct {
+	character    entity.CharacterKey
+	artifactType entity.ArtifactType
+}
+
+// equipmentState は書き込み前の保存内容を読む。リポジトリは書き込みロックやトランザクションの中で渡すこと。
+type equipmentState struct {
+	// current は書き込み前の聖遺物を返し、存在しなければ nil を返す
+	current func(id string) (*entity.Artifact, error)
+	// equipped はキャラクターが装備している書き込み前の聖遺物を返す
+	equipped func(character entity.CharacterKey) ([]*entity.Artifact, error)
+}
+
+// checkEquipmentSlots は artifacts を書き込んだ後に、一人のキャラクターが同じ部位に二つの聖遺物を装備しないことを確かめる。
+// 書き込み前から装備するキャラクターと部位が変わらない聖遺物は確かめない。
+// 旧データで同じ部位を複数装備している聖遺物も、装備を変えなければ編集できるようにするため。
+func checkEquipmentSlots(state equipmentState, artifacts []*entity.Artifact) error {
+	writing := make(map[string]*entity.Artifact, len(artifacts))
+	for _, artifact := range artifacts {
+		writing[artifact.ID] = artifact
+	}
+
+	claimed := make(map[equipmentSlot]string)
+	for _, artifact := range artifacts {
+		if artifact.EquippedBy == "" {
+			continue
+		}
+		current, err := state.current(artifact.ID)
+		if err != nil {
+			return err
+		}
+		if current != nil && current.EquippedBy == artifact.EquippedBy && current.Type == artifact.Type {
+			continue
+		}
+
+		slot := equipmentSlot{character: artifact.EquippedBy, artifactType: artifact.Type}
+		if id, ok := claimed[slot]; ok {
+			return equipmentSlotOccupiedError(slot, id)
+		}
+		claimed[slot] = artifact.ID
+
+		equipped, err := state.equipped(artifact.EquippedBy)
+		if err != nil {
+			return err
+		}
+		for _, other := range equipped {
+			if other.ID == artifact.ID {
+				continue
+			}
+			// 同じ書き込みで装備を変える聖遺物は、書き込み後の値で判定する
+			if written, ok := writing[other.ID]; ok {
+				other = written
+			}
+			if other.EquippedBy == slot.character && other.Type == slot.artifactType {
+				return equipmentSlotOccupiedError(slot, other.ID)
+			}
+		}
+	}
+	return nil
+}
+
+func equipmentSlotOccupiedError(slot equipmentSlot, id string) error {
+	return fmt.Errorf("%w: %s already equips %s %s", ErrEquipmentSlotOccupied, slot.character, slot.artifactType, id)
+}
+
+// slotOccupants はキャラクターがその部位に装備している聖遺物を ID 順に返す。
+func slotOccupants(state equipmentState, slot equipmentSlot) ([]*entity.Artifact, error) {
+	equipped, err := state.equipped(slot.character)
+	if err != nil {
+		return nil, err
+	}
+	var occupants []*entity.Artifact
+	for _, artifact := range equipped {
+		if artifact.Type == slot.artifactType {
+			occupants = append(occupants, artifact)
+		}
+	}
+	slices.SortFunc(occupants, func(a, b *entity.Artifact) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	return occupants, nil
+}
+
+// equipArtifactUpdates は聖遺物をキャラクターに装備させるために更新する聖遺物を返す。すでに装備していれば空を返す。
+// 同じ部位に装備していた聖遺物は、装備させる聖遺物の元の持ち主に渡し、元の持ち主がいなければ外す。
+// 旧データで同じ部位を複数装備していた場合、元の持ち主に渡すのは ID 順で先の聖遺物だけで、残りは外す。
+// 取得した聖遺物は読み手と共有している場合があるため、書き換えずに複製して返す。
+func equipArtifactUpdates(state equipmentState, artifact *entity.Artifact, character entity.CharacterKey) ([]*entity.Artifact, error) {
+	if artifact.EquippedBy == character {
+		return nil, nil
+	}
+	occupants, err := slotOccupants(state, equipmentSlot{character: character, artifactType: artifact.Type})
+	if err != nil {
+		return nil, err
+	}
+
+	equipped := *artifact
+	equipped.EquippedBy = character
+	updates := []*entity.Artifact{&equipped}
+	for i, occupant := range occupants {
+		swapped := *occupant
+		swapped.EquippedBy = ""
+		if i == 0 {
+			swapped.EquippedBy = artifact.EquippedBy
+		}
+		updates = append(updates, &swapped)
+	}
+	return updates, nil
+}
+
+// unequipArtifactUpdates はキャラクターのその部位の聖遺物を外すために更新する聖遺物を返す。何も装備していなければ空を返す。
+func unequipArtifactUpdates(state equipmentState, character entity.CharacterKey, artifactType entity.ArtifactType) ([]*entity.Artifact, error) {
+	occupants, err := slotOccupants(state, equipmentSlot{character: character, artifactType: artifactType})
+	if err != nil {
+		return nil, err
+	}
+
+	updates := make([]*entity.Artifact, 0, len(occupants))
+	for _, occupant := range occupants {
+		unequipped := *occupant
+		unequipped.EquippedBy = ""
+		updates = append(updates, &unequipped)
+	}
+	return updates, nil
+}
@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
+)
+
+var (
+	ErrAPIKeyNotFound = errors.New("API key not found")
+	ErrAPIKeyIsNil    = errors.New("API key is nil")
+)
+
+// InMemoryAPIKeyRepository は API キーを保持する。
+// OpenJSONFile でファイルを指定した場合、変更のたびにファイル全体を書き出す。
+// 鍵はサーバーの起動中に管理コマンドから追加・削除されるため、
+// 参照時にファイルが他のプロセスで更新されていれば読み直す。
+type InMemoryAPIKeyRepository struct {
+	mu   sync.RWMutex
+	Keys map[string]*entity.APIKey
+
+	filename string
+	// loadedModTime と loadedSize は最後に読み書きしたときのファイルの状態
+	loadedModTime time.Time
+	loadedSize    int64
+}
+
+func NewInMemoryAPIKeyRepository() *InMemoryAPIKeyRepository {
+	return &InMemoryAPIKeyRepository{
+		Keys: make(map[string]*entity.APIKey),
+	}
+}
+
+func (repo *InMemoryAPIKeyRepository) GetAPIKey(id string) (*entity.APIKey, error) {
+	if err := repo.refresh(); err != nil {
+		return nil, err
+	}
+
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	key, exists := repo.Keys[id]
+	if !exists {
+		return nil, ErrAPIKeyNotFound
+	}
+	return key, nil
+}
+
+// GetAPIKeys は作成日時の順に並べた API キーを返す。
+func (repo *InMemoryAPIKeyRepository) GetAPIKeys() ([]*entity.APIKey, error) {
+	if err := repo.refresh(); err != nil {
+		return nil, err
+	}
+
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	result := make([]*entity.APIKey, 0, len(repo.Keys))
+	for _, key := range repo.Keys {
+		result = append(result, key)
+	}
+	slices.SortFunc(result, func(a, b *entity.APIKey) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return result, nil
+}
+
+// SaveAPIKey は同じ ID の API キーがあれば置き換える。
+func (repo *InMemoryAPIKeyRepository) SaveAPIKey(key *entity.APIKey) error {
+	if key == nil {
+		return ErrAPIKeyIsNil
+	}
+	if err := repo.refresh(); err != nil {
+		return err
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	previous, existed := repo.Keys[key.ID]
+	repo.Keys[key.ID] = key
+	if err := repo.persist(); err != nil {
+		if existed {
+			repo.Keys[key.ID] = previous
+		} else {
+			delete(repo.Keys, key.ID)
+		}
+		return err
+	}
+	return nil
+}
+
+func (repo *InMemoryAPIKeyRepository) DeleteAPIKey(id string) error {
+	if err := repo.refresh(); err != nil {
+		return err
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	previous, exists := repo.Keys[id]
+	if !exists {
+		return ErrAPIKeyNotFound
+	}
+
+	delete(repo.Keys, id)
+	if err := repo.persist(); err != nil {
+		repo.Keys[id] = previous
+		return err
+	}
+	return nil
+}
+
+type apiKeys struct {
+	Keys map[string]*entity.APIKey `json:"keys"`
+}
+
+// OpenJSONFile はファイルから API キーを読み込み、以降の変更の書き出し先とする。
+// ファイルが存在しない場合は空の状態から始める。
+func (repo *InMemoryAPIKeyRepository) OpenJSONFile(filename string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	repo.filename = filename
+	return repo.load()
+}
+
+// refresh はファイルが最後に読み書きしたときから変わっていれば読み直す。
+func (repo *InMemoryAPIKeyRepository) refresh() error {
+	repo.mu.RLock()
+	filename, modTime, size := repo.filename, repo.loadedModTime, repo.loadedSize
+	repo.mu.RUnlock()
+	if filename == "" {
+		return nil
+	}
+
+	info, err := os.Stat(filename)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err == nil && info.ModTime().Equal(modTime) && info.Size() == size {
+		return nil
+	}
+	if err != nil && modTime.IsZero() {
+		return nil
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	return repo.load()
+}
+
+// load は呼び出し元が mu を保持していることを前提とする。
+func (repo *InMemoryAPIKeyRepository) load() error {
+	loaded := make(map[string]*entity.APIKey)
+
+	file, err := os.ReadFile(repo.filename)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err == nil {
+		var keyData apiKeys
+		if err := json.Unmarshal(file, &keyData); err != nil {
+			return err
+		}
+		if keyData.Keys != nil {
+			loaded = keyData.Keys
+		}
+	}
+
+	repo.Keys = loaded
+	return repo.recordFileState()
+}
+
+// persist は呼び出し元が mu を保持していることを前提とする。
+func (repo *InMemoryAPIKeyRepository) persist() error {
+	if repo.filename == "" {
+		return nil
+	}
+
+	keyBytes, err := json.Marshal(apiKeys{Keys: repo.Keys})
+	if err != nil {
+		return err
+	}
+	// ハッシュであっても鍵の一覧を他のユーザーに読ませない
+	if err := writeFileAtomic(repo.filename, keyBytes, 0600); err != nil {
+		return err
+	}
+	return repo.recordFileState()
+}
+
+// recordFileState は呼び出し元が mu を保持していることを前提とする。
+func (repo *InMemoryAPIKeyRepository) recordFileState() error {
+	info, err := os.Stat(repo.filename)
+	if os.IsNotExist(err) {
+		repo.loadedModTime, repo.loadedSize = time.Time{}, 0
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	repo.loadedModTime, repo.loadedSize = info.ModTime(), info.Size()
+	return nil
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func openTestAPIKeyRepository(t *testing.T, filename string) *InMemoryAPIKeyRepository {
+	t.Helper()
+	repo := NewInMemoryAPIKeyRepository()
+	if err := repo.OpenJSONFile(filename); err != nil {
+		t.Fatalf("OpenJSONFile() error = %v", err)
+	}
+	return repo
+}
+
+func TestInMemoryAPIKeyRepositoryPersistence(t *testing.T) {
+	// GIVEN
+	filename := filepath.Join(t.TempDir(), "api_keys.json")
+	repo := openTestAPIKeyRepository(t, filename)
+	createdAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	keys := []*entity.APIKey{
+		{ID: "B", Name: "scanner", SecretHash: "hash-b", Scope: entity.API_KEY_SCOPE_READ_WRITE, Accounts: []string{"default"}, CreatedAt: createdAt},
+		{ID: "A", Name: "dashboard", SecretHash: "hash-a", Scope: entity.API_KEY_SCOPE_READ_ONLY, Accounts: []string{"*"}, CreatedAt: createdAt.Add(time.Hour)},
+		{ID: "C", Name: "revoked", SecretHash: "hash-c", Scope: entity.API_KEY_SCOPE_READ_ONLY, Accounts: []string{"alt"}, CreatedAt: createdAt},
+	}
+	for _, key := range keys {
+		if err := repo.SaveAPIKey(key); err != nil {
+			t.Fatalf("SaveAPIKey() error = %v", err)
+		}
+	}
+
+	// WHEN
+	if err := repo.DeleteAPIKey("C"); err != nil {
+		t.Fatalf("DeleteAPIKey() error = %v", err)
+	}
+	reopened := openTestAPIKeyRepository(t, filename)
+
+	// THEN
+	got, err := reopened.GetAPIKeys()
+	if err != nil {
+		t.Fatalf("GetAPIKeys() error = %v", err)
+	}
+	if diff := cmp.Diff([]*entity.APIKey{keys[0], keys[1]}, got); diff != "" {
+		t.Errorf("GetAPIKeys() mismatch (-want +got):\n%s", diff)
+	}
+	if _, err := reopened.GetAPIKey("C"); !errors.Is(err, ErrAPIKeyNotFound) {
+		t.Errorf("expected error: %v, got: %v", ErrAPIKeyNotFound, err)
+	}
+	if err := reopened.DeleteAPIKey("C"); !errors.Is(err, ErrAPIKeyNotFound) {
+		t.Errorf("expected error: %v, got: %v", ErrAPIKeyNotFound, err)
+	}
+	info, err := os.Stat(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("expected file mode 0600, got %o", perm)
+	}
+}
+
+func TestInMemoryAPIKeyRepositoryReloadsChangedFile(t *testing.T) {
+	// GIVEN
+	filename := filepath.Join(t.TempDir(), "api_keys.json")
+	server := openTestAPIKeyRepository(t, filename)
+	if _, err := server.GetAPIKey("A"); !errors.Is(err, ErrAPIKeyNotFound) {
+		t.Fatalf("expected error: %v, got: %v", ErrAPIKeyNotFound, err)
+	}
+
+	// WHEN
+	// 鍵の管理コマンドは別のプロセスとしてファイルを書き換える
+	command := openTestAPIKeyRepository(t, filename)
+	key := &entity.APIKey{ID: "A", Name: "scanner", SecretHash: "hash", Scope: entity.API_KEY_SCOPE_READ_WRITE, Accounts: []string{"default"}}
+	if err := command.SaveAPIKey(key); err != nil {
+		t.Fatalf("SaveAPIKey() error = %v", err)
+	}
+
+	// THEN
+	got, err := server.GetAPIKey("A")
+	if err != nil {
+		t.Fatalf("GetAPIKey() error = %v", err)
+	}
+	if diff := cmp.Diff(key, got); diff != "" {
+		t.Errorf("GetAPIKey() mismatch (-want +got):\n%s", diff)
+	}
+
+	if err := command.DeleteAPIKey("A"); err != nil {
+		t.Fatalf("DeleteAPIKey() error = %v", err)
+	}
+	if _, err := server.GetAPIKey("A"); !errors.Is(err, ErrAPIKeyNotFound) {
+		t.Errorf("expected revoked key to be rejected, got: %v", err)
+	}
+}
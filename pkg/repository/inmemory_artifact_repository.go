@@ -3,7 +3,10 @@ package repository
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
+	"sync"
+	"time"
 
 	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
 )
@@ -13,15 +16,180 @@ var (
 	ErrArtifactAlreadyExists = errors.New("artifact already exists")
 	ErrArtifactIsNil         = errors.New("artifact is nil")
 	ErrArtifactIDIsEmpty     = errors.New("artifact ID is empty")
+	ErrArtifactIDDuplicated  = errors.New("artifact ID is duplicated in batch")
 )
 
+// validateArtifactBatch はまとめて保存・更新する聖遺物に nil や空の ID、同じ ID の重複がないことを確かめる。
+// 保存では重複した ID を ErrArtifactAlreadyExists として扱い、既存の聖遺物との重複と区別しない。
+func validateArtifactBatch(artifacts []*entity.Artifact, errDuplicated error) error {
+	ids := make(map[string]struct{}, len(artifacts))
+	for i, artifact := range artifacts {
+		if artifact == nil {
+			return fmt.Errorf("%w: index %d", ErrArtifactIsNil, i)
+		}
+		if artifact.ID == "" {
+			return fmt.Errorf("%w: index %d", ErrArtifactIDIsEmpty, i)
+		}
+		if _, duplicated := ids[artifact.ID]; duplicated {
+			return fmt.Errorf("%w: %s", errDuplicated, artifact.ID)
+		}
+		ids[artifact.ID] = struct{}{}
+	}
+	return nil
+}
+
+// savableArtifacts は SaveEachArtifact のために artifacts を先頭から順に確かめ、保存できる聖遺物と、
+// artifacts と同じ順に並べた保存できない理由を返す。保存内容を読めなかった場合はエラーを返す。
+// exists・state・candidates は書き込みロックやトランザクションの中で渡すこと。
+func savableArtifacts(
+	artifacts []*entity.Artifact,
+	options ArtifactSaveOptions,
+	exists func(id string) bool,
+	state equipmentState,
+	candidates duplicateCandidates,
+) ([]*entity.Artifact, []error, error) {
+	savable := make([]*entity.Artifact, 0, len(artifacts))
+	errs := make([]error, len(artifacts))
+	ids := make(map[string]struct{}, len(artifacts))
+	claimed := make(map[equipmentSlot]string)
+	for i, artifact := range artifacts {
+		if artifact == nil {
+			errs[i] = fmt.Errorf("%w: index %d", ErrArtifactIsNil, i)
+			continue
+		}
+		if artifact.ID == "" {
+			errs[i] = fmt.Errorf("%w: index %d", ErrArtifactIDIsEmpty, i)
+			continue
+		}
+		if _, duplicated := ids[artifact.ID]; duplicated || exists(artifact.ID) {
+			errs[i] = fmt.Errorf("%w: %s", ErrArtifactAlreadyExists, artifact.ID)
+			continue
+		}
+
+		// 先に保存する聖遺物とは、装備する部位だけを比べれば足りる
+		slot := equipmentSlot{character: artifact.EquippedBy, artifactType: artifact.Type}
+		if id, ok := claimed[slot]; ok && artifact.EquippedBy != "" {
+			errs[i] = equipmentSlotOccupiedError(slot, id)
+			continue
+		}
+		if err := checkEquipmentSlots(state, []*entity.Artifact{artifact}); err != nil {
+			if !errors.Is(err, ErrEquipmentSlotOccupied) {
+				return nil, nil, err
+			}
+			errs[i] = err
+			continue
+		}
+		if options.RejectDuplicates {
+			if err := checkDuplicate(candidates, savable, artifact); err != nil {
+				if !errors.Is(err, ErrDuplicateArtifact) {
+					return nil, nil, err
+				}
+				errs[i] = err
+				continue
+			}
+		}
+
+		ids[artifact.ID] = struct{}{}
+		if artifact.EquippedBy != "" {
+			claimed[slot] = artifact.ID
+		}
+		savable = append(savable, artifact)
+	}
+	return savable, errs, nil
+}
+
+// validateModifiedArtifact は ModifyArtifact の modify が返した聖遺物を確かめる。
+func validateModifiedArtifact(id string, artifact *entity.Artifact) error {
+	if artifact == nil {
+		return ErrArtifactIsNil
+	}
+	if artifact.ID != id {
+		return fmt.Errorf("modified artifact %s has different ID %s", id, artifact.ID)
+	}
+	return nil
+}
+
+// InMemoryArtifactRepository は複数の goroutine から同時に利用できる。
+// 読み取りは RLock、書き込みは Lock で保護され、一覧系の取得は
+// ロック取得時点のスナップショットを返す。
+// Artifacts は部位・セットなどの二次索引と同期しているため、生成後は直接書き換えず
+// SaveArtifact などのメソッドを通して更新すること。
 type InMemoryArtifactRepository struct {
+	mu        sync.RWMutex
 	Artifacts map[string]*entity.Artifact
+
+	indexOnce sync.Once
+	index     *artifactIndex
+
+	// BackupCount はスナップショット書き出し時に残す世代数。0 以下ならバックアップしない。
+	BackupCount int
+
+	wal *writeAheadLog
 }
 
 func NewInMemoryArtifactRepository() *InMemoryArtifactRepository {
 	return &InMemoryArtifactRepository{
 		Artifacts: make(map[string]*entity.Artifact),
+		index:     newArtifactIndex(nil),
+	}
+}
+
+// indexes は呼び出し元が mu を保持していることを前提とする。
+// Artifacts を直接指定して生成されたリポジトリでは、最初の呼び出しで索引を構築する。
+func (repo *InMemoryArtifactRepository) indexes() *artifactIndex {
+	repo.indexOnce.Do(func() {
+		if repo.index == nil {
+			repo.index = newArtifactIndex(repo.Artifacts)
+		}
+	})
+	return repo.index
+}
+
+// replaceArtifacts は呼び出し元が mu の書き込みロックを保持していることを前提とする。
+func (repo *InMemoryArtifactRepository) replaceArtifacts(artifacts map[string]*entity.Artifact) {
+	repo.Artifacts = artifacts
+	repo.index = newArtifactIndex(artifacts)
+}
+
+// lookupArtifacts は呼び出し元が mu を保持していることを前提とする。
+// 該当がなくても nil ではなく空のスライスを返す。
+func (repo *InMemoryArtifactRepository) lookupArtifacts(ids artifactIDSet, match func(*entity.Artifact) bool) []*entity.Artifact {
+	result := make([]*entity.Artifact, 0, len(ids))
+	for id := range ids {
+		if artifact := repo.Artifacts[id]; match(artifact) {
+			result = append(result, artifact)
+		}
+	}
+	return result
+}
+
+// candidateArtifacts は呼び出し元が mu を保持していることを前提とする。
+// 索引で引ける条件があれば最も候補の少ない索引の聖遺物を、なければすべての聖遺物を返す。
+func (repo *InMemoryArtifactRepository) candidateArtifacts(query ArtifactQuery) []*entity.Artifact {
+	if ids, ok := repo.indexes().candidates(query); ok {
+		return repo.lookupArtifacts(ids, matchAll)
+	}
+	artifacts := make([]*entity.Artifact, 0, len(repo.Artifacts))
+	for _, artifact := range repo.Artifacts {
+		artifacts = append(artifacts, artifact)
+	}
+	return artifacts
+}
+
+// duplicateCandidates は呼び出し元が mu を保持していることを前提とする。
+func (repo *InMemoryArtifactRepository) duplicateCandidates(query ArtifactQuery) ([]*entity.Artifact, error) {
+	return repo.candidateArtifacts(query), nil
+}
+
+// equipmentState は呼び出し元が mu を保持していることを前提とする。
+func (repo *InMemoryArtifactRepository) equipmentState() equipmentState {
+	return equipmentState{
+		current: func(id string) (*entity.Artifact, error) {
+			return repo.Artifacts[id], nil
+		},
+		equipped: func(character entity.CharacterKey) ([]*entity.Artifact, error) {
+			return repo.lookupArtifacts(repo.indexes().byEquippedBy[character], matchAll), nil
+		},
 	}
 }
 
@@ -30,6 +198,9 @@ func (repo *InMemoryArtifactRepository) GetArtifactByID(id string) (*entity.Arti
 		return nil, ErrArtifactIDIsEmpty
 	}
 
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
 	artifact, exists := repo.Artifacts[id]
 	if !exists {
 		return nil, ErrArtifactNotFound
@@ -38,50 +209,52 @@ func (repo *InMemoryArtifactRepository) GetArtifactByID(id string) (*entity.Arti
 }
 
 func (repo *InMemoryArtifactRepository) GetArtifactByTypeAndSet(artifactType entity.ArtifactType, artifactSet entity.ArtifactSet) ([]*entity.Artifact, error) {
-	var result []*entity.Artifact
-	for _, artifact := range repo.Artifacts {
-		if artifact.Type == artifactType && artifact.ArtifactSet == artifactSet {
-			result = append(result, artifact)
-		}
-	}
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
 
-	if len(result) == 0 {
-		return nil, ErrArtifactNotFound
+	// 部位とセットのうち件数の少ない方の索引から引き、もう一方で絞り込む
+	index := repo.indexes()
+	ids, other := index.byType[artifactType], index.bySet[artifactSet]
+	if len(other) < len(ids) {
+		ids, other = other, ids
 	}
+	result := repo.lookupArtifacts(ids, func(artifact *entity.Artifact) bool {
+		_, ok := other[artifact.ID]
+		return ok
+	})
 
 	return result, nil
 }
 
 func (repo *InMemoryArtifactRepository) GetArtifactByType(artifactType entity.ArtifactType) ([]*entity.Artifact, error) {
-	var result []*entity.Artifact
-	for _, artifact := range repo.Artifacts {
-		if artifact.Type == artifactType {
-			result = append(result, artifact)
-		}
-	}
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
 
-	if len(result) == 0 {
-		return nil, ErrArtifactNotFound
-	}
+	result := repo.lookupArtifacts(repo.indexes().byType[artifactType], matchAll)
 
 	return result, nil
 }
 
 func (repo *InMemoryArtifactRepository) GetArtifactBySet(artifactSet entity.ArtifactSet) ([]*entity.Artifact, error) {
-	var result []*entity.Artifact
-	for _, artifact := range repo.Artifacts {
-		if artifact.ArtifactSet == artifactSet {
-			result = append(result, artifact)
-		}
-	}
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
 
-	if len(result) == 0 {
-		return nil, ErrArtifactNotFound
-	}
+	result := repo.lookupArtifacts(repo.indexes().bySet[artifactSet], matchAll)
 
 	return result, nil
 }
 
+// QueryArtifacts は検索条件に合う聖遺物を並べ替えてページングした結果を返す。
+// 該当がなくてもエラーにはせず、空の結果を返す。
+// 索引で引ける条件があれば、最も候補の少ない索引から絞り込みを始める。
+func (repo *InMemoryArtifactRepository) QueryArtifacts(query ArtifactQuery) (*ArtifactQueryResult, error) {
+	repo.mu.RLock()
+	artifacts := repo.candidateArtifacts(query)
+	repo.mu.RUnlock()
+
+	return queryArtifacts(artifacts, query)
+}
+
 func (repo *InMemoryArtifactRepository) SaveArtifact(artifact *entity.Artifact) error {
 	if artifact == nil {
 		return ErrArtifactIsNil
@@ -91,24 +264,243 @@ func (repo *InMemoryArtifactRepository) SaveArtifact(artifact *entity.Artifact)
 		return ErrArtifactIDIsEmpty
 	}
 
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
 	if _, exists := repo.Artifacts[artifact.ID]; exists {
 		return ErrArtifactAlreadyExists
 	}
+	if err := checkEquipmentSlots(repo.equipmentState(), []*entity.Artifact{artifact}); err != nil {
+		return err
+	}
+
+	if repo.wal != nil {
+		if err := repo.wal.append(walEntry{Op: walOperationSave, Artifact: artifact}); err != nil {
+			return err
+		}
+	}
 
 	repo.Artifacts[artifact.ID] = artifact
+	repo.indexes().add(artifact)
+	return nil
+}
+
+// SaveArtifacts はすべての聖遺物を検証してから保存し、1 件でも保存できなければどれも保存しない。
+func (repo *InMemoryArtifactRepository) SaveArtifacts(artifacts []*entity.Artifact, options ArtifactSaveOptions) error {
+	if err := validateArtifactBatch(artifacts, ErrArtifactAlreadyExists); err != nil {
+		return err
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for _, artifact := range artifacts {
+		if _, exists := repo.Artifacts[artifact.ID]; exists {
+			return fmt.Errorf("%w: %s", ErrArtifactAlreadyExists, artifact.ID)
+		}
+	}
+	if err := checkEquipmentSlots(repo.equipmentState(), artifacts); err != nil {
+		return err
+	}
+	if options.RejectDuplicates {
+		if err := checkDuplicates(repo.duplicateCandidates, artifacts); err != nil {
+			return err
+		}
+	}
+
+	if repo.wal != nil && len(artifacts) > 0 {
+		if err := repo.wal.append(walEntry{Op: walOperationSaveBatch, Artifacts: artifacts}); err != nil {
+			return err
+		}
+	}
+
+	index := repo.indexes()
+	for _, artifact := range artifacts {
+		repo.Artifacts[artifact.ID] = artifact
+		index.add(artifact)
+	}
 	return nil
 }
 
+// SaveEachArtifact は保存できる聖遺物だけを 1 回の WAL の追記で保存し、保存できなかった理由を artifacts と同じ順で返す。
+func (repo *InMemoryArtifactRepository) SaveEachArtifact(artifacts []*entity.Artifact, options ArtifactSaveOptions) ([]error, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	exists := func(id string) bool {
+		_, exists := repo.Artifacts[id]
+		return exists
+	}
+	savable, errs, err := savableArtifacts(artifacts, options, exists, repo.equipmentState(), repo.duplicateCandidates)
+	if err != nil {
+		return nil, err
+	}
+
+	if repo.wal != nil && len(savable) > 0 {
+		if err := repo.wal.append(walEntry{Op: walOperationSaveBatch, Artifacts: savable}); err != nil {
+			return nil, err
+		}
+	}
+
+	index := repo.indexes()
+	for _, artifact := range savable {
+		repo.Artifacts[artifact.ID] = artifact
+		index.add(artifact)
+	}
+	return errs, nil
+}
+
+func (repo *InMemoryArtifactRepository) UpdateArtifact(artifact *entity.Artifact) error {
+	if artifact == nil {
+		return ErrArtifactIsNil
+	}
+
+	if artifact.ID == "" {
+		return ErrArtifactIDIsEmpty
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	current, exists := repo.Artifacts[artifact.ID]
+	if !exists {
+		return ErrArtifactNotFound
+	}
+	if err := checkEquipmentSlots(repo.equipmentState(), []*entity.Artifact{artifact}); err != nil {
+		return err
+	}
+
+	if repo.wal != nil {
+		if err := repo.wal.append(walEntry{Op: walOperationUpdate, Artifact: artifact}); err != nil {
+			return err
+		}
+	}
+
+	// 取得済みのポインタを持つ読み手に影響しないよう、値を書き換えずに差し替える
+	index := repo.indexes()
+	index.remove(current)
+	repo.Artifacts[artifact.ID] = artifact
+	index.add(artifact)
+	return nil
+}
+
+// UpdateArtifacts はすべての聖遺物が存在することを確かめてから更新し、1 件でも更新できなければどれも更新しない。
+func (repo *InMemoryArtifactRepository) UpdateArtifacts(artifacts []*entity.Artifact) error {
+	if err := validateArtifactBatch(artifacts, ErrArtifactIDDuplicated); err != nil {
+		return err
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for _, artifact := range artifacts {
+		if _, exists := repo.Artifacts[artifact.ID]; !exists {
+			return fmt.Errorf("%w: %s", ErrArtifactNotFound, artifact.ID)
+		}
+	}
+	return repo.updateArtifacts(artifacts)
+}
+
+// updateArtifacts は呼び出し元が mu の書き込みロックを保持し、すべての聖遺物が存在することを確かめていることを前提とする。
+func (repo *InMemoryArtifactRepository) updateArtifacts(artifacts []*entity.Artifact) error {
+	if len(artifacts) == 0 {
+		return nil
+	}
+	if err := checkEquipmentSlots(repo.equipmentState(), artifacts); err != nil {
+		return err
+	}
+
+	if repo.wal != nil {
+		if err := repo.wal.append(walEntry{Op: walOperationUpdateBatch, Artifacts: artifacts}); err != nil {
+			return err
+		}
+	}
+
+	index := repo.indexes()
+	for _, artifact := range artifacts {
+		index.remove(repo.Artifacts[artifact.ID])
+		repo.Artifacts[artifact.ID] = artifact
+		index.add(artifact)
+	}
+	return nil
+}
+
+func (repo *InMemoryArtifactRepository) ModifyArtifact(id string, modify func(current *entity.Artifact) (*entity.Artifact, error)) (*entity.Artifact, error) {
+	if id == "" {
+		return nil, ErrArtifactIDIsEmpty
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	current, exists := repo.Artifacts[id]
+	if !exists {
+		return nil, ErrArtifactNotFound
+	}
+	artifact, err := modify(current)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateModifiedArtifact(id, artifact); err != nil {
+		return nil, err
+	}
+	if err := repo.updateArtifacts([]*entity.Artifact{artifact}); err != nil {
+		return nil, err
+	}
+	return artifact, nil
+}
+
+func (repo *InMemoryArtifactRepository) EquipArtifact(id string, character entity.CharacterKey) error {
+	if id == "" {
+		return ErrArtifactIDIsEmpty
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	artifact, exists := repo.Artifacts[id]
+	if !exists {
+		return ErrArtifactNotFound
+	}
+	updates, err := equipArtifactUpdates(repo.equipmentState(), artifact, character)
+	if err != nil {
+		return err
+	}
+	return repo.updateArtifacts(updates)
+}
+
+func (repo *InMemoryArtifactRepository) UnequipArtifact(character entity.CharacterKey, artifactType entity.ArtifactType) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	updates, err := unequipArtifactUpdates(repo.equipmentState(), character, artifactType)
+	if err != nil {
+		return err
+	}
+	return repo.updateArtifacts(updates)
+}
+
 func (repo *InMemoryArtifactRepository) DeleteArtifactByID(id string) error {
 	if id == "" {
 		return ErrArtifactIDIsEmpty
 	}
 
-	if _, exists := repo.Artifacts[id]; !exists {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	artifact, exists := repo.Artifacts[id]
+	if !exists {
 		return ErrArtifactNotFound
 	}
 
+	if repo.wal != nil {
+		if err := repo.wal.append(walEntry{Op: walOperationDelete, ID: id}); err != nil {
+			return err
+		}
+	}
+
 	delete(repo.Artifacts, id)
+	repo.indexes().remove(artifact)
 	return nil
 }
 
@@ -117,6 +509,15 @@ type artifacts struct {
 }
 
 func (repo *InMemoryArtifactRepository) SaveJSONFile(filename string) error {
+	// マーシャル中に map が書き換えられないよう、読み取りロックを保持する
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	return repo.writeJSONFile(filename)
+}
+
+// writeJSONFile は呼び出し元が mu を保持していることを前提とする。
+func (repo *InMemoryArtifactRepository) writeJSONFile(filename string) error {
 	var ArtifactData artifacts
 	ArtifactData.Artifacts = repo.Artifacts
 
@@ -125,25 +526,154 @@ func (repo *InMemoryArtifactRepository) SaveJSONFile(filename string) error {
 		return err
 	}
 
-	err = os.WriteFile(filename, artifactBytes, 0644)
+	if err := rotateBackups(filename, repo.BackupCount, time.Now()); err != nil {
+		return err
+	}
+
+	return writeFileAtomic(filename, artifactBytes, 0644)
+}
+
+func (repo *InMemoryArtifactRepository) LoadJSONFile(filename string) error {
+	loaded, err := readJSONFile(filename)
 	if err != nil {
 		return err
 	}
 
+	repo.mu.Lock()
+	repo.replaceArtifacts(loaded)
+	repo.mu.Unlock()
 	return nil
 }
 
-func (repo *InMemoryArtifactRepository) LoadJSONFile(filename string) error {
+// RestoreJSONFile はスナップショットを読み込み、壊れていれば新しいバックアップから順に
+// 読み込める最初のものを採用する。実際に読み込んだファイルのパスを返す。
+// スナップショットもバックアップも存在しない場合は os.ErrNotExist を返す。
+func (repo *InMemoryArtifactRepository) RestoreJSONFile(filename string) (string, error) {
+	backups, err := listBackups(filename)
+	if err != nil {
+		return "", err
+	}
+
+	candidates := append([]string{filename}, backups...)
+	var errs []error
+	for _, candidate := range candidates {
+		loaded, err := readJSONFile(candidate)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				errs = append(errs, fmt.Errorf("%s: %w", candidate, err))
+			}
+			continue
+		}
+
+		repo.mu.Lock()
+		repo.replaceArtifacts(loaded)
+		repo.mu.Unlock()
+		return candidate, nil
+	}
+
+	if len(errs) == 0 {
+		return "", os.ErrNotExist
+	}
+	return "", fmt.Errorf("%w: %w", ErrNoValidSnapshot, errors.Join(errs...))
+}
+
+func readJSONFile(filename string) (map[string]*entity.Artifact, error) {
 	file, err := os.ReadFile(filename)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	var ArtifactData artifacts
 	err = json.Unmarshal(file, &ArtifactData)
+	if err != nil {
+		return nil, err
+	}
+
+	if ArtifactData.Artifacts == nil {
+		ArtifactData.Artifacts = make(map[string]*entity.Artifact)
+	}
+	migrateArtifacts(ArtifactData.Artifacts)
+	return ArtifactData.Artifacts, nil
+}
+
+// OpenWAL は先行書き込みログを開き、既存のエントリを現在の内容へ再適用する。
+// 以降の SaveArtifact / DeleteArtifactByID はメモリへ反映する前にログへ追記される。
+// LoadJSONFile でスナップショットを読み込んだ後に呼び出すこと。
+func (repo *InMemoryArtifactRepository) OpenWAL(filename string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if repo.wal != nil {
+		return ErrWALAlreadyOpened
+	}
+
+	wal, err := openWriteAheadLog(filename)
 	if err != nil {
 		return err
 	}
-	repo.Artifacts = ArtifactData.Artifacts
+
+	if repo.Artifacts == nil {
+		repo.Artifacts = make(map[string]*entity.Artifact)
+	}
+
+	// スナップショット書き出し後、ログ切り詰め前にクラッシュした場合も
+	// 同じ結果になるよう、再適用は上書き・存在しなければ無視で行う
+	err = wal.replay(func(entry walEntry) {
+		switch entry.Op {
+		case walOperationSave, walOperationUpdate:
+			if entry.Artifact != nil {
+				migrateArtifact(entry.Artifact)
+				repo.Artifacts[entry.Artifact.ID] = entry.Artifact
+			}
+		case walOperationSaveBatch, walOperationUpdateBatch:
+			for _, artifact := range entry.Artifacts {
+				if artifact != nil {
+					migrateArtifact(artifact)
+					repo.Artifacts[artifact.ID] = artifact
+				}
+			}
+		case walOperationDelete:
+			delete(repo.Artifacts, entry.ID)
+		}
+	})
+	if err != nil {
+		_ = wal.close()
+		return err
+	}
+	repo.replaceArtifacts(repo.Artifacts)
+
+	repo.wal = wal
 	return nil
 }
+
+// Compact は現在の内容をスナップショットへ書き出し、先行書き込みログを空にする。
+func (repo *InMemoryArtifactRepository) Compact(filename string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if err := repo.writeJSONFile(filename); err != nil {
+		return err
+	}
+
+	if repo.wal == nil {
+		return nil
+	}
+	return repo.wal.truncate()
+}
+
+func (repo *InMemoryArtifactRepository) CloseWAL() error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if repo.wal == nil {
+		return nil
+	}
+
+	err := repo.wal.close()
+	repo.wal = nil
+	return err
+}
+
+func matchAll(*entity.Artifact) bool {
+	return true
+}
@@ -2,9 +2,15 @@ package repository
 
 import (
 	"errors"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"sync"
 	"testing"
 
 	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
+	"github.com/google/go-cmp/cmp"
 )
 
 func TestInMemoryArtifactRepositoryGetArtifactByID(t *testing.T) {
@@ -134,7 +140,7 @@ func TestInMemoryArtifactRepositoryGetArtifactByTypeAndSet(t *testing.T) {
 			expectedError:              false,
 		},
 		{
-			name: "ShouldInMemoryArtifactRepositoryReturnErrorWhenNoArtifactsFound",
+			name: "ShouldInMemoryArtifactRepositoryReturnEmptySliceWhenNoArtifactsFound",
 
 			mockArtifacts: map[string]*entity.Artifact{
 				"test-id-1": {
@@ -148,7 +154,7 @@ func TestInMemoryArtifactRepositoryGetArtifactByTypeAndSet(t *testing.T) {
 			artifactSet:  entity.ARTIFACT_SET_GLADIATORS_FINALOFFERING,
 
 			expectedGotArtifactsLength: 0,
-			expectedError:              true,
+			expectedError:              false,
 		},
 	}
 
@@ -159,6 +165,9 @@ func TestInMemoryArtifactRepositoryGetArtifactByTypeAndSet(t *testing.T) {
 			}
 
 			result, err := repo.GetArtifactByTypeAndSet(tt.artifactType, tt.artifactSet)
+			if result == nil && err == nil {
+				t.Errorf("expected empty slice, got nil")
+			}
 			if len(result) != tt.expectedGotArtifactsLength {
 				t.Errorf("expected %d artifacts, got %d", tt.expectedGotArtifactsLength, len(result))
 			}
@@ -220,7 +229,7 @@ func TestInMemoryArtifactRepositoryGetArtifactByType(t *testing.T) {
 			expectedError:              false,
 		},
 		{
-			name: "ShouldInMemoryArtifactRepositoryReturnErrorWhenNoArtifactsFound",
+			name: "ShouldInMemoryArtifactRepositoryReturnEmptySliceWhenNoArtifactsFound",
 
 			mockArtifacts: map[string]*entity.Artifact{
 				"test-id-1": {
@@ -232,7 +241,7 @@ func TestInMemoryArtifactRepositoryGetArtifactByType(t *testing.T) {
 			artifactType: entity.ARTIFACT_TYPE_FLOWER,
 
 			expectedGotArtifactsLength: 0,
-			expectedError:              true,
+			expectedError:              false,
 		},
 	}
 
@@ -243,6 +252,9 @@ func TestInMemoryArtifactRepositoryGetArtifactByType(t *testing.T) {
 			}
 
 			result, err := repo.GetArtifactByType(tt.artifactType)
+			if result == nil && err == nil {
+				t.Errorf("expected empty slice, got nil")
+			}
 			if len(result) != tt.expectedGotArtifactsLength {
 				t.Errorf("expected %d artifacts, got %d", tt.expectedGotArtifactsLength, len(result))
 			}
@@ -304,7 +316,7 @@ func TestInMemoryArtifactRepositoryGetArtifactBySet(t *testing.T) {
 			expectedError:              false,
 		},
 		{
-			name: "ShouldInMemoryArtifactRepositoryReturnErrorWhenNoArtifactsFound",
+			name: "ShouldInMemoryArtifactRepositoryReturnEmptySliceWhenNoArtifactsFound",
 
 			mockArtifacts: map[string]*entity.Artifact{
 				"test-id-1": {
@@ -316,7 +328,7 @@ func TestInMemoryArtifactRepositoryGetArtifactBySet(t *testing.T) {
 			artifactSet: entity.ARTIFACT_SET_GLADIATORS_FINALOFFERING,
 
 			expectedGotArtifactsLength: 0,
-			expectedError:              true,
+			expectedError:              false,
 		},
 	}
 
@@ -326,6 +338,9 @@ func TestInMemoryArtifactRepositoryGetArtifactBySet(t *testing.T) {
 				Artifacts: tt.mockArtifacts,
 			}
 			result, err := repo.GetArtifactBySet(tt.artifactSet)
+			if result == nil && err == nil {
+				t.Errorf("expected empty slice, got nil")
+			}
 			if len(result) != tt.expectedGotArtifactsLength {
 				t.Errorf("expected %d artifacts, got %d", tt.expectedGotArtifactsLength, len(result))
 			}
@@ -409,6 +424,167 @@ func TestInMemoryArtifactRepositorySaveArtifact(t *testing.T) {
 	}
 }
 
+func TestInMemoryArtifactRepositorySaveArtifacts(t *testing.T) {
+	tests := []struct {
+		name string
+
+		mockArtifacts map[string]*entity.Artifact
+
+		artifacts []*entity.Artifact
+
+		expectedError error
+		expectedIDs   []string
+	}{
+		{
+			name: "ShouldInMemoryArtifactRepositorySaveArtifactsSuccessfully",
+
+			mockArtifacts: map[string]*entity.Artifact{
+				"existing-id": {ID: "existing-id"},
+			},
+
+			artifacts: []*entity.Artifact{{ID: "new-id-1"}, {ID: "new-id-2"}},
+
+			expectedError: nil,
+			expectedIDs:   []string{"existing-id", "new-id-1", "new-id-2"},
+		},
+		{
+			name: "ShouldInMemoryArtifactRepositorySaveNothingWhenAnyArtifactIDAlreadyExists",
+
+			mockArtifacts: map[string]*entity.Artifact{
+				"existing-id": {ID: "existing-id"},
+			},
+
+			artifacts: []*entity.Artifact{{ID: "new-id"}, {ID: "existing-id"}},
+
+			expectedError: ErrArtifactAlreadyExists,
+			expectedIDs:   []string{"existing-id"},
+		},
+		{
+			name: "ShouldInMemoryArtifactRepositorySaveNothingWhenArtifactIDsAreDuplicated",
+
+			mockArtifacts: map[string]*entity.Artifact{},
+
+			artifacts: []*entity.Artifact{{ID: "new-id"}, {ID: "new-id"}},
+
+			expectedError: ErrArtifactAlreadyExists,
+			expectedIDs:   nil,
+		},
+		{
+			name: "ShouldInMemoryArtifactRepositorySaveNothingWhenArtifactIsNil",
+
+			mockArtifacts: map[string]*entity.Artifact{},
+
+			artifacts: []*entity.Artifact{{ID: "new-id"}, nil},
+
+			expectedError: ErrArtifactIsNil,
+			expectedIDs:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := InMemoryArtifactRepository{
+				Artifacts: tt.mockArtifacts,
+			}
+
+			err := repo.SaveArtifacts(tt.artifacts, ArtifactSaveOptions{})
+
+			if !errors.Is(err, tt.expectedError) {
+				t.Errorf("expected error: %v, got: %v", tt.expectedError, err)
+			}
+
+			ids := slices.Sorted(maps.Keys(repo.Artifacts))
+			if diff := cmp.Diff(tt.expectedIDs, ids); diff != "" {
+				t.Errorf("saved IDs mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInMemoryArtifactRepositoryUpdateArtifact(t *testing.T) {
+	tests := []struct {
+		name string
+
+		mockArtifacts map[string]*entity.Artifact
+
+		artifact *entity.Artifact
+
+		expectedLevel int
+		expectedError error
+	}{
+		{
+			name: "ShouldInMemoryArtifactRepositoryUpdateArtifactSuccessfully",
+
+			mockArtifacts: map[string]*entity.Artifact{
+				"existing-id": {
+					ID:    "existing-id",
+					Level: 0,
+				},
+			},
+
+			artifact: &entity.Artifact{
+				ID:    "existing-id",
+				Level: 20,
+			},
+
+			expectedLevel: 20,
+			expectedError: nil,
+		},
+		{
+			name: "ShouldInMemoryArtifactRepositoryReturnErrorWhenArtifactNotFound",
+
+			mockArtifacts: map[string]*entity.Artifact{},
+
+			artifact: &entity.Artifact{
+				ID: "non-existent-id",
+			},
+
+			expectedError: ErrArtifactNotFound,
+		},
+		{
+			name: "ShouldInMemoryArtifactRepositoryReturnErrorWhenArtifactIsNil",
+
+			mockArtifacts: map[string]*entity.Artifact{},
+
+			artifact: nil,
+
+			expectedError: ErrArtifactIsNil,
+		},
+		{
+			name: "ShouldInMemoryArtifactRepositoryReturnErrorWhenArtifactIDIsEmpty",
+
+			mockArtifacts: map[string]*entity.Artifact{},
+
+			artifact: &entity.Artifact{
+				ID: "",
+			},
+
+			expectedError: ErrArtifactIDIsEmpty,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := InMemoryArtifactRepository{
+				Artifacts: tt.mockArtifacts,
+			}
+
+			err := repo.UpdateArtifact(tt.artifact)
+
+			if !errors.Is(err, tt.expectedError) {
+				t.Errorf("expected error: %v, got: %v", tt.expectedError, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if repo.Artifacts[tt.artifact.ID].Level != tt.expectedLevel {
+				t.Errorf("expected level %d, got %d", tt.expectedLevel, repo.Artifacts[tt.artifact.ID].Level)
+			}
+		})
+	}
+}
+
 func TestInMemoryArtifactRepositoryDeleteArtifactByID(t *testing.T) {
 	tests := []struct {
 		name string
@@ -461,3 +637,151 @@ func TestInMemoryArtifactRepositoryDeleteArtifactByID(t *testing.T) {
 		})
 	}
 }
+
+func TestInMemoryArtifactRepositoryConcurrentSaveAndGet(t *testing.T) {
+	const writers = 8
+	const artifactsPerWriter = 200
+
+	repo := NewInMemoryArtifactRepository()
+
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < artifactsPerWriter; i++ {
+				artifact := &entity.Artifact{
+					ID:          fmt.Sprintf("writer-%d-%d", w, i),
+					ArtifactSet: entity.ARTIFACT_SET_GLADIATORS_FINALOFFERING,
+					Type:        entity.ARTIFACT_TYPE_FLOWER,
+				}
+				if err := repo.SaveArtifact(artifact); err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			}
+		}(w)
+	}
+
+	for r := 0; r < writers; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < artifactsPerWriter; i++ {
+				_, _ = repo.GetArtifactByID(fmt.Sprintf("writer-0-%d", i))
+				_, _ = repo.GetArtifactByType(entity.ARTIFACT_TYPE_FLOWER)
+				_, _ = repo.GetArtifactBySet(entity.ARTIFACT_SET_GLADIATORS_FINALOFFERING)
+				_, _ = repo.GetArtifactByTypeAndSet(entity.ARTIFACT_TYPE_FLOWER, entity.ARTIFACT_SET_GLADIATORS_FINALOFFERING)
+			}
+		}()
+	}
+	wg.Wait()
+
+	result, err := repo.GetArtifactByType(entity.ARTIFACT_TYPE_FLOWER)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result) != writers*artifactsPerWriter {
+		t.Errorf("expected %d artifacts, got %d", writers*artifactsPerWriter, len(result))
+	}
+}
+
+func TestInMemoryArtifactRepositoryConcurrentSaveSameID(t *testing.T) {
+	const goroutines = 32
+
+	repo := NewInMemoryArtifactRepository()
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	succeeded := 0
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := repo.SaveArtifact(&entity.Artifact{ID: "same-id"})
+			if err == nil {
+				mu.Lock()
+				succeeded++
+				mu.Unlock()
+				return
+			}
+			if !errors.Is(err, ErrArtifactAlreadyExists) {
+				t.Errorf("expected error: %v, got: %v", ErrArtifactAlreadyExists, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if succeeded != 1 {
+		t.Errorf("expected exactly 1 successful save, got %d", succeeded)
+	}
+}
+
+func TestInMemoryArtifactRepositoryConcurrentDelete(t *testing.T) {
+	const count = 500
+
+	repo := NewInMemoryArtifactRepository()
+	for i := 0; i < count; i++ {
+		if err := repo.SaveArtifact(&entity.Artifact{ID: fmt.Sprintf("test-id-%d", i), Type: entity.ARTIFACT_TYPE_FLOWER}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < count; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			if err := repo.DeleteArtifactByID(fmt.Sprintf("test-id-%d", i)); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			_, _ = repo.GetArtifactByType(entity.ARTIFACT_TYPE_FLOWER)
+		}()
+	}
+	wg.Wait()
+
+	if artifacts, err := repo.GetArtifactByType(entity.ARTIFACT_TYPE_FLOWER); err != nil || len(artifacts) != 0 {
+		t.Errorf("expected no artifacts, got: %d, error: %v", len(artifacts), err)
+	}
+}
+
+func TestInMemoryArtifactRepositoryConcurrentSaveJSONFile(t *testing.T) {
+	const count = 300
+
+	repo := NewInMemoryArtifactRepository()
+	filename := filepath.Join(t.TempDir(), "artifacts.json")
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < count; i++ {
+			if err := repo.SaveArtifact(&entity.Artifact{ID: fmt.Sprintf("test-id-%d", i)}); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 20; i++ {
+			if err := repo.SaveJSONFile(filename); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}
+	}()
+	wg.Wait()
+
+	if err := repo.SaveJSONFile(filename); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	loaded := NewInMemoryArtifactRepository()
+	if err := loaded.LoadJSONFile(filename); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(loaded.Artifacts) != count {
+		t.Errorf("expected %d artifacts, got %d", count, len(loaded.Artifacts))
+	}
+}
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
+)
+
+var (
+	ErrScoreProfileNotFound = errors.New("score profile not found")
+	ErrScoreProfileIsNil    = errors.New("score profile is nil")
+)
+
+// InMemoryScoreProfileRepository は採点用の重みプロファイルを保持する。
+// OpenJSONFile でファイルを指定した場合、変更のたびにファイル全体を書き出す。
+// プロファイルは件数が少なく更新頻度も低いため、先行書き込みログは使わない。
+type InMemoryScoreProfileRepository struct {
+	mu       sync.RWMutex
+	Profiles map[string]*entity.ScoreProfile
+
+	filename string
+}
+
+func NewInMemoryScoreProfileRepository() *InMemoryScoreProfileRepository {
+	return &InMemoryScoreProfileRepository{
+		Profiles: make(map[string]*entity.ScoreProfile),
+	}
+}
+
+func (repo *InMemoryScoreProfileRepository) GetScoreProfile(name string) (*entity.ScoreProfile, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	profile, exists := repo.Profiles[name]
+	if !exists {
+		return nil, ErrScoreProfileNotFound
+	}
+	return profile, nil
+}
+
+// GetScoreProfiles は名前順に並べたプロファイルを返す。
+func (repo *InMemoryScoreProfileRepository) GetScoreProfiles() ([]*entity.ScoreProfile, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	result := make([]*entity.ScoreProfile, 0, len(repo.Profiles))
+	for _, profile := range repo.Profiles {
+		result = append(result, profile)
+	}
+	slices.SortFunc(result, func(a, b *entity.ScoreProfile) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return result, nil
+}
+
+// SaveScoreProfile は同名のプロファイルがあれば置き換える。
+func (repo *InMemoryScoreProfileRepository) SaveScoreProfile(profile *entity.ScoreProfile) error {
+	if profile == nil {
+		return ErrScoreProfileIsNil
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	previous, existed := repo.Profiles[profile.Name]
+	repo.Profiles[profile.Name] = profile
+	if err := repo.persist(); err != nil {
+		if existed {
+			repo.Profiles[profile.Name] = previous
+		} else {
+			delete(repo.Profiles, profile.Name)
+		}
+		return err
+	}
+	return nil
+}
+
+func (repo *InMemoryScoreProfileRepository) DeleteScoreProfile(name string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	previous, exists := repo.Profiles[name]
+	if !exists {
+		return ErrScoreProfileNotFound
+	}
+
+	delete(repo.Profiles, name)
+	if err := repo.persist(); err != nil {
+		repo.Profiles[name] = previous
+		return err
+	}
+	return nil
+}
+
+type scoreProfiles struct {
+	Profiles map[string]*entity.ScoreProfile `json:"profiles"`
+}
+
+// OpenJSONFile はファイルからプロファイルを読み込み、以降の変更の書き出し先とする。
+// ファイルが存在しない場合は空の状態から始める。
+func (repo *InMemoryScoreProfileRepository) OpenJSONFile(filename string) error {
+	loaded := make(map[string]*entity.ScoreProfile)
+
+	file, err := os.ReadFile(filename)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err == nil {
+		var profileData scoreProfiles
+		if err := json.Unmarshal(file, &profileData); err != nil {
+			return err
+		}
+		if profileData.Profiles != nil {
+			loaded = profileData.Profiles
+		}
+	}
+
+	repo.mu.Lock()
+	repo.Profiles = loaded
+	repo.filename = filename
+	repo.mu.Unlock()
+	return nil
+}
+
+// persist は呼び出し元が mu を保持していることを前提とする。
+func (repo *InMemoryScoreProfileRepository) persist() error {
+	if repo.filename == "" {
+		return nil
+	}
+
+	profileBytes, err := json.Marshal(scoreProfiles{Profiles: repo.Profiles})
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(repo.filename, profileBytes, 0644)
+}
@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestInMemoryScoreProfileRepositoryGetScoreProfiles(t *testing.T) {
+	repo := NewInMemoryScoreProfileRepository()
+	for _, name := range []string{"Raiden", "HuTao", "Nahida"} {
+		if err := repo.SaveScoreProfile(&entity.ScoreProfile{Name: name}); err != nil {
+			t.Fatalf("SaveScoreProfile() error = %v", err)
+		}
+	}
+
+	profiles, err := repo.GetScoreProfiles()
+	if err != nil {
+		t.Fatalf("GetScoreProfiles() error = %v", err)
+	}
+
+	var names []string
+	for _, profile := range profiles {
+		names = append(names, profile.Name)
+	}
+	if diff := cmp.Diff([]string{"HuTao", "Nahida", "Raiden"}, names); diff != "" {
+		t.Errorf("GetScoreProfiles() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestInMemoryScoreProfileRepositorySaveScoreProfile(t *testing.T) {
+	tests := []struct {
+		name string
+
+		mockProfiles map[string]*entity.ScoreProfile
+
+		profile *entity.ScoreProfile
+
+		expectedProfiles map[string]*entity.ScoreProfile
+		expectedError    error
+	}{
+		{
+			name: "ShouldSaveScoreProfileSuccessfully",
+
+			mockProfiles: map[string]*entity.ScoreProfile{},
+
+			profile: &entity.ScoreProfile{
+				Name:    "HuTao",
+				Weights: map[entity.SubstatType]float64{entity.SUBSTAT_CRIT_DMG: 1},
+			},
+
+			expectedProfiles: map[string]*entity.ScoreProfile{
+				"HuTao": {
+					Name:    "HuTao",
+					Weights: map[entity.SubstatType]float64{entity.SUBSTAT_CRIT_DMG: 1},
+				},
+			},
+		},
+		{
+			name: "ShouldReplaceScoreProfileWithSameName",
+
+			mockProfiles: map[string]*entity.ScoreProfile{
+				"HuTao": {
+					Name:    "HuTao",
+					Weights: map[entity.SubstatType]float64{entity.SUBSTAT_CRIT_DMG: 1},
+				},
+			},
+
+			profile: &entity.ScoreProfile{
+				Name:    "HuTao",
+				Weights: map[entity.SubstatType]float64{entity.SUBSTAT_HP_PERCENT: 0.8},
+			},
+
+			expectedProfiles: map[string]*entity.ScoreProfile{
+				"HuTao": {
+					Name:    "HuTao",
+					Weights: map[entity.SubstatType]float64{entity.SUBSTAT_HP_PERCENT: 0.8},
+				},
+			},
+		},
+		{
+			name: "ShouldReturnErrorWhenScoreProfileIsNil",
+
+			mockProfiles: map[string]*entity.ScoreProfile{},
+
+			expectedProfiles: map[string]*entity.ScoreProfile{},
+			expectedError:    ErrScoreProfileIsNil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := InMemoryScoreProfileRepository{
+				Profiles: tt.mockProfiles,
+			}
+
+			err := repo.SaveScoreProfile(tt.profile)
+
+			if !errors.Is(err, tt.expectedError) {
+				t.Errorf("expected error: %v, got: %v", tt.expectedError, err)
+			}
+
+			if diff := cmp.Diff(tt.expectedProfiles, repo.Profiles); diff != "" {
+				t.Errorf("SaveScoreProfile() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInMemoryScoreProfileRepositoryDeleteScoreProfile(t *testing.T) {
+	tests := []struct {
+		name string
+
+		mockProfiles map[string]*entity.ScoreProfile
+
+		profileName string
+
+		expectedError error
+	}{
+		{
+			name: "ShouldDeleteScoreProfileSuccessfully",
+
+			mockProfiles: map[string]*entity.ScoreProfile{
+				"HuTao": {Name: "HuTao"},
+			},
+
+			profileName: "HuTao",
+		},
+		{
+			name: "ShouldReturnErrorWhenScoreProfileNotFound",
+
+			mockProfiles: map[string]*entity.ScoreProfile{
+				"HuTao": {Name: "HuTao"},
+			},
+
+			profileName: "Raiden",
+
+			expectedError: ErrScoreProfileNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := InMemoryScoreProfileRepository{
+				Profiles: tt.mockProfiles,
+			}
+
+			err := repo.DeleteScoreProfile(tt.profileName)
+
+			if !errors.Is(err, tt.expectedError) {
+				t.Errorf("expected error: %v, got: %v", tt.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestInMemoryScoreProfileRepositoryOpenJSONFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "score_profiles.json")
+	profile := &entity.ScoreProfile{
+		Name:    "HuTao",
+		Weights: map[entity.SubstatType]float64{entity.SUBSTAT_CRIT_DMG: 1, entity.SUBSTAT_HP_PERCENT: 0.8},
+	}
+
+	repo := NewInMemoryScoreProfileRepository()
+	if err := repo.OpenJSONFile(filename); err != nil {
+		t.Fatalf("OpenJSONFile() on missing file error = %v", err)
+	}
+	if err := repo.SaveScoreProfile(profile); err != nil {
+		t.Fatalf("SaveScoreProfile() error = %v", err)
+	}
+	if err := repo.SaveScoreProfile(&entity.ScoreProfile{Name: "Raiden"}); err != nil {
+		t.Fatalf("SaveScoreProfile() error = %v", err)
+	}
+	if err := repo.DeleteScoreProfile("Raiden"); err != nil {
+		t.Fatalf("DeleteScoreProfile() error = %v", err)
+	}
+
+	reopened := NewInMemoryScoreProfileRepository()
+	if err := reopened.OpenJSONFile(filename); err != nil {
+		t.Fatalf("OpenJSONFile() error = %v", err)
+	}
+	if diff := cmp.Diff(map[string]*entity.ScoreProfile{"HuTao": profile}, reopened.Profiles); diff != "" {
+		t.Errorf("OpenJSONFile() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestInMemoryScoreProfileRepositoryOpenJSONFileCorrupted(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "score_profiles.json")
+	if err := os.WriteFile(filename, []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	repo := NewInMemoryScoreProfileRepository()
+	if err := repo.OpenJSONFile(filename); err == nil {
+		t.Error("OpenJSONFile() expected error for corrupted file")
+	}
+}
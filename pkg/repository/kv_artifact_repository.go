@@ -0,0 +1,655 @@
+package repository
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// kvArtifactBucket は聖遺物本体を ID をキーとして保存するバケット。
+const kvArtifactBucket = "artifacts"
+
+// kvIndexPrefix はすべての索引バケットの名前の接頭辞。
+const kvIndexPrefix = "index/"
+
+// 二次索引は "index/<種類>/<値>" のバケットに聖遺物 ID をキーとして保存する。
+const (
+	kvIndexByType        = kvIndexPrefix + "type/"
+	kvIndexBySet         = kvIndexPrefix + "set/"
+	kvIndexByPrimaryStat = kvIndexPrefix + "primary_stat/"
+	kvIndexBySubstat     = kvIndexPrefix + "substat/"
+	// kvIndexByEquippedBy は装備している聖遺物だけを持つ。装備していない聖遺物は大半を占めるため索引にしない
+	kvIndexByEquippedBy = kvIndexPrefix + "equipped_by/"
+)
+
+// kvIndexCountBucket は索引バケットの名前をキーとして、そのバケットの聖遺物の数を保存する。
+// 索引と同じトランザクションで更新するため、件数を求めるのにバケットを走査しなくてよい。
+// 索引を作り直すときに一緒に作り直されるよう、索引と同じ接頭辞を付ける。
+const kvIndexCountBucket = kvIndexPrefix + "count"
+
+// kvMetaBucket はファイルの形式に関する情報を保存するバケット。
+const kvMetaBucket = "meta"
+
+// kvIndexVersionKey には索引の版数を保存する。索引の種類を増やした場合は kvIndexVersion を上げ、
+// 開いたファイルの版数が異なれば索引を作り直す。
+const (
+	kvIndexVersionKey = "index_version"
+	kvIndexVersion    = "3"
+)
+
+// kvOpenTimeout は同じファイルを別のプロセスが開いている場合に、ロックの解放を待つ時間。
+// サーバーの起動中に migrate-kv を実行した場合などに、待ち続けずにエラーにする。
+const kvOpenTimeout = time.Second
+
+// KVArtifactRepository は聖遺物と二次索引を組み込みのキーバリューストア (bbolt) に保存する。
+// 値はファイルから読むため、聖遺物の数が増えてもメモリに載せる必要がなく、
+// InMemoryArtifactRepository のようにスナップショット全体を書き出す必要もない。
+// 書き込みはトランザクションごとに fsync され、存在確認と書き込みを同じトランザクションで行うため不可分になる。
+// 複数の goroutine から同時に利用でき、一覧系の取得は呼び出しごとに新しい値を返す。
+type KVArtifactRepository struct {
+	db *bolt.DB
+}
+
+// OpenKVArtifactRepository はファイルを開く。ファイルがなければ空のデータベースを作成する。
+func OpenKVArtifactRepository(filename string) (*KVArtifactRepository, error) {
+	db, err := bolt.Open(filename, 0644, &bolt.Options{Timeout: kvOpenTimeout})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		if _, err := tx.CreateBucketIfNotExists([]byte(kvArtifactBucket)); err != nil {
+			return err
+		}
+		meta, err := tx.CreateBucketIfNotExists([]byte(kvMetaBucket))
+		if err != nil {
+			return err
+		}
+		if string(meta.Get([]byte(kvIndexVersionKey))) == kvIndexVersion {
+			return nil
+		}
+		if err := rebuildIndexes(tx); err != nil {
+			return err
+		}
+		return meta.Put([]byte(kvIndexVersionKey), []byte(kvIndexVersion))
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return &KVArtifactRepository{db: db}, nil
+}
+
+// kvIndexBuckets は聖遺物が登録される索引のバケットを返す。
+func kvIndexBuckets(artifact *entity.Artifact) []string {
+	buckets := []string{
+		kvIndexByType + string(artifact.Type),
+		kvIndexBySet + string(artifact.ArtifactSet),
+		kvIndexByPrimaryStat + string(artifact.PrimaryStat.Type),
+	}
+	for _, substat := range artifact.Substats {
+		buckets = append(buckets, kvIndexBySubstat+string(substat.Type))
+	}
+	if artifact.EquippedBy != "" {
+		buckets = append(buckets, kvIndexByEquippedBy+string(artifact.EquippedBy))
+	}
+	return buckets
+}
+
+// rebuildIndexes はすべての索引バケットを削除し、保存されている聖遺物から作り直す。
+func rebuildIndexes(tx *bolt.Tx) error {
+	var indexBuckets [][]byte
+	err := tx.ForEach(func(name []byte, _ *bolt.Bucket) error {
+		if bytes.HasPrefix(name, []byte(kvIndexPrefix)) {
+			indexBuckets = append(indexBuckets, bytes.Clone(name))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, name := range indexBuckets {
+		if err := tx.DeleteBucket(name); err != nil {
+			return err
+		}
+	}
+
+	artifacts, err := readAllArtifacts(tx)
+	if err != nil {
+		return err
+	}
+	for _, artifact := range artifacts {
+		if err := putIndexes(tx, artifact); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func putArtifact(tx *bolt.Tx, artifact *entity.Artifact) error {
+	artifactBytes, err := json.Marshal(artifact)
+	if err != nil {
+		return err
+	}
+	if err := tx.Bucket([]byte(kvArtifactBucket)).Put([]byte(artifact.ID), artifactBytes); err != nil {
+		return err
+	}
+	return putIndexes(tx, artifact)
+}
+
+func putIndexes(tx *bolt.Tx, artifact *entity.Artifact) error {
+	for _, bucket := range kvIndexBuckets(artifact) {
+		if kvHas(tx, bucket, artifact.ID) {
+			continue
+		}
+		index, err := tx.CreateBucketIfNotExists([]byte(bucket))
+		if err != nil {
+			return err
+		}
+		if err := index.Put([]byte(artifact.ID), []byte{}); err != nil {
+			return err
+		}
+		if err := addKVIndexCount(tx, bucket, 1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func deleteArtifact(tx *bolt.Tx, artifact *entity.Artifact) error {
+	if err := tx.Bucket([]byte(kvArtifactBucket)).Delete([]byte(artifact.ID)); err != nil {
+		return err
+	}
+	for _, bucket := range kvIndexBuckets(artifact) {
+		if !kvHas(tx, bucket, artifact.ID) {
+			continue
+		}
+		if err := tx.Bucket([]byte(bucket)).Delete([]byte(artifact.ID)); err != nil {
+			return err
+		}
+		if err := addKVIndexCount(tx, bucket, -1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addKVIndexCount は索引バケットの件数に delta を足す。件数が 0 になればキーを削除する。
+func addKVIndexCount(tx *bolt.Tx, bucket string, delta int) error {
+	counts, err := tx.CreateBucketIfNotExists([]byte(kvIndexCountBucket))
+	if err != nil {
+		return err
+	}
+	count := kvLen(tx, bucket) + delta
+	if count <= 0 {
+		return counts.Delete([]byte(bucket))
+	}
+	return counts.Put([]byte(bucket), binary.BigEndian.AppendUint64(nil, uint64(count)))
+}
+
+func readArtifact(tx *bolt.Tx, id string) (*entity.Artifact, error) {
+	artifactBytes := tx.Bucket([]byte(kvArtifactBucket)).Get([]byte(id))
+	if artifactBytes == nil {
+		return nil, ErrArtifactNotFound
+	}
+
+	var artifact entity.Artifact
+	if err := json.Unmarshal(artifactBytes, &artifact); err != nil {
+		return nil, fmt.Errorf("artifact %s: %w", id, err)
+	}
+	return &artifact, nil
+}
+
+// kvEquipmentState はトランザクション内の書き込み前の保存内容を読む。
+func kvEquipmentState(tx *bolt.Tx) equipmentState {
+	return equipmentState{
+		current: func(id string) (*entity.Artifact, error) {
+			artifact, err := readArtifact(tx, id)
+			if errors.Is(err, ErrArtifactNotFound) {
+				return nil, nil
+			}
+			return artifact, err
+		},
+		equipped: func(character entity.CharacterKey) ([]*entity.Artifact, error) {
+			return readArtifacts(tx, kvKeys(tx, kvIndexByEquippedBy+string(character)), matchAll)
+		},
+	}
+}
+
+// kvCandidateArtifacts は索引で引ける条件があれば最も候補の少ない索引の聖遺物を、なければすべての聖遺物を返す。
+func kvCandidateArtifacts(tx *bolt.Tx, query ArtifactQuery) ([]*entity.Artifact, error) {
+	if ids, ok := kvCandidates(tx, query); ok {
+		return readArtifacts(tx, ids, matchAll)
+	}
+	return readAllArtifacts(tx)
+}
+
+// readArtifacts は該当がなくても nil ではなく空のスライスを返す。
+func readArtifacts(tx *bolt.Tx, ids []string, match func(*entity.Artifact) bool) ([]*entity.Artifact, error) {
+	result := make([]*entity.Artifact, 0, len(ids))
+	for _, id := range ids {
+		artifact, err := readArtifact(tx, id)
+		if err != nil {
+			return nil, err
+		}
+		if match(artifact) {
+			result = append(result, artifact)
+		}
+	}
+	return result, nil
+}
+
+func readAllArtifacts(tx *bolt.Tx) ([]*entity.Artifact, error) {
+	result := []*entity.Artifact{}
+	err := tx.Bucket([]byte(kvArtifactBucket)).ForEach(func(id, artifactBytes []byte) error {
+		var artifact entity.Artifact
+		if err := json.Unmarshal(artifactBytes, &artifact); err != nil {
+			return fmt.Errorf("artifact %s: %w", id, err)
+		}
+		result = append(result, &artifact)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// kvKeys はバケット中のキーを昇順で返す。バケットがなければ空のスライスを返す。値は読まない。
+func kvKeys(tx *bolt.Tx, bucket string) []string {
+	keys := []string{}
+	b := tx.Bucket([]byte(bucket))
+	if b == nil {
+		return keys
+	}
+	cursor := b.Cursor()
+	for key, _ := cursor.First(); key != nil; key, _ = cursor.Next() {
+		keys = append(keys, string(key))
+	}
+	return keys
+}
+
+// kvLen は kvIndexCountBucket に保存した索引バケットの件数を返す。索引バケットは走査しない。
+func kvLen(tx *bolt.Tx, bucket string) int {
+	counts := tx.Bucket([]byte(kvIndexCountBucket))
+	if counts == nil {
+		return 0
+	}
+	count := counts.Get([]byte(bucket))
+	if len(count) != 8 {
+		return 0
+	}
+	return int(binary.BigEndian.Uint64(count))
+}
+
+// kvHas は索引のバケットにキーがあるかを返す。索引の値は空のため、Get ではなくカーソルで確かめる。
+func kvHas(tx *bolt.Tx, bucket, key string) bool {
+	b := tx.Bucket([]byte(bucket))
+	if b == nil {
+		return false
+	}
+	found, _ := b.Cursor().Seek([]byte(key))
+	return bytes.Equal(found, []byte(key))
+}
+
+// kvIndexedIDs は複数の索引バケットに含まれる ID の和集合を返す。
+func kvIndexedIDs(tx *bolt.Tx, buckets []string) []string {
+	if len(buckets) == 1 {
+		return kvKeys(tx, buckets[0])
+	}
+	seen := make(artifactIDSet)
+	var ids []string
+	for _, bucket := range buckets {
+		for _, id := range kvKeys(tx, bucket) {
+			if _, ok := seen[id]; !ok {
+				seen[id] = struct{}{}
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
+
+func kvIndexBucketNames[K ~string](prefix string, keys []K) []string {
+	buckets := make([]string, 0, len(keys))
+	for _, key := range keys {
+		buckets = append(buckets, prefix+string(key))
+	}
+	return buckets
+}
+
+// kvCandidates は検索条件のうち索引で引ける条件ごとに件数を数え、最も件数の少ない条件の ID を返す。
+// 件数は聖遺物を読まずに求まるため、聖遺物を読み込むのは選んだ条件の候補だけになる。
+// 索引で引ける条件がなければ ok は false になる。
+func kvCandidates(tx *bolt.Tx, query ArtifactQuery) ([]string, bool) {
+	var conditions [][]string
+	if len(query.Types) > 0 {
+		conditions = append(conditions, kvIndexBucketNames(kvIndexByType, query.Types))
+	}
+	if len(query.Sets) > 0 {
+		conditions = append(conditions, kvIndexBucketNames(kvIndexBySet, query.Sets))
+	}
+	if len(query.PrimaryStats) > 0 {
+		conditions = append(conditions, kvIndexBucketNames(kvIndexByPrimaryStat, query.PrimaryStats))
+	}
+	for _, substatType := range query.RequiredSubstats {
+		conditions = append(conditions, []string{kvIndexBySubstat + string(substatType)})
+	}
+	for substatType := range query.MinSubstatValues {
+		conditions = append(conditions, []string{kvIndexBySubstat + string(substatType)})
+	}
+	if len(query.EquippedBy) > 0 && !slices.Contains(query.EquippedBy, "") {
+		conditions = append(conditions, kvIndexBucketNames(kvIndexByEquippedBy, query.EquippedBy))
+	}
+	if len(conditions) == 0 {
+		return nil, false
+	}
+
+	// 和集合の件数は各バケットの件数の和を上限として見積もる
+	var smallest []string
+	smallestCount := -1
+	for _, buckets := range conditions {
+		count := 0
+		for _, bucket := range buckets {
+			count += kvLen(tx, bucket)
+		}
+		if smallestCount < 0 || count < smallestCount {
+			smallest, smallestCount = buckets, count
+		}
+	}
+	return kvIndexedIDs(tx, smallest), true
+}
+
+func (repo *KVArtifactRepository) GetArtifactByID(id string) (*entity.Artifact, error) {
+	if id == "" {
+		return nil, ErrArtifactIDIsEmpty
+	}
+
+	var artifact *entity.Artifact
+	err := repo.db.View(func(tx *bolt.Tx) error {
+		var err error
+		artifact, err = readArtifact(tx, id)
+		return err
+	})
+	return artifact, err
+}
+
+func (repo *KVArtifactRepository) GetArtifactByTypeAndSet(artifactType entity.ArtifactType, artifactSet entity.ArtifactSet) ([]*entity.Artifact, error) {
+	var artifacts []*entity.Artifact
+	err := repo.db.View(func(tx *bolt.Tx) error {
+		// 部位とセットのうち件数の少ない方の索引から引き、もう一方で絞り込む
+		bucket, other := kvIndexByType+string(artifactType), kvIndexBySet+string(artifactSet)
+		if kvLen(tx, other) < kvLen(tx, bucket) {
+			bucket, other = other, bucket
+		}
+		var ids []string
+		for _, id := range kvKeys(tx, bucket) {
+			if kvHas(tx, other, id) {
+				ids = append(ids, id)
+			}
+		}
+
+		var err error
+		artifacts, err = readArtifacts(tx, ids, matchAll)
+		return err
+	})
+	return artifacts, err
+}
+
+func (repo *KVArtifactRepository) GetArtifactByType(artifactType entity.ArtifactType) ([]*entity.Artifact, error) {
+	return repo.readIndexedArtifacts(kvIndexByType + string(artifactType))
+}
+
+func (repo *KVArtifactRepository) GetArtifactBySet(artifactSet entity.ArtifactSet) ([]*entity.Artifact, error) {
+	return repo.readIndexedArtifacts(kvIndexBySet + string(artifactSet))
+}
+
+func (repo *KVArtifactRepository) readIndexedArtifacts(bucket string) ([]*entity.Artifact, error) {
+	var artifacts []*entity.Artifact
+	err := repo.db.View(func(tx *bolt.Tx) error {
+		var err error
+		artifacts, err = readArtifacts(tx, kvKeys(tx, bucket), matchAll)
+		return err
+	})
+	return artifacts, err
+}
+
+// QueryArtifacts は検索条件に合う聖遺物を並べ替えてページングした結果を返す。
+// 該当がなくてもエラーにはせず、空の結果を返す。
+func (repo *KVArtifactRepository) QueryArtifacts(query ArtifactQuery) (*ArtifactQueryResult, error) {
+	var artifacts []*entity.Artifact
+	err := repo.db.View(func(tx *bolt.Tx) error {
+		var err error
+		artifacts, err = kvCandidateArtifacts(tx, query)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return queryArtifacts(artifacts, query)
+}
+
+func (repo *KVArtifactRepository) SaveArtifact(artifact *entity.Artifact) error {
+	if artifact == nil {
+		return ErrArtifactIsNil
+	}
+
+	if artifact.ID == "" {
+		return ErrArtifactIDIsEmpty
+	}
+
+	return repo.db.Update(func(tx *bolt.Tx) error {
+		if tx.Bucket([]byte(kvArtifactBucket)).Get([]byte(artifact.ID)) != nil {
+			return ErrArtifactAlreadyExists
+		}
+		if err := checkEquipmentSlots(kvEquipmentState(tx), []*entity.Artifact{artifact}); err != nil {
+			return err
+		}
+		return putArtifact(tx, artifact)
+	})
+}
+
+// SaveArtifacts はすべての聖遺物を検証してから 1 回のトランザクションで保存し、1 件でも保存できなければどれも保存しない。
+func (repo *KVArtifactRepository) SaveArtifacts(artifacts []*entity.Artifact, options ArtifactSaveOptions) error {
+	if err := validateArtifactBatch(artifacts, ErrArtifactAlreadyExists); err != nil {
+		return err
+	}
+
+	return repo.db.Update(func(tx *bolt.Tx) error {
+		for _, artifact := range artifacts {
+			if tx.Bucket([]byte(kvArtifactBucket)).Get([]byte(artifact.ID)) != nil {
+				return fmt.Errorf("%w: %s", ErrArtifactAlreadyExists, artifact.ID)
+			}
+		}
+		if err := checkEquipmentSlots(kvEquipmentState(tx), artifacts); err != nil {
+			return err
+		}
+		if options.RejectDuplicates {
+			candidates := func(query ArtifactQuery) ([]*entity.Artifact, error) {
+				return kvCandidateArtifacts(tx, query)
+			}
+			if err := checkDuplicates(candidates, artifacts); err != nil {
+				return err
+			}
+		}
+		for _, artifact := range artifacts {
+			if err := putArtifact(tx, artifact); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// SaveEachArtifact は保存できる聖遺物だけを 1 回のトランザクションで保存し、保存できなかった理由を artifacts と同じ順で返す。
+func (repo *KVArtifactRepository) SaveEachArtifact(artifacts []*entity.Artifact, options ArtifactSaveOptions) ([]error, error) {
+	var errs []error
+	err := repo.db.Update(func(tx *bolt.Tx) error {
+		exists := func(id string) bool {
+			return tx.Bucket([]byte(kvArtifactBucket)).Get([]byte(id)) != nil
+		}
+		candidates := func(query ArtifactQuery) ([]*entity.Artifact, error) {
+			return kvCandidateArtifacts(tx, query)
+		}
+		savable, savableErrs, err := savableArtifacts(artifacts, options, exists, kvEquipmentState(tx), candidates)
+		if err != nil {
+			return err
+		}
+		for _, artifact := range savable {
+			if err := putArtifact(tx, artifact); err != nil {
+				return err
+			}
+		}
+		errs = savableErrs
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return errs, nil
+}
+
+func (repo *KVArtifactRepository) UpdateArtifact(artifact *entity.Artifact) error {
+	if artifact == nil {
+		return ErrArtifactIsNil
+	}
+
+	if artifact.ID == "" {
+		return ErrArtifactIDIsEmpty
+	}
+
+	// 古い索引の削除と新しい値の保存を同じトランザクションで行い、索引が食い違わないようにする
+	return repo.db.Update(func(tx *bolt.Tx) error {
+		if _, err := readArtifact(tx, artifact.ID); err != nil {
+			return err
+		}
+		return updateArtifacts(tx, []*entity.Artifact{artifact})
+	})
+}
+
+// UpdateArtifacts はすべての聖遺物が存在することを確かめてから 1 回のトランザクションで更新し、1 件でも更新できなければどれも更新しない。
+func (repo *KVArtifactRepository) UpdateArtifacts(artifacts []*entity.Artifact) error {
+	if err := validateArtifactBatch(artifacts, ErrArtifactIDDuplicated); err != nil {
+		return err
+	}
+
+	return repo.db.Update(func(tx *bolt.Tx) error {
+		for _, artifact := range artifacts {
+			_, err := readArtifact(tx, artifact.ID)
+			if errors.Is(err, ErrArtifactNotFound) {
+				return fmt.Errorf("%w: %s", ErrArtifactNotFound, artifact.ID)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return updateArtifacts(tx, artifacts)
+	})
+}
+
+// updateArtifacts はすべての聖遺物が存在することを呼び出し元が確かめていることを前提とする。
+// 装備の判定は書き込み前の内容で行うため、すべて判定してから書き込む。
+func updateArtifacts(tx *bolt.Tx, artifacts []*entity.Artifact) error {
+	if err := checkEquipmentSlots(kvEquipmentState(tx), artifacts); err != nil {
+		return err
+	}
+	for _, artifact := range artifacts {
+		current, err := readArtifact(tx, artifact.ID)
+		if err != nil {
+			return err
+		}
+		if err := deleteArtifact(tx, current); err != nil {
+			return err
+		}
+		if err := putArtifact(tx, artifact); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (repo *KVArtifactRepository) ModifyArtifact(id string, modify func(current *entity.Artifact) (*entity.Artifact, error)) (*entity.Artifact, error) {
+	if id == "" {
+		return nil, ErrArtifactIDIsEmpty
+	}
+
+	var artifact *entity.Artifact
+	err := repo.db.Update(func(tx *bolt.Tx) error {
+		current, err := readArtifact(tx, id)
+		if err != nil {
+			return err
+		}
+		artifact, err = modify(current)
+		if err != nil {
+			return err
+		}
+		if err := validateModifiedArtifact(id, artifact); err != nil {
+			return err
+		}
+		return updateArtifacts(tx, []*entity.Artifact{artifact})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return artifact, nil
+}
+
+func (repo *KVArtifactRepository) EquipArtifact(id string, character entity.CharacterKey) error {
+	if id == "" {
+		return ErrArtifactIDIsEmpty
+	}
+
+	return repo.db.Update(func(tx *bolt.Tx) error {
+		artifact, err := readArtifact(tx, id)
+		if err != nil {
+			return err
+		}
+		updates, err := equipArtifactUpdates(kvEquipmentState(tx), artifact, character)
+		if err != nil {
+			return err
+		}
+		return updateArtifacts(tx, updates)
+	})
+}
+
+func (repo *KVArtifactRepository) UnequipArtifact(character entity.CharacterKey, artifactType entity.ArtifactType) error {
+	return repo.db.Update(func(tx *bolt.Tx) error {
+		updates, err := unequipArtifactUpdates(kvEquipmentState(tx), character, artifactType)
+		if err != nil {
+			return err
+		}
+		return updateArtifacts(tx, updates)
+	})
+}
+
+func (repo *KVArtifactRepository) DeleteArtifactByID(id string) error {
+	if id == "" {
+		return ErrArtifactIDIsEmpty
+	}
+
+	return repo.db.Update(func(tx *bolt.Tx) error {
+		artifact, err := readArtifact(tx, id)
+		if err != nil {
+			return err
+		}
+		return deleteArtifact(tx, artifact)
+	})
+}
+
+// Compact は何もしない。bbolt は上書き・削除で空いたページをファイル内で再利用するため、
+// InMemoryArtifactRepository のようにスナップショットを書き出して詰め直す必要がない。
+func (repo *KVArtifactRepository) Compact() error {
+	return nil
+}
+
+func (repo *KVArtifactRepository) Close() error {
+	return repo.db.Close()
+}
@@ -0,0 +1,296 @@
+package repository
+
+import (
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
+	"github.com/google/go-cmp/cmp"
+	bolt "go.etcd.io/bbolt"
+)
+
+func openTestKVArtifactRepository(t *testing.T, filename string) *KVArtifactRepository {
+	t.Helper()
+	repo, err := OpenKVArtifactRepository(filename)
+	if err != nil {
+		t.Fatalf("failed to open repository: %v", err)
+	}
+	t.Cleanup(func() { _ = repo.Close() })
+	return repo
+}
+
+// sortedArtifactIDs は取得順に依存せず比較できるよう ID を昇順に並べて返す。
+func sortedArtifactIDs(artifacts []*entity.Artifact) []string {
+	ids := artifactIDs(artifacts)
+	slices.Sort(ids)
+	return ids
+}
+
+func queryKVArtifacts(repo *KVArtifactRepository, query ArtifactQuery) func() ([]*entity.Artifact, error) {
+	return func() ([]*entity.Artifact, error) {
+		result, err := repo.QueryArtifacts(query)
+		if err != nil {
+			return nil, err
+		}
+		return result.Artifacts, nil
+	}
+}
+
+func TestKVArtifactRepositoryIndexFollowsWrites(t *testing.T) {
+	// GIVEN
+	repo := openTestKVArtifactRepository(t, filepath.Join(t.TempDir(), "artifacts.db"))
+	flower := &entity.Artifact{
+		ID:          "flower",
+		Type:        entity.ARTIFACT_TYPE_FLOWER,
+		ArtifactSet: entity.ARTIFACT_SET_GLADIATORS_FINALOFFERING,
+		PrimaryStat: entity.PrimaryStat{Type: entity.HP_FLAT, Value: 4780},
+		Substats:    []entity.Substat{{Type: entity.SUBSTAT_CRIT_RATE, Value: 3.9}},
+		EquippedBy:  "Diluc",
+	}
+	plume := &entity.Artifact{
+		ID:          "plume",
+		Type:        entity.ARTIFACT_TYPE_PLUME,
+		ArtifactSet: entity.ARTIFACT_SET_GLADIATORS_FINALOFFERING,
+		PrimaryStat: entity.PrimaryStat{Type: entity.ATK_FLAT, Value: 311},
+		EquippedBy:  "Diluc",
+	}
+
+	// WHEN
+	if err := repo.SaveArtifacts([]*entity.Artifact{flower, plume}, ArtifactSaveOptions{}); err != nil {
+		t.Fatalf("failed to save artifacts: %v", err)
+	}
+	updated := *plume
+	updated.ArtifactSet = entity.ARTIFACT_SET_WANDERERS_TROUPE
+	updated.Substats = []entity.Substat{{Type: entity.SUBSTAT_CRIT_RATE, Value: 7.8}}
+	updated.EquippedBy = "Bennett"
+	if err := repo.UpdateArtifact(&updated); err != nil {
+		t.Fatalf("failed to update artifact: %v", err)
+	}
+
+	// THEN
+	tests := []struct {
+		name        string
+		query       func() ([]*entity.Artifact, error)
+		expectedIDs []string
+	}{
+		{
+			name: "ShouldFindBySet",
+			query: func() ([]*entity.Artifact, error) {
+				return repo.GetArtifactBySet(entity.ARTIFACT_SET_GLADIATORS_FINALOFFERING)
+			},
+			expectedIDs: []string{"flower"},
+		},
+		{
+			name:        "ShouldFindByUpdatedSet",
+			query:       func() ([]*entity.Artifact, error) { return repo.GetArtifactBySet(entity.ARTIFACT_SET_WANDERERS_TROUPE) },
+			expectedIDs: []string{"plume"},
+		},
+		{
+			name: "ShouldFindByTypeAndSet",
+			query: func() ([]*entity.Artifact, error) {
+				return repo.GetArtifactByTypeAndSet(entity.ARTIFACT_TYPE_PLUME, entity.ARTIFACT_SET_WANDERERS_TROUPE)
+			},
+			expectedIDs: []string{"plume"},
+		},
+		{
+			name: "ShouldReturnEmptyWhenTypeAndSetDoNotOverlap",
+			query: func() ([]*entity.Artifact, error) {
+				return repo.GetArtifactByTypeAndSet(entity.ARTIFACT_TYPE_FLOWER, entity.ARTIFACT_SET_WANDERERS_TROUPE)
+			},
+			expectedIDs: []string{},
+		},
+		{
+			name: "ShouldQueryBySubstat",
+			query: func() ([]*entity.Artifact, error) {
+				result, err := repo.QueryArtifacts(ArtifactQuery{
+					MinSubstatValues: map[entity.SubstatType]float64{entity.SUBSTAT_CRIT_RATE: 5},
+				})
+				if err != nil {
+					return nil, err
+				}
+				return result.Artifacts, nil
+			},
+			expectedIDs: []string{"plume"},
+		},
+		{
+			name:        "ShouldQueryByEquippedCharacter",
+			query:       queryKVArtifacts(repo, ArtifactQuery{EquippedBy: []entity.CharacterKey{"Diluc"}}),
+			expectedIDs: []string{"flower"},
+		},
+		{
+			name:        "ShouldQueryByUpdatedEquippedCharacter",
+			query:       queryKVArtifacts(repo, ArtifactQuery{EquippedBy: []entity.CharacterKey{"Bennett"}}),
+			expectedIDs: []string{"plume"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			artifacts, err := tt.query()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.expectedIDs, sortedArtifactIDs(artifacts)); diff != "" {
+				t.Errorf("IDs mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestKVArtifactRepositoryIndexCountsFollowWrites(t *testing.T) {
+	// GIVEN
+	repo := openTestKVArtifactRepository(t, filepath.Join(t.TempDir(), "artifacts.db"))
+	flower := &entity.Artifact{ID: "flower", Type: entity.ARTIFACT_TYPE_FLOWER, ArtifactSet: entity.ARTIFACT_SET_NOBLESSE_OBLIGE, EquippedBy: "Diluc"}
+	plume := &entity.Artifact{ID: "plume", Type: entity.ARTIFACT_TYPE_PLUME, ArtifactSet: entity.ARTIFACT_SET_NOBLESSE_OBLIGE}
+	sands := &entity.Artifact{ID: "sands", Type: entity.ARTIFACT_TYPE_SANDS, ArtifactSet: entity.ARTIFACT_SET_NOBLESSE_OBLIGE}
+
+	// WHEN
+	if err := repo.SaveArtifacts([]*entity.Artifact{flower, plume, sands}, ArtifactSaveOptions{}); err != nil {
+		t.Fatalf("failed to save artifacts: %v", err)
+	}
+	updated := *plume
+	updated.ArtifactSet = entity.ARTIFACT_SET_WANDERERS_TROUPE
+	if err := repo.UpdateArtifact(&updated); err != nil {
+		t.Fatalf("failed to update artifact: %v", err)
+	}
+	// 索引の変わらない更新で件数が増えないことも確かめる
+	if err := repo.UpdateArtifact(sands); err != nil {
+		t.Fatalf("failed to update artifact: %v", err)
+	}
+	if err := repo.DeleteArtifactByID("flower"); err != nil {
+		t.Fatalf("failed to delete artifact: %v", err)
+	}
+
+	// THEN
+	buckets := []string{
+		kvIndexBySet + string(entity.ARTIFACT_SET_NOBLESSE_OBLIGE),
+		kvIndexBySet + string(entity.ARTIFACT_SET_WANDERERS_TROUPE),
+		kvIndexByType + string(entity.ARTIFACT_TYPE_FLOWER),
+		kvIndexByType + string(entity.ARTIFACT_TYPE_SANDS),
+		kvIndexByEquippedBy + "Diluc",
+	}
+	expected := map[string]int{
+		buckets[0]: 1,
+		buckets[1]: 1,
+		buckets[2]: 0,
+		buckets[3]: 1,
+		buckets[4]: 0,
+	}
+	counts := map[string]int{}
+	err := repo.db.View(func(tx *bolt.Tx) error {
+		for _, bucket := range buckets {
+			counts[bucket] = kvLen(tx, bucket)
+			if counts[bucket] != len(kvKeys(tx, bucket)) {
+				t.Errorf("count of %s is %d, but bucket has %d keys", bucket, counts[bucket], len(kvKeys(tx, bucket)))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(expected, counts); diff != "" {
+		t.Errorf("counts mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestKVArtifactRepositoryPersistence(t *testing.T) {
+	// GIVEN
+	filename := filepath.Join(t.TempDir(), "artifacts.db")
+	repo, err := OpenKVArtifactRepository(filename)
+	if err != nil {
+		t.Fatalf("failed to open repository: %v", err)
+	}
+	artifacts := []*entity.Artifact{
+		{ID: "kept", Type: entity.ARTIFACT_TYPE_SANDS, ArtifactSet: entity.ARTIFACT_SET_NOBLESSE_OBLIGE, Rarity: 5, Level: 20, Locked: true},
+		{ID: "deleted", Type: entity.ARTIFACT_TYPE_SANDS, ArtifactSet: entity.ARTIFACT_SET_NOBLESSE_OBLIGE, Rarity: 5},
+	}
+	if err := repo.SaveArtifacts(artifacts, ArtifactSaveOptions{}); err != nil {
+		t.Fatalf("failed to save artifacts: %v", err)
+	}
+	if err := repo.DeleteArtifactByID("deleted"); err != nil {
+		t.Fatalf("failed to delete artifact: %v", err)
+	}
+
+	// WHEN
+	if err := repo.Compact(); err != nil {
+		t.Fatalf("failed to compact: %v", err)
+	}
+	if err := repo.Close(); err != nil {
+		t.Fatalf("failed to close: %v", err)
+	}
+	reopened := openTestKVArtifactRepository(t, filename)
+
+	// THEN
+	artifact, err := reopened.GetArtifactByID("kept")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(artifacts[0], artifact); diff != "" {
+		t.Errorf("artifact mismatch (-want +got):\n%s", diff)
+	}
+	if _, err := reopened.GetArtifactByID("deleted"); !errors.Is(err, ErrArtifactNotFound) {
+		t.Errorf("expected error: %v, got: %v", ErrArtifactNotFound, err)
+	}
+	bySet, err := reopened.GetArtifactBySet(entity.ARTIFACT_SET_NOBLESSE_OBLIGE)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"kept"}, sortedArtifactIDs(bySet)); diff != "" {
+		t.Errorf("index mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestOpenKVArtifactRepositoryFailsWhileFileIsOpen(t *testing.T) {
+	// GIVEN
+	filename := filepath.Join(t.TempDir(), "artifacts.db")
+	openTestKVArtifactRepository(t, filename)
+
+	// WHEN
+	repo, err := OpenKVArtifactRepository(filename)
+
+	// THEN
+	if err == nil {
+		_ = repo.Close()
+		t.Fatal("expected error while another handle holds the file lock")
+	}
+}
+
+func TestKVArtifactRepositoryRebuildsIndexesWhenVersionChanges(t *testing.T) {
+	// GIVEN
+	filename := filepath.Join(t.TempDir(), "artifacts.db")
+	repo, err := OpenKVArtifactRepository(filename)
+	if err != nil {
+		t.Fatalf("failed to open repository: %v", err)
+	}
+	artifact := &entity.Artifact{ID: "flower", Type: entity.ARTIFACT_TYPE_FLOWER, ArtifactSet: entity.ARTIFACT_SET_NOBLESSE_OBLIGE, EquippedBy: "Diluc"}
+	if err := repo.SaveArtifact(artifact); err != nil {
+		t.Fatalf("failed to save artifact: %v", err)
+	}
+	// 装備の索引を持たない旧版のファイルを再現する
+	err = repo.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.DeleteBucket([]byte(kvIndexByEquippedBy + "Diluc")); err != nil {
+			return err
+		}
+		return tx.Bucket([]byte(kvMetaBucket)).Put([]byte(kvIndexVersionKey), []byte("1"))
+	})
+	if err != nil {
+		t.Fatalf("failed to downgrade index: %v", err)
+	}
+	if err := repo.Close(); err != nil {
+		t.Fatalf("failed to close: %v", err)
+	}
+
+	// WHEN
+	reopened := openTestKVArtifactRepository(t, filename)
+
+	// THEN
+	artifacts, err := queryKVArtifacts(reopened, ArtifactQuery{EquippedBy: []entity.CharacterKey{"Diluc"}})()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"flower"}, sortedArtifactIDs(artifacts)); diff != "" {
+		t.Errorf("index mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,32 @@
+package repository
+
+import "github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
+
+// migrateArtifacts は旧バージョンで保存されたデータを現在の表現へ変換する。
+// スナップショットと先行書き込みログの読み込み時に適用される。
+func migrateArtifacts(artifacts map[string]*entity.Artifact) {
+	for _, artifact := range artifacts {
+		migrateArtifact(artifact)
+	}
+}
+
+func migrateArtifact(artifact *entity.Artifact) {
+	if artifact == nil {
+		return
+	}
+
+	// セットの旧キー ("Gladiator" など) を正規キーへ置き換える
+	if artifactSet, err := entity.ParseArtifactSet(string(artifact.ArtifactSet)); err == nil {
+		artifact.ArtifactSet = artifactSet
+	}
+
+	// レアリティを持たなかった旧データは、当時登録対象だった星 5 として扱う
+	if artifact.Rarity == 0 {
+		artifact.Rarity = entity.MaxRarity
+	}
+
+	// 元素の区別がなかった元素ダメージバフは元素不明として残す
+	if artifact.PrimaryStat.Type == entity.ELEMENTAL_DMG_BONUS {
+		artifact.PrimaryStat.Type = entity.UNKNOWN_ELEMENT_DMG_BONUS
+	}
+}
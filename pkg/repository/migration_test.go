@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
+)
+
+func TestInMemoryArtifactRepositoryLoadJSONFileMigration(t *testing.T) {
+	tests := []struct {
+		name string
+
+		// GIVEN
+		content string
+
+		// THEN
+		expectedArtifactSet entity.ArtifactSet
+		expectedPrimaryStat entity.PrimaryStatType
+	}{
+		{
+			name: "ShouldMigrateLegacyArtifactSetKey",
+
+			content: `{"artifacts":{"test-id":{"ID":"test-id","ArtifactSet":"Gladiator"}}}`,
+
+			expectedArtifactSet: entity.ARTIFACT_SET_GLADIATORS_FINALOFFERING,
+		},
+		{
+			name: "ShouldMigrateLegacyElementalDMGBonusToUnknownElement",
+
+			content: `{"artifacts":{"test-id":{"ID":"test-id","ArtifactSet":"GladiatorsFinale","Type":"GOBLET","PrimaryStat":{"Type":"ELEMENTAL_DMG_BONUS","Value":46.6}}}}`,
+
+			expectedArtifactSet: entity.ARTIFACT_SET_GLADIATORS_FINALOFFERING,
+			expectedPrimaryStat: entity.UNKNOWN_ELEMENT_DMG_BONUS,
+		},
+		{
+			name: "ShouldKeepPerElementDMGBonus",
+
+			content: `{"artifacts":{"test-id":{"ID":"test-id","ArtifactSet":"GladiatorsFinale","Type":"GOBLET","PrimaryStat":{"Type":"PYRO_DMG_BONUS","Value":46.6}}}}`,
+
+			expectedArtifactSet: entity.ARTIFACT_SET_GLADIATORS_FINALOFFERING,
+			expectedPrimaryStat: entity.PYRO_DMG_BONUS,
+		},
+		{
+			name: "ShouldMigrateMisnamedViridescentVenererKey",
+
+			content: `{"artifacts":{"test-id":{"ID":"test-id","ArtifactSet":"Vermillion"}}}`,
+
+			expectedArtifactSet: entity.ARTIFACT_SET_VIRIDESCENT_VENERER,
+		},
+		{
+			name: "ShouldKeepCanonicalArtifactSetKey",
+
+			content: `{"artifacts":{"test-id":{"ID":"test-id","ArtifactSet":"VermillionHereafter"}}}`,
+
+			expectedArtifactSet: "VermillionHereafter",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), "artifacts.json")
+			if err := os.WriteFile(filename, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("failed to write file: %v", err)
+			}
+
+			repo := NewInMemoryArtifactRepository()
+			if err := repo.LoadJSONFile(filename); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			artifact, err := repo.GetArtifactByID("test-id")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if artifact.ArtifactSet != tt.expectedArtifactSet {
+				t.Errorf("expected artifact set %s, got %s", tt.expectedArtifactSet, artifact.ArtifactSet)
+			}
+			if artifact.PrimaryStat.Type != tt.expectedPrimaryStat {
+				t.Errorf("expected primary stat %s, got %s", tt.expectedPrimaryStat, artifact.PrimaryStat.Type)
+			}
+		})
+	}
+}
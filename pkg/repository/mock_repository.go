@@ -14,6 +14,12 @@ type MockArtifactGetter struct {
 
 	GetArtifactBySetResponse []*entity.Artifact
 	GetArtifactBySetError    error
+
+	QueryArtifactsResponse *ArtifactQueryResult
+	QueryArtifactsError    error
+
+	// QueriedArtifactQuery は最後に QueryArtifacts に渡された値を保持する
+	QueriedArtifactQuery ArtifactQuery
 }
 
 func (m *MockArtifactGetter) GetArtifactByID(id string) (*entity.Artifact, error) {
@@ -32,6 +38,11 @@ func (m *MockArtifactGetter) GetArtifactBySet(artifactSet entity.ArtifactSet) ([
 	return m.GetArtifactBySetResponse, m.GetArtifactBySetError
 }
 
+func (m *MockArtifactGetter) QueryArtifacts(query ArtifactQuery) (*ArtifactQueryResult, error) {
+	m.QueriedArtifactQuery = query
+	return m.QueryArtifactsResponse, m.QueryArtifactsError
+}
+
 type MockArtifactSaver struct {
 	SaveArtifactError error
 }
@@ -39,3 +50,204 @@ type MockArtifactSaver struct {
 func (m *MockArtifactSaver) SaveArtifact(artifact *entity.Artifact) error {
 	return m.SaveArtifactError
 }
+
+type MockArtifactBatchSaver struct {
+	SaveArtifactsError     error
+	SaveEachArtifactErrors []error
+	SaveEachArtifactError  error
+
+	// SavedArtifacts と SaveOptions は最後に SaveArtifacts に渡された値を保持する
+	SavedArtifacts []*entity.Artifact
+	SaveOptions    ArtifactSaveOptions
+	// SavedEachArtifacts は最後に SaveEachArtifact に渡された値を保持する
+	SavedEachArtifacts []*entity.Artifact
+}
+
+func (m *MockArtifactBatchSaver) SaveArtifacts(artifacts []*entity.Artifact, options ArtifactSaveOptions) error {
+	m.SavedArtifacts = artifacts
+	m.SaveOptions = options
+	return m.SaveArtifactsError
+}
+
+// SaveEachArtifact は SaveEachArtifactErrors が nil なら、すべて保存できた結果を返す。
+func (m *MockArtifactBatchSaver) SaveEachArtifact(artifacts []*entity.Artifact, options ArtifactSaveOptions) ([]error, error) {
+	m.SavedEachArtifacts = artifacts
+	m.SaveOptions = options
+	if m.SaveEachArtifactError != nil {
+		return nil, m.SaveEachArtifactError
+	}
+	if m.SaveEachArtifactErrors == nil {
+		return make([]error, len(artifacts)), nil
+	}
+	return m.SaveEachArtifactErrors, nil
+}
+
+type MockArtifactUpdater struct {
+	UpdateArtifactError error
+
+	// UpdatedArtifact は最後に UpdateArtifact に渡された値を保持する
+	UpdatedArtifact *entity.Artifact
+}
+
+func (m *MockArtifactUpdater) UpdateArtifact(artifact *entity.Artifact) error {
+	m.UpdatedArtifact = artifact
+	return m.UpdateArtifactError
+}
+
+// MockArtifactModifier は ModifyArtifactCurrent を modify に渡す。
+type MockArtifactModifier struct {
+	ModifyArtifactCurrent *entity.Artifact
+	ModifyArtifactError   error
+
+	// ModifiedArtifact は最後に modify が返した値を保持する
+	ModifiedArtifact *entity.Artifact
+}
+
+func (m *MockArtifactModifier) ModifyArtifact(id string, modify func(current *entity.Artifact) (*entity.Artifact, error)) (*entity.Artifact, error) {
+	if m.ModifyArtifactError != nil {
+		return nil, m.ModifyArtifactError
+	}
+	artifact, err := modify(m.ModifyArtifactCurrent)
+	if err != nil {
+		return nil, err
+	}
+	m.ModifiedArtifact = artifact
+	return artifact, nil
+}
+
+type MockArtifactEquipper struct {
+	EquipArtifactError   error
+	UnequipArtifactError error
+}
+
+func (m *MockArtifactEquipper) EquipArtifact(id string, character entity.CharacterKey) error {
+	return m.EquipArtifactError
+}
+
+func (m *MockArtifactEquipper) UnequipArtifact(character entity.CharacterKey, artifactType entity.ArtifactType) error {
+	return m.UnequipArtifactError
+}
+
+type MockArtifactDeleter struct {
+	DeleteArtifactByIDError error
+}
+
+func (m *MockArtifactDeleter) DeleteArtifactByID(id string) error {
+	return m.DeleteArtifactByIDError
+}
+
+type MockScoreProfileGetter struct {
+	GetScoreProfileResponse *entity.ScoreProfile
+	GetScoreProfileError    error
+
+	GetScoreProfilesResponse []*entity.ScoreProfile
+	GetScoreProfilesError    error
+}
+
+func (m *MockScoreProfileGetter) GetScoreProfile(name string) (*entity.ScoreProfile, error) {
+	return m.GetScoreProfileResponse, m.GetScoreProfileError
+}
+
+func (m *MockScoreProfileGetter) GetScoreProfiles() ([]*entity.ScoreProfile, error) {
+	return m.GetScoreProfilesResponse, m.GetScoreProfilesError
+}
+
+type MockScoreProfileSaver struct {
+	SaveScoreProfileError error
+
+	// SavedScoreProfile は最後に SaveScoreProfile に渡された値を保持する
+	SavedScoreProfile *entity.ScoreProfile
+}
+
+func (m *MockScoreProfileSaver) SaveScoreProfile(profile *entity.ScoreProfile) error {
+	m.SavedScoreProfile = profile
+	return m.SaveScoreProfileError
+}
+
+type MockScoreProfileDeleter struct {
+	DeleteScoreProfileError error
+}
+
+func (m *MockScoreProfileDeleter) DeleteScoreProfile(name string) error {
+	return m.DeleteScoreProfileError
+}
+
+// MockAccountGetter の GetAccountArtifacts は Stores にないアカウントに ErrAccountNotFound を返す。
+type MockAccountGetter struct {
+	GetAccountsResponse []string
+	GetAccountsError    error
+
+	Stores                   map[string]ArtifactStore
+	GetAccountArtifactsError error
+}
+
+func (m *MockAccountGetter) GetAccounts() ([]string, error) {
+	return m.GetAccountsResponse, m.GetAccountsError
+}
+
+func (m *MockAccountGetter) GetAccountArtifacts(account string) (ArtifactStore, error) {
+	if m.GetAccountArtifactsError != nil {
+		return nil, m.GetAccountArtifactsError
+	}
+	store, ok := m.Stores[account]
+	if !ok {
+		return nil, ErrAccountNotFound
+	}
+	return store, nil
+}
+
+type MockAccountCreator struct {
+	CreateAccountError error
+
+	// CreatedAccount は最後に CreateAccount に渡された値を保持する
+	CreatedAccount string
+}
+
+func (m *MockAccountCreator) CreateAccount(account string) error {
+	m.CreatedAccount = account
+	return m.CreateAccountError
+}
+
+type MockAPIKeyGetter struct {
+	GetAPIKeyResponse *entity.APIKey
+	GetAPIKeyError    error
+
+	GetAPIKeysResponse []*entity.APIKey
+	GetAPIKeysError    error
+
+	// QueriedID は最後に GetAPIKey に渡された値を保持する
+	QueriedID string
+}
+
+func (m *MockAPIKeyGetter) GetAPIKey(id string) (*entity.APIKey, error) {
+	m.QueriedID = id
+	return m.GetAPIKeyResponse, m.GetAPIKeyError
+}
+
+func (m *MockAPIKeyGetter) GetAPIKeys() ([]*entity.APIKey, error) {
+	return m.GetAPIKeysResponse, m.GetAPIKeysError
+}
+
+type MockAPIKeySaver struct {
+	SaveAPIKeyError error
+
+	// SavedAPIKey は最後に SaveAPIKey に渡された値を保持する
+	SavedAPIKey *entity.APIKey
+}
+
+func (m *MockAPIKeySaver) SaveAPIKey(key *entity.APIKey) error {
+	m.SavedAPIKey = key
+	return m.SaveAPIKeyError
+}
+
+type MockAPIKeyDeleter struct {
+	DeleteAPIKeyError error
+
+	// DeletedID は最後に DeleteAPIKey に渡された値を保持する
+	DeletedID string
+}
+
+func (m *MockAPIKeyDeleter) DeleteAPIKey(id string) error {
+	m.DeletedID = id
+	return m.DeleteAPIKeyError
+}
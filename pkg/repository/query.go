@@ -0,0 +1,240 @@
+package repository
+
+import (
+	"cmp"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
+)
+
+var (
+	ErrInvalidArtifactQuery = errors.New("invalid artifact query")
+	ErrInvalidCursor        = errors.New("invalid cursor")
+)
+
+type ArtifactSortKey string
+
+const SORT_BY_ID ArtifactSortKey = "id"
+const SORT_BY_LEVEL ArtifactSortKey = "level"
+const SORT_BY_RARITY ArtifactSortKey = "rarity"
+const SORT_BY_CRIT_VALUE ArtifactSortKey = "crit_value"
+
+// SortBySubstat はサブステータスの値で並べるキーを返す。キーの文字列はサブステータスの種類 (例: "CRIT_DMG") と同じ。
+// サブステータスを持たない聖遺物は 0 として扱う。
+func SortBySubstat(substatType entity.SubstatType) ArtifactSortKey {
+	return ArtifactSortKey(substatType)
+}
+
+func (k ArtifactSortKey) valid() bool {
+	switch k {
+	case SORT_BY_ID, SORT_BY_LEVEL, SORT_BY_RARITY, SORT_BY_CRIT_VALUE:
+		return true
+	}
+	_, err := entity.NewSubstat(string(k), 0)
+	return err == nil
+}
+
+func (k ArtifactSortKey) value(artifact *entity.Artifact) float64 {
+	switch k {
+	case SORT_BY_ID:
+		return 0
+	case SORT_BY_LEVEL:
+		return float64(artifact.Level)
+	case SORT_BY_RARITY:
+		return float64(artifact.Rarity)
+	case SORT_BY_CRIT_VALUE:
+		return entity.CritValue(artifact)
+	}
+	for _, substat := range artifact.Substats {
+		if substat.Type == entity.SubstatType(k) {
+			return substat.Value
+		}
+	}
+	return 0
+}
+
+// ArtifactQuery は聖遺物の検索条件を表す。
+// 空のスライスや nil の条件は絞り込みに使わない。同じ条件内の複数の値は OR、条件同士は AND で結合する。
+type ArtifactQuery struct {
+	Types        []entity.ArtifactType
+	Sets         []entity.ArtifactSet
+	PrimaryStats []entity.PrimaryStatType
+	Rarities     []int
+	MinLevel     *int
+	MaxLevel     *int
+	// RequiredSubstats はすべて持っている聖遺物だけを返す
+	RequiredSubstats []entity.SubstatType
+	// MinSubstatValues はサブステータスの値が指定値以上の聖遺物だけを返す
+	MinSubstatValues map[entity.SubstatType]float64
+	// EquippedBy は装備しているキャラクターで絞り込む。空文字列はどのキャラクターも装備していない聖遺物を表す
+	EquippedBy []entity.CharacterKey
+
+	// SortKey が空の場合は ID 順に並べる。同じ値の聖遺物は常に ID 順になる。
+	SortKey        ArtifactSortKey
+	SortDescending bool
+
+	// Limit が 0 の場合は件数を制限しない
+	Limit  int
+	Offset int
+	// Cursor は前回の結果の NextCursor。Offset とは同時に指定できない。
+	Cursor string
+}
+
+type ArtifactQueryResult struct {
+	Artifacts []*entity.Artifact
+	// Total はページングする前の該当件数
+	Total int
+	// NextCursor は続きがある場合のみ設定される
+	NextCursor string
+}
+
+func (q *ArtifactQuery) validate() error {
+	if q.SortKey != "" && !q.SortKey.valid() {
+		return fmt.Errorf("%w: unknown sort key %s", ErrInvalidArtifactQuery, q.SortKey)
+	}
+	if q.Limit < 0 || q.Offset < 0 {
+		return fmt.Errorf("%w: limit and offset must not be negative", ErrInvalidArtifactQuery)
+	}
+	if q.Cursor != "" && q.Offset > 0 {
+		return fmt.Errorf("%w: cursor and offset cannot be used together", ErrInvalidArtifactQuery)
+	}
+	return nil
+}
+
+func (q *ArtifactQuery) matches(artifact *entity.Artifact) bool {
+	if len(q.Types) > 0 && !slices.Contains(q.Types, artifact.Type) {
+		return false
+	}
+	if len(q.Sets) > 0 && !slices.Contains(q.Sets, artifact.ArtifactSet) {
+		return false
+	}
+	if len(q.PrimaryStats) > 0 && !slices.Contains(q.PrimaryStats, artifact.PrimaryStat.Type) {
+		return false
+	}
+	if len(q.Rarities) > 0 && !slices.Contains(q.Rarities, artifact.Rarity) {
+		return false
+	}
+	if q.MinLevel != nil && artifact.Level < *q.MinLevel {
+		return false
+	}
+	if q.MaxLevel != nil && artifact.Level > *q.MaxLevel {
+		return false
+	}
+	if len(q.EquippedBy) > 0 && !slices.Contains(q.EquippedBy, artifact.EquippedBy) {
+		return false
+	}
+
+	for _, required := range q.RequiredSubstats {
+		if !hasSubstat(artifact, required) {
+			return false
+		}
+	}
+	for substatType, minValue := range q.MinSubstatValues {
+		if !hasSubstat(artifact, substatType) || SortBySubstat(substatType).value(artifact) < minValue {
+			return false
+		}
+	}
+	return true
+}
+
+func hasSubstat(artifact *entity.Artifact, substatType entity.SubstatType) bool {
+	return slices.ContainsFunc(artifact.Substats, func(substat entity.Substat) bool {
+		return substat.Type == substatType
+	})
+}
+
+// artifactCursor は最後に返した聖遺物の並び替えの値と ID を保持し、
+// その直後から再開できるようにする。ページ間で追加・削除があっても重複や欠落が起きない。
+type artifactCursor struct {
+	Value float64 `json:"v"`
+	ID    string  `json:"id"`
+}
+
+func encodeCursor(cursor artifactCursor) string {
+	cursorBytes, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(cursorBytes)
+}
+
+func decodeCursor(encoded string) (artifactCursor, error) {
+	var cursor artifactCursor
+	cursorBytes, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return cursor, ErrInvalidCursor
+	}
+	if err := json.Unmarshal(cursorBytes, &cursor); err != nil || cursor.ID == "" {
+		return cursor, ErrInvalidCursor
+	}
+	return cursor, nil
+}
+
+type sortedArtifact struct {
+	artifact *entity.Artifact
+	value    float64
+}
+
+func (q *ArtifactQuery) compare(a, b sortedArtifact) int {
+	order := cmp.Compare(a.value, b.value)
+	if q.SortDescending {
+		order = -order
+	}
+	if order != 0 {
+		return order
+	}
+	return strings.Compare(a.artifact.ID, b.artifact.ID)
+}
+
+// queryArtifacts は聖遺物の一覧から検索条件に合うものを並べ替え、ページングして返す。
+func queryArtifacts(artifacts []*entity.Artifact, query ArtifactQuery) (*ArtifactQueryResult, error) {
+	if err := query.validate(); err != nil {
+		return nil, err
+	}
+	sortKey := query.SortKey
+	if sortKey == "" {
+		sortKey = SORT_BY_ID
+	}
+
+	matched := make([]sortedArtifact, 0, len(artifacts))
+	for _, artifact := range artifacts {
+		if query.matches(artifact) {
+			matched = append(matched, sortedArtifact{artifact: artifact, value: sortKey.value(artifact)})
+		}
+	}
+	slices.SortFunc(matched, query.compare)
+
+	start := query.Offset
+	if query.Cursor != "" {
+		cursor, err := decodeCursor(query.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		after := sortedArtifact{artifact: &entity.Artifact{ID: cursor.ID}, value: cursor.Value}
+		start, _ = slices.BinarySearchFunc(matched, after, query.compare)
+		if start < len(matched) && matched[start].artifact.ID == cursor.ID {
+			start++
+		}
+	}
+	start = min(start, len(matched))
+
+	end := len(matched)
+	if query.Limit > 0 {
+		end = min(start+query.Limit, len(matched))
+	}
+
+	result := &ArtifactQueryResult{
+		Artifacts: make([]*entity.Artifact, 0, end-start),
+		Total:     len(matched),
+	}
+	for _, item := range matched[start:end] {
+		result.Artifacts = append(result.Artifacts, item.artifact)
+	}
+	if end < len(matched) && end > start {
+		last := matched[end-1]
+		result.NextCursor = encodeCursor(artifactCursor{Value: last.value, ID: last.artifact.ID})
+	}
+	return result, nil
+}
@@ -0,0 +1,249 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
+	"github.com/google/go-cmp/cmp"
+)
+
+func newQueryTestRepository() *InMemoryArtifactRepository {
+	return &InMemoryArtifactRepository{
+		Artifacts: map[string]*entity.Artifact{
+			"a": {
+				ID: "a", ArtifactSet: entity.ARTIFACT_SET_GLADIATORS_FINALOFFERING, Type: entity.ARTIFACT_TYPE_FLOWER,
+				Rarity: 5, Level: 20, PrimaryStat: entity.PrimaryStat{Type: entity.HP_FLAT, Value: 4780},
+				Substats: []entity.Substat{{Type: entity.SUBSTAT_CRIT_RATE, Value: 10.5}, {Type: entity.SUBSTAT_CRIT_DMG, Value: 21.0}},
+			},
+			"b": {
+				ID: "b", ArtifactSet: entity.ARTIFACT_SET_NOBLESSE_OBLIGE, Type: entity.ARTIFACT_TYPE_SANDS,
+				Rarity: 5, Level: 0, PrimaryStat: entity.PrimaryStat{Type: entity.ATK_PERCENT, Value: 7.0},
+				Substats: []entity.Substat{{Type: entity.SUBSTAT_CRIT_DMG, Value: 7.8}},
+			},
+			"c": {
+				ID: "c", ArtifactSet: entity.ARTIFACT_SET_GLADIATORS_FINALOFFERING, Type: entity.ARTIFACT_TYPE_GOBLET,
+				Rarity: 4, Level: 8, PrimaryStat: entity.PrimaryStat{Type: entity.PYRO_DMG_BONUS, Value: 15.0},
+				Substats: []entity.Substat{{Type: entity.SUBSTAT_CRIT_RATE, Value: 5.4}},
+			},
+			"d": {
+				ID: "d", ArtifactSet: entity.ARTIFACT_SET_NOBLESSE_OBLIGE, Type: entity.ARTIFACT_TYPE_CIRCLET,
+				Rarity: 5, Level: 20, PrimaryStat: entity.PrimaryStat{Type: entity.CRIT_RATE, Value: 31.1},
+				Substats: []entity.Substat{{Type: entity.SUBSTAT_CRIT_DMG, Value: 21.0}},
+			},
+		},
+	}
+}
+
+func artifactIDs(artifacts []*entity.Artifact) []string {
+	ids := make([]string, len(artifacts))
+	for i, artifact := range artifacts {
+		ids[i] = artifact.ID
+	}
+	return ids
+}
+
+func TestInMemoryArtifactRepositoryQueryArtifacts(t *testing.T) {
+	minLevel := 8
+	maxLevel := 8
+
+	tests := []struct {
+		name string
+
+		// WHEN
+		query ArtifactQuery
+
+		// THEN
+		expectedIDs        []string
+		expectedTotal      int
+		expectedNextCursor bool
+		expectedError      error
+	}{
+		{
+			name: "ShouldReturnAllArtifactsInIDOrderWithoutConditions",
+
+			query: ArtifactQuery{},
+
+			expectedIDs:   []string{"a", "b", "c", "d"},
+			expectedTotal: 4,
+		},
+		{
+			name: "ShouldFilterByTypesAndSets",
+
+			query: ArtifactQuery{
+				Types: []entity.ArtifactType{entity.ARTIFACT_TYPE_FLOWER, entity.ARTIFACT_TYPE_GOBLET, entity.ARTIFACT_TYPE_SANDS},
+				Sets:  []entity.ArtifactSet{entity.ARTIFACT_SET_GLADIATORS_FINALOFFERING},
+			},
+
+			expectedIDs:   []string{"a", "c"},
+			expectedTotal: 2,
+		},
+		{
+			name: "ShouldFilterByPrimaryStatRarityAndLevelRange",
+
+			query: ArtifactQuery{
+				PrimaryStats: []entity.PrimaryStatType{entity.PYRO_DMG_BONUS, entity.HP_FLAT},
+				Rarities:     []int{4},
+				MinLevel:     &minLevel,
+				MaxLevel:     &maxLevel,
+			},
+
+			expectedIDs:   []string{"c"},
+			expectedTotal: 1,
+		},
+		{
+			name: "ShouldFilterByRequiredSubstatsAndMinimumValues",
+
+			query: ArtifactQuery{
+				RequiredSubstats: []entity.SubstatType{entity.SUBSTAT_CRIT_DMG},
+				MinSubstatValues: map[entity.SubstatType]float64{entity.SUBSTAT_CRIT_DMG: 20},
+			},
+
+			expectedIDs:   []string{"a", "d"},
+			expectedTotal: 2,
+		},
+		{
+			name: "ShouldSortByCritValueDescendingWithIDTiebreak",
+
+			query: ArtifactQuery{
+				SortKey:        SORT_BY_CRIT_VALUE,
+				SortDescending: true,
+			},
+
+			expectedIDs:   []string{"a", "d", "c", "b"},
+			expectedTotal: 4,
+		},
+		{
+			name: "ShouldSortBySubstatAscending",
+
+			query: ArtifactQuery{
+				SortKey: SortBySubstat(entity.SUBSTAT_CRIT_DMG),
+			},
+
+			expectedIDs:   []string{"c", "b", "a", "d"},
+			expectedTotal: 4,
+		},
+		{
+			name: "ShouldPaginateWithLimitAndOffset",
+
+			query: ArtifactQuery{
+				Limit:  2,
+				Offset: 1,
+			},
+
+			expectedIDs:        []string{"b", "c"},
+			expectedTotal:      4,
+			expectedNextCursor: true,
+		},
+		{
+			name: "ShouldReturnEmptyPageWhenOffsetExceedsTotal",
+
+			query: ArtifactQuery{
+				Offset: 10,
+			},
+
+			expectedIDs:   []string{},
+			expectedTotal: 4,
+		},
+		{
+			name: "ShouldReturnErrorWhenSortKeyIsUnknown",
+
+			query: ArtifactQuery{
+				SortKey: "unknown",
+			},
+
+			expectedError: ErrInvalidArtifactQuery,
+		},
+		{
+			name: "ShouldReturnErrorWhenLimitIsNegative",
+
+			query: ArtifactQuery{
+				Limit: -1,
+			},
+
+			expectedError: ErrInvalidArtifactQuery,
+		},
+		{
+			name: "ShouldReturnErrorWhenCursorAndOffsetAreCombined",
+
+			query: ArtifactQuery{
+				Offset: 1,
+				Cursor: encodeCursor(artifactCursor{ID: "a"}),
+			},
+
+			expectedError: ErrInvalidArtifactQuery,
+		},
+		{
+			name: "ShouldReturnErrorWhenCursorIsMalformed",
+
+			query: ArtifactQuery{
+				Cursor: "not a cursor",
+			},
+
+			expectedError: ErrInvalidCursor,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newQueryTestRepository()
+
+			result, err := repo.QueryArtifacts(tt.query)
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error: %v, got: %v", tt.expectedError, err)
+			}
+			if tt.expectedError != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tt.expectedIDs, artifactIDs(result.Artifacts)); diff != "" {
+				t.Errorf("QueryArtifacts() mismatch (-want +got):\n%s", diff)
+			}
+			if result.Total != tt.expectedTotal {
+				t.Errorf("expected total: %d, got: %d", tt.expectedTotal, result.Total)
+			}
+			if (result.NextCursor != "") != tt.expectedNextCursor {
+				t.Errorf("expected next cursor: %v, got: %q", tt.expectedNextCursor, result.NextCursor)
+			}
+		})
+	}
+}
+
+func TestInMemoryArtifactRepositoryQueryArtifactsCursorPagination(t *testing.T) {
+	repo := newQueryTestRepository()
+	query := ArtifactQuery{
+		SortKey:        SORT_BY_CRIT_VALUE,
+		SortDescending: true,
+		Limit:          3,
+	}
+
+	first, err := repo.QueryArtifacts(query)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"a", "d", "c"}, artifactIDs(first.Artifacts)); diff != "" {
+		t.Fatalf("first page mismatch (-want +got):\n%s", diff)
+	}
+
+	// ページの間に追加・削除があっても、カーソルの位置から重複なく続きを返す
+	if err := repo.SaveArtifact(&entity.Artifact{
+		ID: "e", Substats: []entity.Substat{{Type: entity.SUBSTAT_CRIT_DMG, Value: 6.2}},
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := repo.DeleteArtifactByID("c"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	query.Cursor = first.NextCursor
+	second, err := repo.QueryArtifacts(query)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"b", "e"}, artifactIDs(second.Artifacts)); diff != "" {
+		t.Errorf("second page mismatch (-want +got):\n%s", diff)
+	}
+	if second.NextCursor != "" {
+		t.Errorf("expected no next cursor on last page, got: %q", second.NextCursor)
+	}
+}
@@ -2,17 +2,108 @@ package repository
 
 import "github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
 
+// ArtifactGetter の一覧系メソッドは、該当がなければエラーではなく空のスライスを返す。
+// ErrArtifactNotFound は ID を指定した単一の取得でのみ返す。
 type ArtifactGetter interface {
 	GetArtifactByID(id string) (*entity.Artifact, error)
 	GetArtifactByTypeAndSet(artifactType entity.ArtifactType, artifactSet entity.ArtifactSet) ([]*entity.Artifact, error)
 	GetArtifactByType(artifactType entity.ArtifactType) ([]*entity.Artifact, error)
 	GetArtifactBySet(artifactSet entity.ArtifactSet) ([]*entity.Artifact, error)
+	QueryArtifacts(query ArtifactQuery) (*ArtifactQueryResult, error)
 }
 
+// 聖遺物を保存・更新するメソッドは、一人のキャラクターが同じ部位に二つの聖遺物を装備する状態になる場合、
+// 何も書き込まずに ErrEquipmentSlotOccupied を返す。判定は書き込みと同じロックの中で行う。
 type ArtifactSaver interface {
 	SaveArtifact(artifact *entity.Artifact) error
 }
 
+// ArtifactSaveOptions は保存するときに、書き込みと同じロックの中で追加で行う確認。
+type ArtifactSaveOptions struct {
+	// RejectDuplicates が true の場合、保存済みの聖遺物か、同じ呼び出しで先に保存する聖遺物と内容が同じ聖遺物があれば
+	// 何も保存せずに ErrDuplicateArtifact を返す。判定を書き込みと同じロックの中で行うため、
+	// 同じ内容の聖遺物を同時に保存しても一つしか保存されない。
+	RejectDuplicates bool
+}
+
+// ArtifactBatchSaver は複数の聖遺物をまとめて保存する。
+type ArtifactBatchSaver interface {
+	// SaveArtifacts はいずれかを保存できない場合はどれも保存しない。
+	SaveArtifacts(artifacts []*entity.Artifact, options ArtifactSaveOptions) error
+	// SaveEachArtifact は保存できる聖遺物だけを一度の書き込みで保存し、保存できなかった理由を artifacts と同じ順で返す。
+	// 保存できた聖遺物の理由は nil になる。各聖遺物は、保存済みの聖遺物と、同じ呼び出しで先に保存する聖遺物に対して確かめる。
+	// 保存内容を読み書きできなかった場合は何も保存せずにエラーを返す。
+	SaveEachArtifact(artifacts []*entity.Artifact, options ArtifactSaveOptions) ([]error, error)
+}
+
+type ArtifactUpdater interface {
+	UpdateArtifact(artifact *entity.Artifact) error
+}
+
+// ArtifactBatchUpdater は複数の聖遺物をまとめて更新する。
+// いずれかが存在しないなど更新できない場合はどれも更新しない。
+type ArtifactBatchUpdater interface {
+	UpdateArtifacts(artifacts []*entity.Artifact) error
+}
+
+// ArtifactModifier は保存済みの聖遺物を読んでから書き戻すまでを書き込みロックの中で行い、
+// 部分的な更新が並行する他の更新を取り消さないようにする。
+type ArtifactModifier interface {
+	// ModifyArtifact は modify に現在の聖遺物を渡し、返された聖遺物で更新して返す。
+	// modify がエラーを返した場合は何も書き込まずにそのエラーを返す。
+	// modify はロックを保持したまま呼ばれるため、リポジトリのメソッドを呼んではならない。また current を書き換えないこと。
+	ModifyArtifact(id string, modify func(current *entity.Artifact) (*entity.Artifact, error)) (*entity.Artifact, error)
+}
+
+// ArtifactEquipper は聖遺物の装備を変更する。
+// 読み取りから書き込みまでを書き込みロックの中で行うため、同時に装備を変更しても
+// 一人のキャラクターが同じ部位に二つの聖遺物を装備することはなく、並行する他の更新を取り消すこともない。
+type ArtifactEquipper interface {
+	// EquipArtifact は聖遺物をキャラクターに装備させる。同じ部位に装備していた聖遺物は、
+	// 装備させた聖遺物の元の持ち主に渡し、元の持ち主がいなければ外す。
+	EquipArtifact(id string, character entity.CharacterKey) error
+	// UnequipArtifact はキャラクターがその部位に装備している聖遺物を外す。何も装備していなければ何もしない。
+	UnequipArtifact(character entity.CharacterKey, artifactType entity.ArtifactType) error
+}
+
 type ArtifactDeleter interface {
 	DeleteArtifactByID(id string) error
 }
+
+type ScoreProfileGetter interface {
+	GetScoreProfile(name string) (*entity.ScoreProfile, error)
+	GetScoreProfiles() ([]*entity.ScoreProfile, error)
+}
+
+type ScoreProfileSaver interface {
+	SaveScoreProfile(profile *entity.ScoreProfile) error
+}
+
+type ScoreProfileDeleter interface {
+	DeleteScoreProfile(name string) error
+}
+
+// AccountGetter の GetAccounts は DefaultAccount を含むすべてのアカウント名を昇順で返す。
+// GetAccountArtifacts はアカウントが存在しなければ ErrAccountNotFound を返す。
+type AccountGetter interface {
+	GetAccounts() ([]string, error)
+	GetAccountArtifacts(account string) (ArtifactStore, error)
+}
+
+type AccountCreator interface {
+	CreateAccount(account string) error
+}
+
+// APIKeyGetter の GetAPIKey は API キーが存在しなければ ErrAPIKeyNotFound を返す。
+type APIKeyGetter interface {
+	GetAPIKey(id string) (*entity.APIKey, error)
+	GetAPIKeys() ([]*entity.APIKey, error)
+}
+
+type APIKeySaver interface {
+	SaveAPIKey(key *entity.APIKey) error
+}
+
+type APIKeyDeleter interface {
+	DeleteAPIKey(id string) error
+}
@@ -0,0 +1,1547 @@
+// Package repositorytest は聖遺物リポジトリの実装が満たすべき振る舞いを検証する共通のテストスイート。
+//
+// 新しい保存先を実装した場合は、その実装のテストから Run を呼び出す。
+//
+//	func TestMyArtifactRepositoryConformance(t *testing.T) {
+//		repositorytest.Run(t, func(t *testing.T, dir string) repositorytest.Repository {
+//			return openMyRepository(t, dir)
+//		})
+//	}
+package repositorytest
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/repository"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+// Repository はスイートが検証するリポジトリ。
+// ArtifactUpdater や ArtifactBatchSaver、ArtifactBatchUpdater、ArtifactModifier、ArtifactEquipper も実装していれば、それらのメソッドも検証する。
+type Repository interface {
+	repository.ArtifactGetter
+	repository.ArtifactSaver
+	repository.ArtifactDeleter
+	// Close は変更をすべて永続化してファイルを閉じる。
+	Close() error
+}
+
+// Factory は dir を保存先とするリポジトリを開く。
+// 同じ dir で再び呼ばれた場合は、前回 Close するまでに保存した内容を復元すること。
+type Factory func(t *testing.T, dir string) Repository
+
+// Run はすべての検証をサブテストとして実行する。各サブテストは空の保存先から始まる。
+func Run(t *testing.T, factory Factory) {
+	t.Helper()
+
+	tests := []struct {
+		name string
+		run  func(t *testing.T, factory Factory)
+	}{
+		{"GetArtifactByID", testGetArtifactByID},
+		{"GetArtifactByType", testGetArtifactByType},
+		{"GetArtifactBySet", testGetArtifactBySet},
+		{"GetArtifactByTypeAndSet", testGetArtifactByTypeAndSet},
+		{"QueryArtifacts", testQueryArtifacts},
+		{"QueryArtifactsCursorPagination", testQueryArtifactsCursorPagination},
+		{"SaveArtifact", testSaveArtifact},
+		{"SaveArtifacts", testSaveArtifacts},
+		{"SaveEachArtifact", testSaveEachArtifact},
+		{"UpdateArtifact", testUpdateArtifact},
+		{"UpdateArtifacts", testUpdateArtifacts},
+		{"ModifyArtifact", testModifyArtifact},
+		{"EquipArtifact", testEquipArtifact},
+		{"UnequipArtifact", testUnequipArtifact},
+		{"DeleteArtifactByID", testDeleteArtifactByID},
+		{"ConcurrentSaveAndGet", testConcurrentSaveAndGet},
+		{"ConcurrentSaveSameID", testConcurrentSaveSameID},
+		{"ConcurrentDelete", testConcurrentDelete},
+		{"ConcurrentModify", testConcurrentModify},
+		{"ConcurrentEquip", testConcurrentEquip},
+		{"ConcurrentSaveSameContent", testConcurrentSaveSameContent},
+		{"PersistenceRoundTrip", testPersistenceRoundTrip},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, factory)
+		})
+	}
+}
+
+// open は空の保存先でリポジトリを開き、テスト終了時に閉じる。
+func open(t *testing.T, factory Factory) Repository {
+	t.Helper()
+	repo := factory(t, t.TempDir())
+	t.Cleanup(func() {
+		if err := repo.Close(); err != nil {
+			t.Errorf("failed to close repository: %v", err)
+		}
+	})
+	return repo
+}
+
+// fixtureArtifacts は部位・セット・メインステータス・サブステータスの組み合わせが重ならない聖遺物。
+func fixtureArtifacts() []*entity.Artifact {
+	return []*entity.Artifact{
+		{
+			ID: "a", ArtifactSet: entity.ARTIFACT_SET_GLADIATORS_FINALOFFERING, Type: entity.ARTIFACT_TYPE_FLOWER,
+			Rarity: 5, Level: 20, PrimaryStat: entity.PrimaryStat{Type: entity.HP_FLAT, Value: 4780},
+			Substats: []entity.Substat{{Type: entity.SUBSTAT_CRIT_RATE, Value: 10.5}, {Type: entity.SUBSTAT_CRIT_DMG, Value: 14.0}},
+			Locked:   true,
+		},
+		{
+			ID: "b", ArtifactSet: entity.ARTIFACT_SET_GLADIATORS_FINALOFFERING, Type: entity.ARTIFACT_TYPE_PLUME,
+			Rarity: 5, Level: 16, PrimaryStat: entity.PrimaryStat{Type: entity.ATK_FLAT, Value: 258},
+			Substats: []entity.Substat{{Type: entity.SUBSTAT_CRIT_DMG, Value: 7.0}},
+		},
+		{
+			ID: "c", ArtifactSet: entity.ARTIFACT_SET_WANDERERS_TROUPE, Type: entity.ARTIFACT_TYPE_FLOWER,
+			Rarity: 4, Level: 8, PrimaryStat: entity.PrimaryStat{Type: entity.HP_FLAT, Value: 1893},
+			Substats:   []entity.Substat{{Type: entity.SUBSTAT_ATK_PERCENT, Value: 4.1}},
+			EquippedBy: "Xiangling",
+		},
+		{
+			ID: "d", ArtifactSet: entity.ARTIFACT_SET_NOBLESSE_OBLIGE, Type: entity.ARTIFACT_TYPE_CIRCLET,
+			Rarity: 5, Level: 20, PrimaryStat: entity.PrimaryStat{Type: entity.CRIT_RATE, Value: 31.1},
+			Substats: []entity.Substat{{Type: entity.SUBSTAT_CRIT_DMG, Value: 21.0}},
+		},
+	}
+}
+
+// seed は fixtureArtifacts を保存したリポジトリを返す。
+func seed(t *testing.T, factory Factory) Repository {
+	t.Helper()
+	repo := open(t, factory)
+	for _, artifact := range fixtureArtifacts() {
+		if err := repo.SaveArtifact(artifact); err != nil {
+			t.Fatalf("failed to save artifact %s: %v", artifact.ID, err)
+		}
+	}
+	return repo
+}
+
+// ids は取得順に依存せず比較できるよう ID を昇順に並べて返す。
+func ids(artifacts []*entity.Artifact) []string {
+	result := make([]string, 0, len(artifacts))
+	for _, artifact := range artifacts {
+		result = append(result, artifact.ID)
+	}
+	slices.Sort(result)
+	return result
+}
+
+// diffArtifact は保存前後の聖遺物を比較する。保存先によって空のスライスが nil に戻る場合を許容する。
+func diffArtifact(expected, actual *entity.Artifact) string {
+	return cmp.Diff(expected, actual, cmpopts.EquateEmpty())
+}
+
+// checkList は一覧系のメソッドが、該当がなくても nil ではないスライスを返すことも検証する。
+func checkList(t *testing.T, artifacts []*entity.Artifact, err error, expectedIDs []string) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if artifacts == nil {
+		t.Errorf("expected non-nil slice")
+	}
+	if diff := cmp.Diff(expectedIDs, ids(artifacts)); diff != "" {
+		t.Errorf("IDs mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func testGetArtifactByID(t *testing.T, factory Factory) {
+	fixtures := fixtureArtifacts()
+	tests := []struct {
+		name string
+
+		// WHEN
+		artifactID string
+
+		// THEN
+		expectedArtifact *entity.Artifact
+		expectedError    error
+	}{
+		{
+			name:             "ShouldGetArtifactByIDSuccessfully",
+			artifactID:       "a",
+			expectedArtifact: fixtures[0],
+		},
+		{
+			name:          "ShouldReturnErrArtifactNotFoundWhenArtifactDoesNotExist",
+			artifactID:    "non-existent-id",
+			expectedError: repository.ErrArtifactNotFound,
+		},
+		{
+			name:          "ShouldReturnErrArtifactIDIsEmptyWhenIDIsEmpty",
+			artifactID:    "",
+			expectedError: repository.ErrArtifactIDIsEmpty,
+		},
+	}
+
+	// GIVEN
+	repo := seed(t, factory)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// WHEN
+			artifact, err := repo.GetArtifactByID(tt.artifactID)
+
+			// THEN
+			if !errors.Is(err, tt.expectedError) {
+				t.Errorf("expected error: %v, got: %v", tt.expectedError, err)
+			}
+			if diff := diffArtifact(tt.expectedArtifact, artifact); diff != "" {
+				t.Errorf("artifact mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func testGetArtifactByType(t *testing.T, factory Factory) {
+	tests := []struct {
+		name         string
+		artifactType entity.ArtifactType
+		expectedIDs  []string
+	}{
+		{name: "ShouldGetAllArtifactsOfType", artifactType: entity.ARTIFACT_TYPE_FLOWER, expectedIDs: []string{"a", "c"}},
+		{name: "ShouldReturnEmptySliceWhenNoArtifactMatches", artifactType: entity.ARTIFACT_TYPE_SANDS, expectedIDs: []string{}},
+	}
+
+	repo := seed(t, factory)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			artifacts, err := repo.GetArtifactByType(tt.artifactType)
+			checkList(t, artifacts, err, tt.expectedIDs)
+		})
+	}
+}
+
+func testGetArtifactBySet(t *testing.T, factory Factory) {
+	tests := []struct {
+		name        string
+		artifactSet entity.ArtifactSet
+		expectedIDs []string
+	}{
+		{name: "ShouldGetAllArtifactsOfSet", artifactSet: entity.ARTIFACT_SET_GLADIATORS_FINALOFFERING, expectedIDs: []string{"a", "b"}},
+		{name: "ShouldReturnEmptySliceWhenNoArtifactMatches", artifactSet: entity.ARTIFACT_SET_VIRIDESCENT_VENERER, expectedIDs: []string{}},
+	}
+
+	repo := seed(t, factory)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			artifacts, err := repo.GetArtifactBySet(tt.artifactSet)
+			checkList(t, artifacts, err, tt.expectedIDs)
+		})
+	}
+}
+
+func testGetArtifactByTypeAndSet(t *testing.T, factory Factory) {
+	tests := []struct {
+		name         string
+		artifactType entity.ArtifactType
+		artifactSet  entity.ArtifactSet
+		expectedIDs  []string
+	}{
+		{
+			name:         "ShouldGetArtifactsMatchingBothTypeAndSet",
+			artifactType: entity.ARTIFACT_TYPE_FLOWER, artifactSet: entity.ARTIFACT_SET_GLADIATORS_FINALOFFERING,
+			expectedIDs: []string{"a"},
+		},
+		{
+			name:         "ShouldReturnEmptySliceWhenTypeAndSetDoNotOverlap",
+			artifactType: entity.ARTIFACT_TYPE_CIRCLET, artifactSet: entity.ARTIFACT_SET_GLADIATORS_FINALOFFERING,
+			expectedIDs: []string{},
+		},
+	}
+
+	repo := seed(t, factory)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			artifacts, err := repo.GetArtifactByTypeAndSet(tt.artifactType, tt.artifactSet)
+			checkList(t, artifacts, err, tt.expectedIDs)
+		})
+	}
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func testQueryArtifacts(t *testing.T, factory Factory) {
+	tests := []struct {
+		name string
+
+		// WHEN
+		query repository.ArtifactQuery
+
+		// THEN
+		expectedIDs   []string
+		expectedTotal int
+		expectedError error
+	}{
+		{
+			name:          "ShouldReturnAllArtifactsInIDOrderWithoutConditions",
+			query:         repository.ArtifactQuery{},
+			expectedIDs:   []string{"a", "b", "c", "d"},
+			expectedTotal: 4,
+		},
+		{
+			name: "ShouldCombineIndexedAndUnindexedConditions",
+			query: repository.ArtifactQuery{
+				Types:    []entity.ArtifactType{entity.ARTIFACT_TYPE_FLOWER, entity.ARTIFACT_TYPE_CIRCLET},
+				Rarities: []int{5},
+				MinLevel: intPtr(20),
+			},
+			expectedIDs:   []string{"a", "d"},
+			expectedTotal: 2,
+		},
+		{
+			name: "ShouldFilterBySubstatConditions",
+			query: repository.ArtifactQuery{
+				RequiredSubstats: []entity.SubstatType{entity.SUBSTAT_CRIT_DMG},
+				MinSubstatValues: map[entity.SubstatType]float64{entity.SUBSTAT_CRIT_DMG: 10},
+			},
+			expectedIDs:   []string{"a", "d"},
+			expectedTotal: 2,
+		},
+		{
+			name: "ShouldSortAndPaginate",
+			query: repository.ArtifactQuery{
+				SortKey:        repository.SORT_BY_CRIT_VALUE,
+				SortDescending: true,
+				Limit:          2,
+				Offset:         1,
+			},
+			expectedIDs:   []string{"d", "b"},
+			expectedTotal: 4,
+		},
+		{
+			name: "ShouldReturnEmptyResultWhenNothingMatches",
+			query: repository.ArtifactQuery{
+				Sets: []entity.ArtifactSet{entity.ARTIFACT_SET_VIRIDESCENT_VENERER},
+			},
+			expectedIDs:   []string{},
+			expectedTotal: 0,
+		},
+		{
+			name:          "ShouldFilterByEquippedCharacter",
+			query:         repository.ArtifactQuery{EquippedBy: []entity.CharacterKey{"Xiangling"}},
+			expectedIDs:   []string{"c"},
+			expectedTotal: 1,
+		},
+		{
+			name: "ShouldFilterUnequippedArtifactsByEmptyCharacter",
+			query: repository.ArtifactQuery{
+				Types:      []entity.ArtifactType{entity.ARTIFACT_TYPE_FLOWER},
+				EquippedBy: []entity.CharacterKey{""},
+			},
+			expectedIDs:   []string{"a"},
+			expectedTotal: 1,
+		},
+		{
+			name:          "ShouldReturnErrInvalidArtifactQueryForUnknownSortKey",
+			query:         repository.ArtifactQuery{SortKey: "unknown"},
+			expectedError: repository.ErrInvalidArtifactQuery,
+		},
+		{
+			name:          "ShouldReturnErrInvalidCursorForMalformedCursor",
+			query:         repository.ArtifactQuery{Cursor: "not-a-cursor"},
+			expectedError: repository.ErrInvalidCursor,
+		},
+	}
+
+	// GIVEN
+	repo := seed(t, factory)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// WHEN
+			result, err := repo.QueryArtifacts(tt.query)
+
+			// THEN
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error: %v, got: %v", tt.expectedError, err)
+			}
+			if tt.expectedError != nil {
+				return
+			}
+			// ページングした結果は並び順も含めて比較する
+			gotIDs := make([]string, 0, len(result.Artifacts))
+			for _, artifact := range result.Artifacts {
+				gotIDs = append(gotIDs, artifact.ID)
+			}
+			if diff := cmp.Diff(tt.expectedIDs, gotIDs); diff != "" {
+				t.Errorf("IDs mismatch (-want +got):\n%s", diff)
+			}
+			if result.Total != tt.expectedTotal {
+				t.Errorf("expected total %d, got %d", tt.expectedTotal, result.Total)
+			}
+		})
+	}
+}
+
+func testQueryArtifactsCursorPagination(t *testing.T, factory Factory) {
+	// GIVEN
+	repo := seed(t, factory)
+
+	// WHEN
+	var gotIDs []string
+	query := repository.ArtifactQuery{SortKey: repository.SORT_BY_LEVEL, Limit: 3}
+	for page := 0; ; page++ {
+		if page > 4 {
+			t.Fatalf("pagination did not terminate")
+		}
+		result, err := repo.QueryArtifacts(query)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, artifact := range result.Artifacts {
+			gotIDs = append(gotIDs, artifact.ID)
+		}
+		if result.NextCursor == "" {
+			break
+		}
+		query.Cursor = result.NextCursor
+	}
+
+	// THEN
+	if diff := cmp.Diff([]string{"c", "b", "a", "d"}, gotIDs); diff != "" {
+		t.Errorf("IDs mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func testSaveArtifact(t *testing.T, factory Factory) {
+	tests := []struct {
+		name string
+
+		// GIVEN
+		existing []*entity.Artifact
+
+		// WHEN
+		artifact *entity.Artifact
+
+		// THEN
+		expectedError error
+	}{
+		{
+			name:     "ShouldSaveArtifactSuccessfully",
+			artifact: fixtureArtifacts()[0],
+		},
+		{
+			name:          "ShouldReturnErrArtifactAlreadyExistsWhenIDIsTaken",
+			existing:      []*entity.Artifact{{ID: "a", Type: entity.ARTIFACT_TYPE_SANDS}},
+			artifact:      fixtureArtifacts()[0],
+			expectedError: repository.ErrArtifactAlreadyExists,
+		},
+		{
+			name:     "ShouldSaveArtifactEquippedInOtherSlot",
+			existing: []*entity.Artifact{{ID: "x", Type: entity.ARTIFACT_TYPE_PLUME, EquippedBy: "Diluc"}},
+			artifact: &entity.Artifact{ID: "y", Type: entity.ARTIFACT_TYPE_FLOWER, EquippedBy: "Diluc"},
+		},
+		{
+			name:          "ShouldReturnErrEquipmentSlotOccupiedWhenSlotIsTaken",
+			existing:      []*entity.Artifact{{ID: "x", Type: entity.ARTIFACT_TYPE_FLOWER, EquippedBy: "Diluc"}},
+			artifact:      &entity.Artifact{ID: "y", Type: entity.ARTIFACT_TYPE_FLOWER, EquippedBy: "Diluc"},
+			expectedError: repository.ErrEquipmentSlotOccupied,
+		},
+		{
+			name:          "ShouldReturnErrArtifactIsNilWhenArtifactIsNil",
+			artifact:      nil,
+			expectedError: repository.ErrArtifactIsNil,
+		},
+		{
+			name:          "ShouldReturnErrArtifactIDIsEmptyWhenIDIsEmpty",
+			artifact:      &entity.Artifact{Type: entity.ARTIFACT_TYPE_FLOWER},
+			expectedError: repository.ErrArtifactIDIsEmpty,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			repo := open(t, factory)
+			for _, artifact := range tt.existing {
+				if err := repo.SaveArtifact(artifact); err != nil {
+					t.Fatalf("failed to save artifact: %v", err)
+				}
+			}
+
+			// WHEN
+			err := repo.SaveArtifact(tt.artifact)
+
+			// THEN
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error: %v, got: %v", tt.expectedError, err)
+			}
+			if tt.expectedError != nil {
+				// 失敗した保存で既存の聖遺物が書き換わっていないこと
+				for _, artifact := range tt.existing {
+					got, err := repo.GetArtifactByID(artifact.ID)
+					if err != nil {
+						t.Fatalf("unexpected error: %v", err)
+					}
+					if diff := diffArtifact(artifact, got); diff != "" {
+						t.Errorf("existing artifact changed (-want +got):\n%s", diff)
+					}
+				}
+				return
+			}
+			got, err := repo.GetArtifactByID(tt.artifact.ID)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := diffArtifact(tt.artifact, got); diff != "" {
+				t.Errorf("artifact mismatch (-want +got):\n%s", diff)
+			}
+			byType, err := repo.GetArtifactByType(tt.artifact.Type)
+			checkList(t, byType, err, append(sameType(tt.existing, tt.artifact.Type), tt.artifact.ID))
+		})
+	}
+}
+
+func testSaveArtifacts(t *testing.T, factory Factory) {
+	// sameContentAs は ID と装備を除いて artifact と同じ内容の聖遺物を返す
+	sameContentAs := func(id string, artifact *entity.Artifact) *entity.Artifact {
+		copied := *artifact
+		copied.ID = id
+		copied.EquippedBy = ""
+		return &copied
+	}
+	newArtifact := &entity.Artifact{
+		ArtifactSet: entity.ARTIFACT_SET_VIRIDESCENT_VENERER, Type: entity.ARTIFACT_TYPE_SANDS,
+		Rarity: 5, Level: 0, PrimaryStat: entity.PrimaryStat{Type: entity.ELEMENTAL_MASTERY, Value: 28},
+		Substats: []entity.Substat{{Type: entity.SUBSTAT_CRIT_RATE, Value: 3.9}},
+	}
+
+	tests := []struct {
+		name string
+
+		// WHEN
+		artifacts []*entity.Artifact
+		options   repository.ArtifactSaveOptions
+
+		// THEN
+		expectedIDs   []string
+		expectedError error
+	}{
+		{
+			name:        "ShouldSaveAllArtifacts",
+			artifacts:   []*entity.Artifact{{ID: "new-1"}, {ID: "new-2"}},
+			expectedIDs: []string{"a", "b", "c", "d", "new-1", "new-2"},
+		},
+		{
+			name:          "ShouldSaveNothingWhenAnyIDAlreadyExists",
+			artifacts:     []*entity.Artifact{{ID: "new-1"}, {ID: "a"}},
+			expectedIDs:   []string{"a", "b", "c", "d"},
+			expectedError: repository.ErrArtifactAlreadyExists,
+		},
+		{
+			name:          "ShouldSaveNothingWhenIDIsDuplicatedInBatch",
+			artifacts:     []*entity.Artifact{{ID: "new-1"}, {ID: "new-1"}},
+			expectedIDs:   []string{"a", "b", "c", "d"},
+			expectedError: repository.ErrArtifactAlreadyExists,
+		},
+		{
+			name: "ShouldSaveNothingWhenSlotIsTaken",
+			artifacts: []*entity.Artifact{
+				{ID: "new-1", Type: entity.ARTIFACT_TYPE_PLUME, EquippedBy: "Xiangling"},
+				{ID: "new-2", Type: entity.ARTIFACT_TYPE_FLOWER, EquippedBy: "Xiangling"},
+			},
+			expectedIDs:   []string{"a", "b", "c", "d"},
+			expectedError: repository.ErrEquipmentSlotOccupied,
+		},
+		{
+			name: "ShouldSaveNothingWhenSlotIsDuplicatedInBatch",
+			artifacts: []*entity.Artifact{
+				{ID: "new-1", Type: entity.ARTIFACT_TYPE_PLUME, EquippedBy: "Diluc"},
+				{ID: "new-2", Type: entity.ARTIFACT_TYPE_PLUME, EquippedBy: "Diluc"},
+			},
+			expectedIDs:   []string{"a", "b", "c", "d"},
+			expectedError: repository.ErrEquipmentSlotOccupied,
+		},
+		{
+			name:        "ShouldSaveSameContentWhenDuplicatesAreNotRejected",
+			artifacts:   []*entity.Artifact{sameContentAs("new-1", fixtureArtifacts()[0]), sameContentAs("new-2", newArtifact), sameContentAs("new-3", newArtifact)},
+			expectedIDs: []string{"a", "b", "c", "d", "new-1", "new-2", "new-3"},
+		},
+		{
+			name:        "ShouldSaveDistinctContentWhenDuplicatesAreRejected",
+			artifacts:   []*entity.Artifact{sameContentAs("new-1", newArtifact)},
+			options:     repository.ArtifactSaveOptions{RejectDuplicates: true},
+			expectedIDs: []string{"a", "b", "c", "d", "new-1"},
+		},
+		{
+			name:          "ShouldSaveNothingWhenContentDuplicatesStoredArtifact",
+			artifacts:     []*entity.Artifact{sameContentAs("new-1", newArtifact), sameContentAs("new-2", fixtureArtifacts()[2])},
+			options:       repository.ArtifactSaveOptions{RejectDuplicates: true},
+			expectedIDs:   []string{"a", "b", "c", "d"},
+			expectedError: repository.ErrDuplicateArtifact,
+		},
+		{
+			name:          "ShouldSaveNothingWhenContentIsDuplicatedInBatch",
+			artifacts:     []*entity.Artifact{sameContentAs("new-1", newArtifact), sameContentAs("new-2", newArtifact)},
+			options:       repository.ArtifactSaveOptions{RejectDuplicates: true},
+			expectedIDs:   []string{"a", "b", "c", "d"},
+			expectedError: repository.ErrDuplicateArtifact,
+		},
+		{
+			name:          "ShouldSaveNothingWhenArtifactIsNil",
+			artifacts:     []*entity.Artifact{{ID: "new-1"}, nil},
+			expectedIDs:   []string{"a", "b", "c", "d"},
+			expectedError: repository.ErrArtifactIsNil,
+		},
+		{
+			name:          "ShouldSaveNothingWhenIDIsEmpty",
+			artifacts:     []*entity.Artifact{{ID: "new-1"}, {}},
+			expectedIDs:   []string{"a", "b", "c", "d"},
+			expectedError: repository.ErrArtifactIDIsEmpty,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			repo := seed(t, factory)
+			batchSaver, ok := repo.(repository.ArtifactBatchSaver)
+			if !ok {
+				t.Skip("repository does not implement ArtifactBatchSaver")
+			}
+
+			// WHEN
+			err := batchSaver.SaveArtifacts(tt.artifacts, tt.options)
+
+			// THEN
+			if !errors.Is(err, tt.expectedError) {
+				t.Errorf("expected error: %v, got: %v", tt.expectedError, err)
+			}
+			result, err := repo.QueryArtifacts(repository.ArtifactQuery{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.expectedIDs, ids(result.Artifacts)); diff != "" {
+				t.Errorf("IDs mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func testSaveEachArtifact(t *testing.T, factory Factory) {
+	newArtifact := &entity.Artifact{
+		ArtifactSet: entity.ARTIFACT_SET_VIRIDESCENT_VENERER, Type: entity.ARTIFACT_TYPE_SANDS,
+		Rarity: 5, Level: 0, PrimaryStat: entity.PrimaryStat{Type: entity.ELEMENTAL_MASTERY, Value: 28},
+		Substats: []entity.Substat{{Type: entity.SUBSTAT_CRIT_RATE, Value: 3.9}},
+	}
+	// sameContentAs は ID を除いて artifact と同じ内容の聖遺物を返す
+	sameContentAs := func(id string, artifact *entity.Artifact) *entity.Artifact {
+		copied := *artifact
+		copied.ID = id
+		copied.EquippedBy = ""
+		return &copied
+	}
+
+	tests := []struct {
+		name string
+
+		// WHEN
+		artifacts []*entity.Artifact
+		options   repository.ArtifactSaveOptions
+
+		// THEN
+		expectedErrors []error
+		expectedIDs    []string
+	}{
+		{
+			name:           "ShouldSaveAllArtifacts",
+			artifacts:      []*entity.Artifact{{ID: "new-1"}, {ID: "new-2"}},
+			expectedErrors: []error{nil, nil},
+			expectedIDs:    []string{"a", "b", "c", "d", "new-1", "new-2"},
+		},
+		{
+			name:           "ShouldSaveOthersWhenIDAlreadyExists",
+			artifacts:      []*entity.Artifact{{ID: "new-1"}, {ID: "a"}, {ID: "new-1"}, {ID: "new-2"}},
+			expectedErrors: []error{nil, repository.ErrArtifactAlreadyExists, repository.ErrArtifactAlreadyExists, nil},
+			expectedIDs:    []string{"a", "b", "c", "d", "new-1", "new-2"},
+		},
+		{
+			name:           "ShouldSaveOthersWhenArtifactIsInvalid",
+			artifacts:      []*entity.Artifact{nil, {}, {ID: "new-1"}},
+			expectedErrors: []error{repository.ErrArtifactIsNil, repository.ErrArtifactIDIsEmpty, nil},
+			expectedIDs:    []string{"a", "b", "c", "d", "new-1"},
+		},
+		{
+			name: "ShouldSaveOthersWhenSlotIsTaken",
+			artifacts: []*entity.Artifact{
+				{ID: "new-1", Type: entity.ARTIFACT_TYPE_FLOWER, EquippedBy: "Xiangling"},
+				{ID: "new-2", Type: entity.ARTIFACT_TYPE_PLUME, EquippedBy: "Diluc"},
+				{ID: "new-3", Type: entity.ARTIFACT_TYPE_PLUME, EquippedBy: "Diluc"},
+			},
+			expectedErrors: []error{repository.ErrEquipmentSlotOccupied, nil, repository.ErrEquipmentSlotOccupied},
+			expectedIDs:    []string{"a", "b", "c", "d", "new-2"},
+		},
+		{
+			name:           "ShouldSaveSameContentWhenDuplicatesAreNotRejected",
+			artifacts:      []*entity.Artifact{sameContentAs("new-1", fixtureArtifacts()[0]), sameContentAs("new-2", newArtifact), sameContentAs("new-3", newArtifact)},
+			expectedErrors: []error{nil, nil, nil},
+			expectedIDs:    []string{"a", "b", "c", "d", "new-1", "new-2", "new-3"},
+		},
+		{
+			name:           "ShouldSaveOthersWhenContentIsDuplicated",
+			artifacts:      []*entity.Artifact{sameContentAs("new-1", fixtureArtifacts()[0]), sameContentAs("new-2", newArtifact), sameContentAs("new-3", newArtifact)},
+			options:        repository.ArtifactSaveOptions{RejectDuplicates: true},
+			expectedErrors: []error{repository.ErrDuplicateArtifact, nil, repository.ErrDuplicateArtifact},
+			expectedIDs:    []string{"a", "b", "c", "d", "new-2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			repo := seed(t, factory)
+			batchSaver, ok := repo.(repository.ArtifactBatchSaver)
+			if !ok {
+				t.Skip("repository does not implement ArtifactBatchSaver")
+			}
+
+			// WHEN
+			errs, err := batchSaver.SaveEachArtifact(tt.artifacts, tt.options)
+
+			// THEN
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(errs) != len(tt.expectedErrors) {
+				t.Fatalf("expected %d errors, got %d", len(tt.expectedErrors), len(errs))
+			}
+			for i, expectedError := range tt.expectedErrors {
+				if !errors.Is(errs[i], expectedError) {
+					t.Errorf("expected error at index %d: %v, got: %v", i, expectedError, errs[i])
+				}
+			}
+			result, err := repo.QueryArtifacts(repository.ArtifactQuery{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.expectedIDs, ids(result.Artifacts)); diff != "" {
+				t.Errorf("IDs mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func testUpdateArtifact(t *testing.T, factory Factory) {
+	updated := fixtureArtifacts()[0]
+	updated.ArtifactSet = entity.ARTIFACT_SET_VIRIDESCENT_VENERER
+	updated.Level = 20
+	updated.Substats = []entity.Substat{{Type: entity.SUBSTAT_ELEMENTAL_MASTERY, Value: 23}}
+	conflicting := fixtureArtifacts()[0]
+	conflicting.EquippedBy = "Xiangling"
+
+	tests := []struct {
+		name string
+
+		// WHEN
+		artifact *entity.Artifact
+
+		// THEN
+		expectedError error
+	}{
+		{
+			name:     "ShouldUpdateArtifactAndIndexes",
+			artifact: updated,
+		},
+		{
+			name:          "ShouldReturnErrArtifactNotFoundWhenArtifactDoesNotExist",
+			artifact:      &entity.Artifact{ID: "non-existent-id"},
+			expectedError: repository.ErrArtifactNotFound,
+		},
+		{
+			name:          "ShouldReturnErrEquipmentSlotOccupiedWhenSlotIsTaken",
+			artifact:      conflicting,
+			expectedError: repository.ErrEquipmentSlotOccupied,
+		},
+		{
+			name:          "ShouldReturnErrArtifactIsNilWhenArtifactIsNil",
+			artifact:      nil,
+			expectedError: repository.ErrArtifactIsNil,
+		},
+		{
+			name:          "ShouldReturnErrArtifactIDIsEmptyWhenIDIsEmpty",
+			artifact:      &entity.Artifact{},
+			expectedError: repository.ErrArtifactIDIsEmpty,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			repo := seed(t, factory)
+			updater, ok := repo.(repository.ArtifactUpdater)
+			if !ok {
+				t.Skip("repository does not implement ArtifactUpdater")
+			}
+
+			// WHEN
+			err := updater.UpdateArtifact(tt.artifact)
+
+			// THEN
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error: %v, got: %v", tt.expectedError, err)
+			}
+			if tt.expectedError != nil {
+				return
+			}
+			got, err := repo.GetArtifactByID(tt.artifact.ID)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := diffArtifact(tt.artifact, got); diff != "" {
+				t.Errorf("artifact mismatch (-want +got):\n%s", diff)
+			}
+
+			// 索引も更新後の値に追従していること
+			oldSet, err := repo.GetArtifactBySet(entity.ARTIFACT_SET_GLADIATORS_FINALOFFERING)
+			checkList(t, oldSet, err, []string{"b"})
+			newSet, err := repo.GetArtifactBySet(entity.ARTIFACT_SET_VIRIDESCENT_VENERER)
+			checkList(t, newSet, err, []string{"a"})
+			result, err := repo.QueryArtifacts(repository.ArtifactQuery{RequiredSubstats: []entity.SubstatType{entity.SUBSTAT_CRIT_RATE}})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			checkList(t, result.Artifacts, nil, []string{})
+		})
+	}
+}
+
+func testUpdateArtifacts(t *testing.T, factory Factory) {
+	fixtures := fixtureArtifacts()
+	unequipped := *fixtures[2]
+	unequipped.EquippedBy = ""
+	equipped := *fixtures[0]
+	equipped.EquippedBy = "Xiangling"
+
+	tests := []struct {
+		name string
+
+		// WHEN
+		artifacts []*entity.Artifact
+
+		// THEN
+		expectedEquippedIDs []string
+		expectedError       error
+	}{
+		{
+			name:                "ShouldUpdateAllArtifactsAndIndexes",
+			artifacts:           []*entity.Artifact{&unequipped, &equipped},
+			expectedEquippedIDs: []string{"a"},
+		},
+		{
+			name:                "ShouldUpdateNothingWhenSlotIsTaken",
+			artifacts:           []*entity.Artifact{&equipped},
+			expectedEquippedIDs: []string{"c"},
+			expectedError:       repository.ErrEquipmentSlotOccupied,
+		},
+		{
+			name:                "ShouldUpdateNothingWhenAnyArtifactDoesNotExist",
+			artifacts:           []*entity.Artifact{&unequipped, {ID: "non-existent-id"}},
+			expectedEquippedIDs: []string{"c"},
+			expectedError:       repository.ErrArtifactNotFound,
+		},
+		{
+			name:                "ShouldUpdateNothingWhenIDIsDuplicatedInBatch",
+			artifacts:           []*entity.Artifact{&unequipped, &unequipped},
+			expectedEquippedIDs: []string{"c"},
+			expectedError:       repository.ErrArtifactIDDuplicated,
+		},
+		{
+			name:                "ShouldUpdateNothingWhenArtifactIsNil",
+			artifacts:           []*entity.Artifact{&unequipped, nil},
+			expectedEquippedIDs: []string{"c"},
+			expectedError:       repository.ErrArtifactIsNil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			repo := seed(t, factory)
+			batchUpdater, ok := repo.(repository.ArtifactBatchUpdater)
+			if !ok {
+				t.Skip("repository does not implement ArtifactBatchUpdater")
+			}
+
+			// WHEN
+			err := batchUpdater.UpdateArtifacts(tt.artifacts)
+
+			// THEN
+			if !errors.Is(err, tt.expectedError) {
+				t.Errorf("expected error: %v, got: %v", tt.expectedError, err)
+			}
+			result, err := repo.QueryArtifacts(repository.ArtifactQuery{EquippedBy: []entity.CharacterKey{"Xiangling"}})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.expectedEquippedIDs, ids(result.Artifacts)); diff != "" {
+				t.Errorf("IDs mismatch (-want +got):\n%s", diff)
+			}
+			// 索引も更新後の値に追従していること
+			flowers, err := repo.GetArtifactByType(entity.ARTIFACT_TYPE_FLOWER)
+			checkList(t, flowers, err, []string{"a", "c"})
+		})
+	}
+}
+
+func testModifyArtifact(t *testing.T, factory Factory) {
+	errModify := errors.New("modify failed")
+
+	tests := []struct {
+		name string
+
+		// WHEN
+		artifactID string
+		modify     func(current *entity.Artifact) (*entity.Artifact, error)
+
+		// THEN
+		expectedLevel int
+		expectedError error
+	}{
+		{
+			name:       "ShouldUpdateArtifactReturnedByModify",
+			artifactID: "b",
+			modify: func(current *entity.Artifact) (*entity.Artifact, error) {
+				modified := *current
+				modified.Level++
+				return &modified, nil
+			},
+			expectedLevel: 17,
+		},
+		{
+			name:       "ShouldUpdateNothingWhenModifyFails",
+			artifactID: "b",
+			modify: func(current *entity.Artifact) (*entity.Artifact, error) {
+				return nil, errModify
+			},
+			expectedLevel: 16,
+			expectedError: errModify,
+		},
+		{
+			name:       "ShouldReturnErrEquipmentSlotOccupiedWhenSlotIsTaken",
+			artifactID: "a",
+			modify: func(current *entity.Artifact) (*entity.Artifact, error) {
+				modified := *current
+				modified.EquippedBy = "Xiangling"
+				return &modified, nil
+			},
+			expectedLevel: 16,
+			expectedError: repository.ErrEquipmentSlotOccupied,
+		},
+		{
+			name:       "ShouldReturnErrArtifactIsNilWhenModifyReturnsNil",
+			artifactID: "b",
+			modify: func(current *entity.Artifact) (*entity.Artifact, error) {
+				return nil, nil
+			},
+			expectedLevel: 16,
+			expectedError: repository.ErrArtifactIsNil,
+		},
+		{
+			name:       "ShouldReturnErrArtifactNotFoundWhenArtifactDoesNotExist",
+			artifactID: "non-existent-id",
+			modify: func(current *entity.Artifact) (*entity.Artifact, error) {
+				return current, nil
+			},
+			expectedLevel: 16,
+			expectedError: repository.ErrArtifactNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			repo := seed(t, factory)
+			modifier, ok := repo.(repository.ArtifactModifier)
+			if !ok {
+				t.Skip("repository does not implement ArtifactModifier")
+			}
+
+			// WHEN
+			_, err := modifier.ModifyArtifact(tt.artifactID, tt.modify)
+
+			// THEN
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error: %v, got: %v", tt.expectedError, err)
+			}
+			got, err := repo.GetArtifactByID("b")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Level != tt.expectedLevel {
+				t.Errorf("expected level %d, got %d", tt.expectedLevel, got.Level)
+			}
+			equipped, err := repo.QueryArtifacts(repository.ArtifactQuery{EquippedBy: []entity.CharacterKey{"Xiangling"}})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			checkList(t, equipped.Artifacts, nil, []string{"c"})
+		})
+	}
+}
+
+// equippedIDs はキャラクターごとに装備している聖遺物の ID を返す。
+func equippedIDs(t *testing.T, repo Repository, characters ...entity.CharacterKey) map[entity.CharacterKey][]string {
+	t.Helper()
+	result := make(map[entity.CharacterKey][]string, len(characters))
+	for _, character := range characters {
+		equipped, err := repo.QueryArtifacts(repository.ArtifactQuery{EquippedBy: []entity.CharacterKey{character}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		result[character] = ids(equipped.Artifacts)
+	}
+	return result
+}
+
+// sameType は artifacts のうち部位が artifactType の聖遺物の ID を返す。
+func sameType(artifacts []*entity.Artifact, artifactType entity.ArtifactType) []string {
+	result := []string{}
+	for _, artifact := range artifacts {
+		if artifact.Type == artifactType {
+			result = append(result, artifact.ID)
+		}
+	}
+	return result
+}
+
+func testEquipArtifact(t *testing.T, factory Factory) {
+	tests := []struct {
+		name string
+
+		// GIVEN
+		existing []*entity.Artifact
+
+		// WHEN
+		artifactID string
+		character  entity.CharacterKey
+
+		// THEN
+		expectedEquippedIDs map[entity.CharacterKey][]string
+		expectedError       error
+	}{
+		{
+			name:       "ShouldEquipArtifactInEmptySlot",
+			artifactID: "b",
+			character:  "Xiangling",
+			expectedEquippedIDs: map[entity.CharacterKey][]string{
+				"Xiangling": {"b", "c"},
+				"Diluc":     {},
+			},
+		},
+		{
+			name:       "ShouldUnequipOccupantWhenArtifactHadNoOwner",
+			artifactID: "a",
+			character:  "Xiangling",
+			expectedEquippedIDs: map[entity.CharacterKey][]string{
+				"Xiangling": {"a"},
+				"Diluc":     {},
+			},
+		},
+		{
+			name:       "ShouldSwapOccupantWithPreviousOwner",
+			existing:   []*entity.Artifact{{ID: "e", Type: entity.ARTIFACT_TYPE_FLOWER, EquippedBy: "Diluc"}},
+			artifactID: "e",
+			character:  "Xiangling",
+			expectedEquippedIDs: map[entity.CharacterKey][]string{
+				"Xiangling": {"e"},
+				"Diluc":     {"c"},
+			},
+		},
+		{
+			name:       "ShouldDoNothingWhenArtifactIsAlreadyEquipped",
+			artifactID: "c",
+			character:  "Xiangling",
+			expectedEquippedIDs: map[entity.CharacterKey][]string{
+				"Xiangling": {"c"},
+				"Diluc":     {},
+			},
+		},
+		{
+			name:       "ShouldReturnErrArtifactNotFoundWhenArtifactDoesNotExist",
+			artifactID: "non-existent-id",
+			character:  "Xiangling",
+			expectedEquippedIDs: map[entity.CharacterKey][]string{
+				"Xiangling": {"c"},
+				"Diluc":     {},
+			},
+			expectedError: repository.ErrArtifactNotFound,
+		},
+		{
+			name:       "ShouldReturnErrArtifactIDIsEmptyWhenIDIsEmpty",
+			artifactID: "",
+			character:  "Xiangling",
+			expectedEquippedIDs: map[entity.CharacterKey][]string{
+				"Xiangling": {"c"},
+				"Diluc":     {},
+			},
+			expectedError: repository.ErrArtifactIDIsEmpty,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			repo := seed(t, factory)
+			equipper, ok := repo.(repository.ArtifactEquipper)
+			if !ok {
+				t.Skip("repository does not implement ArtifactEquipper")
+			}
+			for _, artifact := range tt.existing {
+				if err := repo.SaveArtifact(artifact); err != nil {
+					t.Fatalf("failed to save artifact: %v", err)
+				}
+			}
+
+			// WHEN
+			err := equipper.EquipArtifact(tt.artifactID, tt.character)
+
+			// THEN
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error: %v, got: %v", tt.expectedError, err)
+			}
+			if diff := cmp.Diff(tt.expectedEquippedIDs, equippedIDs(t, repo, "Xiangling", "Diluc")); diff != "" {
+				t.Errorf("equipped IDs mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func testUnequipArtifact(t *testing.T, factory Factory) {
+	tests := []struct {
+		name string
+
+		// WHEN
+		character    entity.CharacterKey
+		artifactType entity.ArtifactType
+
+		// THEN
+		expectedEquippedIDs []string
+	}{
+		{
+			name:                "ShouldUnequipArtifactInSlot",
+			character:           "Xiangling",
+			artifactType:        entity.ARTIFACT_TYPE_FLOWER,
+			expectedEquippedIDs: []string{},
+		},
+		{
+			name:                "ShouldDoNothingWhenSlotIsEmpty",
+			character:           "Xiangling",
+			artifactType:        entity.ARTIFACT_TYPE_PLUME,
+			expectedEquippedIDs: []string{"c"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			repo := seed(t, factory)
+			equipper, ok := repo.(repository.ArtifactEquipper)
+			if !ok {
+				t.Skip("repository does not implement ArtifactEquipper")
+			}
+
+			// WHEN
+			err := equipper.UnequipArtifact(tt.character, tt.artifactType)
+
+			// THEN
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.expectedEquippedIDs, equippedIDs(t, repo, tt.character)[tt.character]); diff != "" {
+				t.Errorf("equipped IDs mismatch (-want +got):\n%s", diff)
+			}
+			// 外した聖遺物も残っていること
+			flowers, err := repo.GetArtifactByType(entity.ARTIFACT_TYPE_FLOWER)
+			checkList(t, flowers, err, []string{"a", "c"})
+		})
+	}
+}
+
+func testDeleteArtifactByID(t *testing.T, factory Factory) {
+	tests := []struct {
+		name string
+
+		// WHEN
+		artifactID string
+
+		// THEN
+		expectedIDs   []string
+		expectedError error
+	}{
+		{
+			name:        "ShouldDeleteArtifactSuccessfully",
+			artifactID:  "a",
+			expectedIDs: []string{"c"},
+		},
+		{
+			name:          "ShouldReturnErrArtifactNotFoundWhenArtifactDoesNotExist",
+			artifactID:    "non-existent-id",
+			expectedIDs:   []string{"a", "c"},
+			expectedError: repository.ErrArtifactNotFound,
+		},
+		{
+			name:          "ShouldReturnErrArtifactIDIsEmptyWhenIDIsEmpty",
+			artifactID:    "",
+			expectedIDs:   []string{"a", "c"},
+			expectedError: repository.ErrArtifactIDIsEmpty,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			repo := seed(t, factory)
+
+			// WHEN
+			err := repo.DeleteArtifactByID(tt.artifactID)
+
+			// THEN
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error: %v, got: %v", tt.expectedError, err)
+			}
+			if tt.expectedError == nil {
+				if _, err := repo.GetArtifactByID(tt.artifactID); !errors.Is(err, repository.ErrArtifactNotFound) {
+					t.Errorf("expected error: %v, got: %v", repository.ErrArtifactNotFound, err)
+				}
+			}
+			flowers, err := repo.GetArtifactByType(entity.ARTIFACT_TYPE_FLOWER)
+			checkList(t, flowers, err, tt.expectedIDs)
+		})
+	}
+}
+
+func testConcurrentSaveAndGet(t *testing.T, factory Factory) {
+	const writers = 8
+	const artifactsPerWriter = 50
+
+	repo := open(t, factory)
+
+	var wg sync.WaitGroup
+	for w := range writers {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := range artifactsPerWriter {
+				artifact := &entity.Artifact{
+					ID:          fmt.Sprintf("writer-%d-%d", w, i),
+					ArtifactSet: entity.ARTIFACT_SET_GLADIATORS_FINALOFFERING,
+					Type:        entity.ARTIFACT_TYPE_FLOWER,
+				}
+				if err := repo.SaveArtifact(artifact); err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := range artifactsPerWriter {
+				_, _ = repo.GetArtifactByID(fmt.Sprintf("writer-0-%d", i))
+				_, _ = repo.GetArtifactByTypeAndSet(entity.ARTIFACT_TYPE_FLOWER, entity.ARTIFACT_SET_GLADIATORS_FINALOFFERING)
+				if _, err := repo.QueryArtifacts(repository.ArtifactQuery{Types: []entity.ArtifactType{entity.ARTIFACT_TYPE_FLOWER}}); err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	result, err := repo.GetArtifactByType(entity.ARTIFACT_TYPE_FLOWER)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result) != writers*artifactsPerWriter {
+		t.Errorf("expected %d artifacts, got %d", writers*artifactsPerWriter, len(result))
+	}
+}
+
+func testConcurrentSaveSameID(t *testing.T, factory Factory) {
+	const goroutines = 32
+
+	repo := open(t, factory)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	succeeded := 0
+	for range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := repo.SaveArtifact(&entity.Artifact{ID: "same-id"})
+			if err == nil {
+				mu.Lock()
+				succeeded++
+				mu.Unlock()
+				return
+			}
+			if !errors.Is(err, repository.ErrArtifactAlreadyExists) {
+				t.Errorf("expected error: %v, got: %v", repository.ErrArtifactAlreadyExists, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if succeeded != 1 {
+		t.Errorf("expected exactly 1 successful save, got %d", succeeded)
+	}
+}
+
+func testConcurrentDelete(t *testing.T, factory Factory) {
+	const count = 100
+
+	repo := open(t, factory)
+	for i := range count {
+		if err := repo.SaveArtifact(&entity.Artifact{ID: fmt.Sprintf("test-id-%d", i), Type: entity.ARTIFACT_TYPE_FLOWER}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// 同じ ID の削除が 2 回ずつ競合し、どちらか一方だけが成功する
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	deleted := 0
+	for i := range count * 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := repo.DeleteArtifactByID(fmt.Sprintf("test-id-%d", i/2))
+			if err == nil {
+				mu.Lock()
+				deleted++
+				mu.Unlock()
+				return
+			}
+			if !errors.Is(err, repository.ErrArtifactNotFound) {
+				t.Errorf("expected error: %v, got: %v", repository.ErrArtifactNotFound, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if deleted != count {
+		t.Errorf("expected %d successful deletes, got %d", count, deleted)
+	}
+	artifacts, err := repo.GetArtifactByType(entity.ARTIFACT_TYPE_FLOWER)
+	checkList(t, artifacts, err, []string{})
+}
+
+// testConcurrentModify は同じ聖遺物を同時に読んで書き戻しても、どの変更も失われないことを確かめる。
+func testConcurrentModify(t *testing.T, factory Factory) {
+	const goroutines = 32
+
+	repo := open(t, factory)
+	modifier, ok := repo.(repository.ArtifactModifier)
+	if !ok {
+		t.Skip("repository does not implement ArtifactModifier")
+	}
+	if err := repo.SaveArtifact(&entity.Artifact{ID: "counter", Type: entity.ARTIFACT_TYPE_FLOWER}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := modifier.ModifyArtifact("counter", func(current *entity.Artifact) (*entity.Artifact, error) {
+				modified := *current
+				modified.Level++
+				return &modified, nil
+			})
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	got, err := repo.GetArtifactByID("counter")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Level != goroutines {
+		t.Errorf("expected level %d, got %d", goroutines, got.Level)
+	}
+}
+
+// testConcurrentEquip は装備の変更と装備した聖遺物の保存を同時に行っても、
+// 一人のキャラクターが同じ部位に二つの聖遺物を装備しないことを確かめる。-race を付けて実行すること。
+func testConcurrentEquip(t *testing.T, factory Factory) {
+	const flowers = 8
+	const goroutines = 16
+	const equipsPerGoroutine = 20
+	characters := []entity.CharacterKey{"Diluc", "Xiangling", "Bennett", "RaidenShogun"}
+
+	repo := open(t, factory)
+	equipper, ok := repo.(repository.ArtifactEquipper)
+	if !ok {
+		t.Skip("repository does not implement ArtifactEquipper")
+	}
+	for i := range flowers {
+		if err := repo.SaveArtifact(&entity.Artifact{ID: fmt.Sprintf("flower-%d", i), Type: entity.ARTIFACT_TYPE_FLOWER}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	var wg sync.WaitGroup
+	for g := range goroutines {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := range equipsPerGoroutine {
+				id := fmt.Sprintf("flower-%d", (g+i)%flowers)
+				if err := equipper.EquipArtifact(id, characters[(g*i+g)%len(characters)]); err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			// 空いている部位にだけ保存でき、埋まっていれば ErrEquipmentSlotOccupied になる
+			err := repo.SaveArtifact(&entity.Artifact{
+				ID:         fmt.Sprintf("saved-flower-%d", g),
+				Type:       entity.ARTIFACT_TYPE_FLOWER,
+				EquippedBy: characters[g%len(characters)],
+			})
+			if err != nil && !errors.Is(err, repository.ErrEquipmentSlotOccupied) {
+				t.Errorf("expected error: %v, got: %v", repository.ErrEquipmentSlotOccupied, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for character, equipped := range equippedIDs(t, repo, characters...) {
+		if len(equipped) > 1 {
+			t.Errorf("%s equips %d flowers: %v", character, len(equipped), equipped)
+		}
+	}
+}
+
+func testPersistenceRoundTrip(t *testing.T, factory Factory) {
+	// GIVEN
+	dir := t.TempDir()
+	repo := factory(t, dir)
+	fixtures := fixtureArtifacts()
+	for _, artifact := range fixtures {
+		if err := repo.SaveArtifact(artifact); err != nil {
+			t.Fatalf("failed to save artifact %s: %v", artifact.ID, err)
+		}
+	}
+	if err := repo.DeleteArtifactByID("b"); err != nil {
+		t.Fatalf("failed to delete artifact: %v", err)
+	}
+	expected := []*entity.Artifact{fixtures[0], fixtures[2], fixtures[3]}
+	if updater, ok := repo.(repository.ArtifactUpdater); ok {
+		updated := *fixtures[2]
+		updated.Level = 12
+		updated.EquippedBy = ""
+		if err := updater.UpdateArtifact(&updated); err != nil {
+			t.Fatalf("failed to update artifact: %v", err)
+		}
+		expected[1] = &updated
+	}
+	if batchUpdater, ok := repo.(repository.ArtifactBatchUpdater); ok {
+		updated := *fixtures[3]
+		updated.EquippedBy = "Xiangling"
+		if err := batchUpdater.UpdateArtifacts([]*entity.Artifact{&updated}); err != nil {
+			t.Fatalf("failed to update artifacts: %v", err)
+		}
+		expected[2] = &updated
+	}
+	if batchSaver, ok := repo.(repository.ArtifactBatchSaver); ok {
+		saved := &entity.Artifact{
+			ID: "e", ArtifactSet: entity.ARTIFACT_SET_VIRIDESCENT_VENERER, Type: entity.ARTIFACT_TYPE_SANDS,
+			Rarity: 5, Level: 0, PrimaryStat: entity.PrimaryStat{Type: entity.ELEMENTAL_MASTERY, Value: 28},
+			Substats: []entity.Substat{{Type: entity.SUBSTAT_CRIT_RATE, Value: 3.9}},
+		}
+		errs, err := batchSaver.SaveEachArtifact([]*entity.Artifact{saved, {ID: "a"}}, repository.ArtifactSaveOptions{})
+		if err != nil || errs[0] != nil {
+			t.Fatalf("failed to save artifacts: %v, %v", err, errs)
+		}
+		expected = append(expected, saved)
+	}
+
+	// WHEN
+	if err := repo.Close(); err != nil {
+		t.Fatalf("failed to close repository: %v", err)
+	}
+	reopened := factory(t, dir)
+	t.Cleanup(func() {
+		if err := reopened.Close(); err != nil {
+			t.Errorf("failed to close repository: %v", err)
+		}
+	})
+
+	// THEN
+	result, err := reopened.QueryArtifacts(repository.ArtifactQuery{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(expected, result.Artifacts, cmpopts.EquateEmpty()); diff != "" {
+		t.Errorf("artifacts mismatch (-want +got):\n%s", diff)
+	}
+	// 索引も復元されていること
+	flowers, err := reopened.GetArtifactByType(entity.ARTIFACT_TYPE_FLOWER)
+	checkList(t, flowers, err, []string{"a", "c"})
+	plumes, err := reopened.GetArtifactByType(entity.ARTIFACT_TYPE_PLUME)
+	checkList(t, plumes, err, []string{})
+}
+
+func testConcurrentSaveSameContent(t *testing.T, factory Factory) {
+	const goroutines = 32
+
+	repo := open(t, factory)
+	batchSaver, ok := repo.(repository.ArtifactBatchSaver)
+	if !ok {
+		t.Skip("repository does not implement ArtifactBatchSaver")
+	}
+
+	var wg sync.WaitGroup
+	var saved atomic.Int32
+	for g := range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// ID だけが異なる同じ内容の聖遺物は、重複を拒否すると一つしか保存できない
+			err := batchSaver.SaveArtifacts([]*entity.Artifact{{
+				ID:          fmt.Sprintf("flower-%d", g),
+				ArtifactSet: entity.ARTIFACT_SET_GLADIATORS_FINALOFFERING,
+				Type:        entity.ARTIFACT_TYPE_FLOWER,
+				Rarity:      5,
+				PrimaryStat: entity.PrimaryStat{Type: entity.HP_FLAT, Value: 717},
+				Substats:    []entity.Substat{{Type: entity.SUBSTAT_CRIT_RATE, Value: 3.9}},
+			}}, repository.ArtifactSaveOptions{RejectDuplicates: true})
+			switch {
+			case err == nil:
+				saved.Add(1)
+			case !errors.Is(err, repository.ErrDuplicateArtifact):
+				t.Errorf("expected error: %v, got: %v", repository.ErrDuplicateArtifact, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := saved.Load(); got != 1 {
+		t.Errorf("expected exactly one artifact to be saved, got %d", got)
+	}
+	result, err := repo.QueryArtifacts(repository.ArtifactQuery{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Artifacts) != 1 {
+		t.Errorf("expected 1 stored artifact, got %d", len(result.Artifacts))
+	}
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	backupSuffix          = ".bak"
+	backupTimestampLayout = "20060102T150405.000000000Z"
+)
+
+var ErrNoValidSnapshot = errors.New("no valid snapshot or backup found")
+
+// writeFileAtomic は同じディレクトリの一時ファイルへ書き込み、fsync してから
+// rename で置き換える。途中でクラッシュしても既存のファイルは壊れない。
+func writeFileAtomic(filename string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(filename)
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(filename)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+	defer func() {
+		// rename に成功していれば一時ファイルは既に存在しない
+		_ = os.Remove(tmpName)
+	}()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmpName, filename); err != nil {
+		return err
+	}
+	return syncDir(dir)
+}
+
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = d.Close() }()
+
+	return d.Sync()
+}
+
+func backupPath(filename string, now time.Time) string {
+	return fmt.Sprintf("%s.%s%s", filename, now.UTC().Format(backupTimestampLayout), backupSuffix)
+}
+
+// listBackups は filename のバックアップを新しい順に返す。
+func listBackups(filename string) ([]string, error) {
+	matches, err := filepath.Glob(filename + ".*" + backupSuffix)
+	if err != nil {
+		return nil, err
+	}
+
+	backups := make([]string, 0, len(matches))
+	for _, match := range matches {
+		timestamp := strings.TrimSuffix(strings.TrimPrefix(match, filename+"."), backupSuffix)
+		if _, err := time.Parse(backupTimestampLayout, timestamp); err != nil {
+			continue
+		}
+		backups = append(backups, match)
+	}
+
+	sort.Sort(sort.Reverse(sort.StringSlice(backups)))
+	return backups, nil
+}
+
+// rotateBackups は現在のファイルをタイムスタンプ付きのバックアップとして残し、
+// 新しいものから keep 件を超えた古いバックアップを削除する。
+func rotateBackups(filename string, keep int, now time.Time) error {
+	if keep <= 0 {
+		return nil
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	if err := writeFileAtomic(backupPath(filename, now), data, 0644); err != nil {
+		return err
+	}
+
+	backups, err := listBackups(filename)
+	if err != nil {
+		return err
+	}
+	for _, old := range backups[min(keep, len(backups)):] {
+		if err := os.Remove(old); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
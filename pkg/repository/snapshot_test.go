@@ -0,0 +1,239 @@
+package repository
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	tmpDir := t.TempDir()
+	filename := filepath.Join(tmpDir, "artifacts.json")
+
+	if err := os.WriteFile(filename, []byte("old"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	if err := writeFileAtomic(filename, []byte("new"), 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(data) != "new" {
+		t.Errorf("expected content %q, got %q", "new", string(data))
+	}
+
+	entries, err := os.ReadDir(tmpDir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, entry := range entries {
+		if strings.Contains(entry.Name(), ".tmp-") {
+			t.Errorf("temporary file should be removed: %s", entry.Name())
+		}
+	}
+}
+
+func TestRotateBackups(t *testing.T) {
+	tests := []struct {
+		name string
+
+		// GIVEN
+		keep   int
+		writes int
+
+		// THEN
+		expectedBackups int
+	}{
+		{
+			name: "ShouldKeepOnlyConfiguredNumberOfBackups",
+
+			keep:   3,
+			writes: 6,
+
+			expectedBackups: 3,
+		},
+		{
+			name: "ShouldKeepAllBackupsWhenFewerThanLimit",
+
+			keep:   5,
+			writes: 3,
+
+			// 初回は既存ファイルがないためバックアップされない
+			expectedBackups: 2,
+		},
+		{
+			name: "ShouldNotBackupWhenDisabled",
+
+			keep:   -1,
+			writes: 3,
+
+			expectedBackups: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), "artifacts.json")
+			now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+			for i := 0; i < tt.writes; i++ {
+				if err := rotateBackups(filename, tt.keep, now.Add(time.Duration(i)*time.Second)); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if err := writeFileAtomic(filename, []byte(`{"artifacts":{}}`), 0644); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+
+			backups, err := listBackups(filename)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(backups) != tt.expectedBackups {
+				t.Errorf("expected %d backups, got %d", tt.expectedBackups, len(backups))
+			}
+
+			for i := 1; i < len(backups); i++ {
+				if backups[i-1] < backups[i] {
+					t.Errorf("backups should be sorted newest first: %v", backups)
+				}
+			}
+		})
+	}
+}
+
+func TestInMemoryArtifactRepositoryRestoreJSONFile(t *testing.T) {
+	validSnapshot := `{"artifacts":{"snapshot-id":{"ID":"snapshot-id"}}}`
+	validBackup := `{"artifacts":{"backup-id":{"ID":"backup-id"}}}`
+	olderBackup := `{"artifacts":{"older-id":{"ID":"older-id"}}}`
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+
+		// GIVEN
+		snapshot *string
+		backups  []string // 古い順
+
+		// THEN
+		expectedID         string
+		expectedFromBackup bool
+		expectedError      error
+	}{
+		{
+			name: "ShouldRestoreFromSnapshot",
+
+			snapshot: &validSnapshot,
+			backups:  []string{validBackup},
+
+			expectedID: "snapshot-id",
+		},
+		{
+			name: "ShouldFallBackToNewestValidBackupWhenSnapshotIsTruncated",
+
+			snapshot: func() *string { s := `{"artifacts":{"snaps`; return &s }(),
+			backups:  []string{olderBackup, validBackup, `{"broken`},
+
+			expectedID:         "backup-id",
+			expectedFromBackup: true,
+		},
+		{
+			name: "ShouldFallBackToBackupWhenSnapshotIsMissing",
+
+			snapshot: nil,
+			backups:  []string{validBackup},
+
+			expectedID:         "backup-id",
+			expectedFromBackup: true,
+		},
+		{
+			name: "ShouldReturnErrorWhenNoValidSnapshotExists",
+
+			snapshot: func() *string { s := `{"broken`; return &s }(),
+			backups:  []string{`{"broken`},
+
+			expectedError: ErrNoValidSnapshot,
+		},
+		{
+			name: "ShouldReturnNotExistWhenNothingIsStored",
+
+			expectedError: os.ErrNotExist,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), "artifacts.json")
+			if tt.snapshot != nil {
+				if err := os.WriteFile(filename, []byte(*tt.snapshot), 0644); err != nil {
+					t.Fatalf("failed to write snapshot: %v", err)
+				}
+			}
+			for i, backup := range tt.backups {
+				path := backupPath(filename, now.Add(time.Duration(i)*time.Second))
+				if err := os.WriteFile(path, []byte(backup), 0644); err != nil {
+					t.Fatalf("failed to write backup: %v", err)
+				}
+			}
+
+			repo := NewInMemoryArtifactRepository()
+			loadedFrom, err := repo.RestoreJSONFile(filename)
+
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error: %v, got: %v", tt.expectedError, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if (loadedFrom != filename) != tt.expectedFromBackup {
+				t.Errorf("expected restored from backup: %v, got path %s", tt.expectedFromBackup, loadedFrom)
+			}
+			if _, err := repo.GetArtifactByID(tt.expectedID); err != nil {
+				t.Errorf("expected artifact %s to exist, got error: %v", tt.expectedID, err)
+			}
+		})
+	}
+}
+
+func TestInMemoryArtifactRepositorySaveJSONFileCreatesBackups(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "artifacts.json")
+
+	repo := NewInMemoryArtifactRepository()
+	repo.BackupCount = 2
+
+	for _, id := range []string{"first", "second", "third", "fourth"} {
+		if err := repo.SaveArtifact(&entity.Artifact{ID: id}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := repo.SaveJSONFile(filename); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	backups, err := listBackups(filename)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(backups) != 2 {
+		t.Fatalf("expected 2 backups, got %d", len(backups))
+	}
+
+	// 最新のバックアップは直前の世代 (3 件) のスナップショット
+	previous := NewInMemoryArtifactRepository()
+	if err := previous.LoadJSONFile(backups[0]); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(previous.Artifacts) != 3 {
+		t.Errorf("expected 3 artifacts in newest backup, got %d", len(previous.Artifacts))
+	}
+}
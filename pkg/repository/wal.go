@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
+)
+
+var (
+	ErrWALAlreadyOpened = errors.New("write-ahead log is already opened")
+	ErrWALCorrupted     = errors.New("write-ahead log is corrupted")
+)
+
+type walOperation string
+
+const (
+	walOperationSave        walOperation = "save"
+	walOperationSaveBatch   walOperation = "save_batch"
+	walOperationUpdate      walOperation = "update"
+	walOperationUpdateBatch walOperation = "update_batch"
+	walOperationDelete      walOperation = "delete"
+)
+
+// walEntry の Artifacts は save_batch と update_batch でのみ使う。
+// まとめて 1 行に書くことで、書き込み途中のクラッシュでは一部だけが適用されることはない。
+type walEntry struct {
+	Op        walOperation       `json:"op"`
+	ID        string             `json:"id,omitempty"`
+	Artifact  *entity.Artifact   `json:"artifact,omitempty"`
+	Artifacts []*entity.Artifact `json:"artifacts,omitempty"`
+}
+
+// writeAheadLog は変更操作を 1 行 1 エントリの JSON Lines 形式で追記するログ。
+// 追記ごとに fsync し、起動時にスナップショットの上へ再適用される。
+type writeAheadLog struct {
+	file *os.File
+}
+
+func openWriteAheadLog(path string) (*writeAheadLog, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &writeAheadLog{file: file}, nil
+}
+
+func (w *writeAheadLog) append(entry walEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	if _, err := w.file.Write(line); err != nil {
+		return err
+	}
+	return w.file.Sync()
+}
+
+// replay はログ先頭から順にエントリを apply に渡す。
+// 書き込み途中でクラッシュした末尾の不完全な行は破棄し、ファイルを切り詰める。
+func (w *writeAheadLog) replay(apply func(entry walEntry)) error {
+	data, err := os.ReadFile(w.file.Name())
+	if err != nil {
+		return err
+	}
+
+	offset := 0
+	for offset < len(data) {
+		end := bytes.IndexByte(data[offset:], '\n')
+		if end < 0 {
+			break
+		}
+
+		line := data[offset : offset+end]
+		if len(bytes.TrimSpace(line)) > 0 {
+			var entry walEntry
+			if err := json.Unmarshal(line, &entry); err != nil {
+				return fmt.Errorf("%w: offset %d: %v", ErrWALCorrupted, offset, err)
+			}
+			apply(entry)
+		}
+		offset += end + 1
+	}
+
+	if offset < len(data) {
+		if err := w.file.Truncate(int64(offset)); err != nil {
+			return err
+		}
+		return w.file.Sync()
+	}
+	return nil
+}
+
+func (w *writeAheadLog) truncate() error {
+	if err := w.file.Truncate(0); err != nil {
+		return err
+	}
+	return w.file.Sync()
+}
+
+func (w *writeAheadLog) close() error {
+	return w.file.Close()
+}
@@ -0,0 +1,229 @@
+package repository
+
+import (
+	"errors"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestInMemoryArtifactRepositoryOpenWAL(t *testing.T) {
+	tests := []struct {
+		name string
+
+		// GIVEN
+		snapshot   map[string]*entity.Artifact
+		walContent string
+
+		// THEN
+		expectedIDs   []string
+		expectedError error
+	}{
+		{
+			name: "ShouldReplaySaveAndDeleteOnTopOfSnapshot",
+
+			snapshot: map[string]*entity.Artifact{
+				"snapshot-id": {ID: "snapshot-id"},
+				"deleted-id":  {ID: "deleted-id"},
+			},
+			walContent: `{"op":"save","artifact":{"ID":"wal-id"}}` + "\n" +
+				`{"op":"delete","id":"deleted-id"}` + "\n",
+
+			expectedIDs: []string{"snapshot-id", "wal-id"},
+		},
+		{
+			name: "ShouldReplayIdempotentlyWhenSnapshotAlreadyContainsEntries",
+
+			snapshot: map[string]*entity.Artifact{
+				"wal-id": {ID: "wal-id"},
+			},
+			walContent: `{"op":"save","artifact":{"ID":"wal-id"}}` + "\n" +
+				`{"op":"delete","id":"missing-id"}` + "\n",
+
+			expectedIDs: []string{"wal-id"},
+		},
+		{
+			name: "ShouldDiscardTornTailEntry",
+
+			snapshot:   map[string]*entity.Artifact{},
+			walContent: `{"op":"save","artifact":{"ID":"wal-id"}}` + "\n" + `{"op":"save","artif`,
+
+			expectedIDs: []string{"wal-id"},
+		},
+		{
+			name: "ShouldReturnErrorWhenCompleteEntryIsCorrupted",
+
+			snapshot:   map[string]*entity.Artifact{},
+			walContent: "not-json\n",
+
+			expectedError: ErrWALCorrupted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			walPath := filepath.Join(t.TempDir(), "artifacts.wal")
+			if err := os.WriteFile(walPath, []byte(tt.walContent), 0644); err != nil {
+				t.Fatalf("failed to write WAL file: %v", err)
+			}
+
+			repo := InMemoryArtifactRepository{
+				Artifacts: tt.snapshot,
+			}
+			err := repo.OpenWAL(walPath)
+			defer func() { _ = repo.CloseWAL() }()
+
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error: %v, got: %v", tt.expectedError, err)
+			}
+			if err != nil {
+				return
+			}
+
+			for _, id := range tt.expectedIDs {
+				if _, err := repo.GetArtifactByID(id); err != nil {
+					t.Errorf("expected artifact %s to exist, got error: %v", id, err)
+				}
+			}
+			if len(repo.Artifacts) != len(tt.expectedIDs) {
+				t.Errorf("expected %d artifacts, got %d", len(tt.expectedIDs), len(repo.Artifacts))
+			}
+		})
+	}
+}
+
+func TestInMemoryArtifactRepositoryWALRecovery(t *testing.T) {
+	tmpDir := t.TempDir()
+	dataPath := filepath.Join(tmpDir, "artifacts.json")
+	walPath := filepath.Join(tmpDir, "artifacts.wal")
+
+	testArtifact := &entity.Artifact{
+		ID:          "test-id",
+		ArtifactSet: entity.ARTIFACT_SET_GLADIATORS_FINALOFFERING,
+		Type:        entity.ARTIFACT_TYPE_FLOWER,
+		Rarity:      5,
+		Level:       20,
+		PrimaryStat: entity.PrimaryStat{Type: entity.ATK_PERCENT, Value: 46.6},
+		Substats:    []entity.Substat{{Type: entity.SUBSTAT_CRIT_RATE, Value: 3.9}},
+	}
+
+	// スナップショットを書き出さずにプロセスが落ちた状況を再現する
+	repo := NewInMemoryArtifactRepository()
+	if err := repo.OpenWAL(walPath); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := repo.SaveArtifact(testArtifact); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := repo.SaveArtifact(&entity.Artifact{ID: "deleted-id"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := repo.DeleteArtifactByID("deleted-id"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := repo.CloseWAL(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	recovered := NewInMemoryArtifactRepository()
+	if err := recovered.OpenWAL(walPath); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := recovered.GetArtifactByID("test-id")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(testArtifact, got); diff != "" {
+		t.Errorf("recovered artifact mismatch (-want +got):\n%s", diff)
+	}
+	if _, err := recovered.GetArtifactByID("deleted-id"); !errors.Is(err, ErrArtifactNotFound) {
+		t.Errorf("expected error: %v, got: %v", ErrArtifactNotFound, err)
+	}
+
+	// コンパクション後はスナップショットのみから復元できる
+	if err := recovered.Compact(dataPath); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := recovered.CloseWAL(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	info, err := os.Stat(walPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if info.Size() != 0 {
+		t.Errorf("expected WAL to be empty after compaction, got %d bytes", info.Size())
+	}
+
+	reloaded := NewInMemoryArtifactRepository()
+	if err := reloaded.LoadJSONFile(dataPath); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := reloaded.OpenWAL(walPath); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer func() { _ = reloaded.CloseWAL() }()
+
+	if len(reloaded.Artifacts) != 1 {
+		t.Errorf("expected 1 artifact, got %d", len(reloaded.Artifacts))
+	}
+}
+
+func TestInMemoryArtifactRepositoryWALRecoveryOfBatch(t *testing.T) {
+	walPath := filepath.Join(t.TempDir(), "artifacts.wal")
+
+	repo := NewInMemoryArtifactRepository()
+	if err := repo.OpenWAL(walPath); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := repo.SaveArtifacts([]*entity.Artifact{{ID: "batch-id-1"}, {ID: "batch-id-2"}}, ArtifactSaveOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := repo.CloseWAL(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// 次のバッチの書き込み途中でクラッシュした状況を再現する
+	file, err := os.OpenFile(walPath, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := file.WriteString(`{"op":"save_batch","artifacts":[{"ID":"torn-id"}`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	recovered := NewInMemoryArtifactRepository()
+	if err := recovered.OpenWAL(walPath); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer func() { _ = recovered.CloseWAL() }()
+
+	ids := slices.Sorted(maps.Keys(recovered.Artifacts))
+	if diff := cmp.Diff([]string{"batch-id-1", "batch-id-2"}, ids); diff != "" {
+		t.Errorf("recovered IDs mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestInMemoryArtifactRepositoryOpenWALTwice(t *testing.T) {
+	walPath := filepath.Join(t.TempDir(), "artifacts.wal")
+
+	repo := NewInMemoryArtifactRepository()
+	if err := repo.OpenWAL(walPath); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer func() { _ = repo.CloseWAL() }()
+
+	if err := repo.OpenWAL(walPath); !errors.Is(err, ErrWALAlreadyOpened) {
+		t.Errorf("expected error: %v, got: %v", ErrWALAlreadyOpened, err)
+	}
+}
@@ -0,0 +1,116 @@
+package service
+
+import "github.com/YutoOkawa/genshin-artifact-db/pkg/repository"
+
+type AccountDTO struct {
+	Name string `json:"name"`
+}
+
+type CreateAccountCommand struct {
+	Name string
+}
+
+// AccountServices はひとつのアカウントの聖遺物を扱うサービスの組。
+// スコアプロファイルはアカウント間で共有する。
+type AccountServices struct {
+	GetArtifact    *GetArtifactService
+	UpdateArtifact *UpdateArtifactService
+	BatchArtifact  *BatchArtifactService
+	Equipment      *EquipmentService
+	Score          *ScoreService
+	Import         *ImportService
+	Export         *ExportService
+}
+
+type GetAccountsServiceInterface interface {
+	GetAccounts() ([]*AccountDTO, error)
+}
+
+type GetAccountServiceInterface interface {
+	GetAccount(name string) (*AccountDTO, error)
+}
+
+type CreateAccountServiceInterface interface {
+	CreateAccount(accountCommand CreateAccountCommand) (*AccountDTO, error)
+}
+
+type AccountServicesResolverInterface interface {
+	AccountServices(name string) (*AccountServices, error)
+}
+
+// AccountService のスコアプロファイルは採点にのみ使い、変更は ScoreProfileService で行う。
+type AccountService struct {
+	accountGetter      repository.AccountGetter
+	accountCreator     repository.AccountCreator
+	scoreProfileGetter repository.ScoreProfileGetter
+}
+
+func NewAccountService(
+	accountGetter repository.AccountGetter,
+	accountCreator repository.AccountCreator,
+	scoreProfileGetter repository.ScoreProfileGetter,
+) *AccountService {
+	return &AccountService{
+		accountGetter:      accountGetter,
+		accountCreator:     accountCreator,
+		scoreProfileGetter: scoreProfileGetter,
+	}
+}
+
+func (s *AccountService) GetAccounts() ([]*AccountDTO, error) {
+	accounts, err := s.accountGetter.GetAccounts()
+	if err != nil {
+		return nil, ClassifyError(err)
+	}
+
+	accountDTOs := make([]*AccountDTO, 0, len(accounts))
+	for _, account := range accounts {
+		accountDTOs = append(accountDTOs, &AccountDTO{Name: account})
+	}
+	return accountDTOs, nil
+}
+
+func (s *AccountService) GetAccount(name string) (*AccountDTO, error) {
+	if _, err := s.getAccountArtifacts(name); err != nil {
+		return nil, err
+	}
+	return &AccountDTO{Name: name}, nil
+}
+
+func (s *AccountService) CreateAccount(accountCommand CreateAccountCommand) (*AccountDTO, error) {
+	if err := s.accountCreator.CreateAccount(accountCommand.Name); err != nil {
+		return nil, ClassifyError(err)
+	}
+	return &AccountDTO{Name: accountCommand.Name}, nil
+}
+
+// AccountServices はアカウントの聖遺物リポジトリを使うサービスを組み立てる。
+// name はパスの値として検証し、不正な場合は項目名 account の検証エラーを返す。
+func (s *AccountService) AccountServices(name string) (*AccountServices, error) {
+	artifacts, err := s.getAccountArtifacts(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AccountServices{
+		GetArtifact:    NewGetArtifactService(artifacts, s.scoreProfileGetter),
+		UpdateArtifact: NewUpdateArtifactService(artifacts, artifacts, artifacts, artifacts, artifacts),
+		BatchArtifact:  NewBatchArtifactService(artifacts, artifacts),
+		Equipment:      NewEquipmentService(artifacts, artifacts),
+		Score:          NewScoreService(artifacts, s.scoreProfileGetter),
+		Import:         NewImportService(artifacts, artifacts),
+		Export:         NewExportService(artifacts),
+	}, nil
+}
+
+func (s *AccountService) getAccountArtifacts(name string) (repository.ArtifactStore, error) {
+	if err := repository.ValidateAccountName(name); err != nil {
+		return nil, NewValidationError("invalid_account_name", "account", err)
+	}
+
+	artifacts, err := s.accountGetter.GetAccountArtifacts(name)
+	if err != nil {
+		return nil, ClassifyError(err)
+	}
+	return artifacts, nil
+}
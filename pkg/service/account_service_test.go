@@ -0,0 +1,180 @@
+package service
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/repository"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAccountServiceAccountServices(t *testing.T) {
+	store, err := repository.OpenKVArtifactRepository(filepath.Join(t.TempDir(), "artifacts.db"))
+	if err != nil {
+		t.Fatalf("failed to open repository: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	if err := store.SaveArtifact(&entity.Artifact{
+		ID:          "alt-flower",
+		Type:        entity.ARTIFACT_TYPE_FLOWER,
+		ArtifactSet: entity.ARTIFACT_SET_GLADIATORS_FINALOFFERING,
+		PrimaryStat: entity.PrimaryStat{Type: entity.HP_FLAT, Value: 717},
+	}); err != nil {
+		t.Fatalf("failed to save artifact: %v", err)
+	}
+
+	tests := []struct {
+		name string
+
+		// GIVEN
+		mockGetAccountArtifactsError error
+
+		// WHEN
+		account string
+
+		// THEN
+		expectedErrorCode  string
+		expectedErrorField string
+	}{
+		{
+			name: "ShouldResolveServicesOnAccountArtifacts",
+
+			account: "alt",
+		},
+		{
+			name: "ShouldReturnValidationErrorWhenAccountNameIsInvalid",
+
+			account: "../alt",
+
+			expectedErrorCode:  "invalid_account_name",
+			expectedErrorField: "account",
+		},
+		{
+			name: "ShouldReturnNotFoundWhenAccountDoesNotExist",
+
+			account: "missing",
+
+			expectedErrorCode: "account_not_found",
+		},
+		{
+			name: "ShouldReturnInternalErrorWhenOpenFails",
+
+			mockGetAccountArtifactsError: errors.New("permission denied"),
+
+			account: "alt",
+
+			expectedErrorCode: ErrorCodeInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accountService := NewAccountService(
+				&repository.MockAccountGetter{
+					Stores:                   map[string]repository.ArtifactStore{"alt": store},
+					GetAccountArtifactsError: tt.mockGetAccountArtifactsError,
+				},
+				&repository.MockAccountCreator{},
+				&repository.MockScoreProfileGetter{},
+			)
+
+			services, err := accountService.AccountServices(tt.account)
+			if tt.expectedErrorCode != "" {
+				var serviceError *Error
+				if !errors.As(err, &serviceError) || serviceError.Code != tt.expectedErrorCode {
+					t.Fatalf("expected error code %s, got %v", tt.expectedErrorCode, err)
+				}
+				var field string
+				if len(serviceError.Fields) > 0 {
+					field = serviceError.Fields[0].Field
+				}
+				if field != tt.expectedErrorField {
+					t.Errorf("expected field %q, got %q", tt.expectedErrorField, field)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			artifact, err := services.GetArtifact.GetArtifact("alt-flower")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if artifact.ID != "alt-flower" {
+				t.Errorf("expected artifact alt-flower, got %s", artifact.ID)
+			}
+		})
+	}
+}
+
+func TestAccountServiceCreateAccount(t *testing.T) {
+	tests := []struct {
+		name string
+
+		// GIVEN
+		mockCreateAccountError error
+
+		// WHEN
+		command CreateAccountCommand
+
+		// THEN
+		expectedAccount   *AccountDTO
+		expectedErrorCode string
+	}{
+		{
+			name: "ShouldCreateAccountSuccessfully",
+
+			command: CreateAccountCommand{Name: "alt"},
+
+			expectedAccount: &AccountDTO{Name: "alt"},
+		},
+		{
+			name: "ShouldReturnConflictWhenAccountAlreadyExists",
+
+			mockCreateAccountError: repository.ErrAccountAlreadyExists,
+
+			command: CreateAccountCommand{Name: "alt"},
+
+			expectedErrorCode: "account_already_exists",
+		},
+		{
+			name: "ShouldReturnValidationErrorWhenAccountNameIsInvalid",
+
+			mockCreateAccountError: repository.ErrInvalidAccountName,
+
+			command: CreateAccountCommand{Name: "Alt"},
+
+			expectedErrorCode: "invalid_account_name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accountCreator := &repository.MockAccountCreator{CreateAccountError: tt.mockCreateAccountError}
+			accountService := NewAccountService(&repository.MockAccountGetter{}, accountCreator, nil)
+
+			account, err := accountService.CreateAccount(tt.command)
+			if tt.expectedErrorCode != "" {
+				var serviceError *Error
+				if !errors.As(err, &serviceError) || serviceError.Code != tt.expectedErrorCode {
+					t.Fatalf("expected error code %s, got %v", tt.expectedErrorCode, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.expectedAccount, account); diff != "" {
+				t.Errorf("account mismatch (-want +got):\n%s", diff)
+			}
+			if accountCreator.CreatedAccount != tt.command.Name {
+				t.Errorf("expected account %s to be created, got %s", tt.command.Name, accountCreator.CreatedAccount)
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/repository"
+)
+
+// APIKeyPrefix は API キーの先頭に付け、ログや設定ファイルに紛れた鍵を見つけやすくする。
+// 鍵は APIKeyPrefix + ID + "_" + 秘密部分 の形式で、秘密部分は作成時にだけ返す。
+const APIKeyPrefix = "gadb_"
+
+var (
+	ErrAuthenticationRequired = errors.New("authentication is required")
+	ErrInvalidAPIKey          = errors.New("invalid API key")
+	ErrReadOnlyAPIKey         = errors.New("API key is read-only")
+	ErrAccountForbidden       = errors.New("API key is not allowed to access the account")
+)
+
+type APIKeyDTO struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Scope     string    `json:"scope"`
+	Accounts  []string  `json:"accounts"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CreatedAPIKeyDTO の Key は保存されないため、作成時に利用者へ渡す以外に知る方法はない。
+type CreatedAPIKeyDTO struct {
+	APIKeyDTO
+	Key string `json:"key"`
+}
+
+type CreateAPIKeyCommand struct {
+	Name     string
+	Scope    string
+	Accounts []string
+}
+
+// Principal は認証された API キーの権限。
+type Principal struct {
+	KeyID    string
+	Name     string
+	Scope    entity.APIKeyScope
+	Accounts []string
+}
+
+func (p *Principal) apiKey() *entity.APIKey {
+	return &entity.APIKey{ID: p.KeyID, Name: p.Name, Scope: p.Scope, Accounts: p.Accounts}
+}
+
+// AuthorizeWrite は書き込みのスコープを持たない場合に ErrReadOnlyAPIKey を返す。
+func (p *Principal) AuthorizeWrite() error {
+	if !p.apiKey().CanWrite() {
+		return ClassifyError(fmt.Errorf("%w: %s", ErrReadOnlyAPIKey, p.KeyID))
+	}
+	return nil
+}
+
+// AuthorizeAccount はアカウントを扱えない場合に ErrAccountForbidden を返す。
+func (p *Principal) AuthorizeAccount(account string) error {
+	if !p.apiKey().CanAccessAccount(account) {
+		return ClassifyError(fmt.Errorf("%w: %s", ErrAccountForbidden, account))
+	}
+	return nil
+}
+
+// AuthorizeAllAccounts はアカウントの作成など、特定のアカウントに属さない操作を許可するかを判定する。
+func (p *Principal) AuthorizeAllAccounts() error {
+	if !p.apiKey().CanAccessAllAccounts() {
+		return ClassifyError(fmt.Errorf("%w: requires access to all accounts", ErrAccountForbidden))
+	}
+	return nil
+}
+
+type AuthenticateServiceInterface interface {
+	Authenticate(key string) (*Principal, error)
+}
+
+type APIKeyService struct {
+	apiKeyGetter  repository.APIKeyGetter
+	apiKeySaver   repository.APIKeySaver
+	apiKeyDeleter repository.APIKeyDeleter
+	now           func() time.Time
+}
+
+func NewAPIKeyService(
+	apiKeyGetter repository.APIKeyGetter,
+	apiKeySaver repository.APIKeySaver,
+	apiKeyDeleter repository.APIKeyDeleter,
+) *APIKeyService {
+	return &APIKeyService{
+		apiKeyGetter:  apiKeyGetter,
+		apiKeySaver:   apiKeySaver,
+		apiKeyDeleter: apiKeyDeleter,
+		now:           time.Now,
+	}
+}
+
+// CreateAPIKey は API キーを発行する。返した Key 以外から鍵を復元することはできない。
+// Accounts には entity.APIKeyAllAccounts か、アカウント名として正しい値だけを指定できる。
+func (s *APIKeyService) CreateAPIKey(apiKeyCommand CreateAPIKeyCommand) (*CreatedAPIKeyDTO, error) {
+	scope, err := entity.NewAPIKeyScope(apiKeyCommand.Scope)
+	if err != nil {
+		return nil, ClassifyError(err)
+	}
+	for _, account := range apiKeyCommand.Accounts {
+		if account == entity.APIKeyAllAccounts {
+			continue
+		}
+		if err := repository.ValidateAccountName(account); err != nil {
+			return nil, NewValidationError("invalid_account_name", "accounts", err)
+		}
+	}
+
+	id := rand.Text()
+	secret := rand.Text()
+	apiKey, err := entity.NewAPIKey(id, apiKeyCommand.Name, hashAPIKeySecret(secret), scope, apiKeyCommand.Accounts, s.now().UTC())
+	if err != nil {
+		return nil, ClassifyError(err)
+	}
+	if err := s.apiKeySaver.SaveAPIKey(apiKey); err != nil {
+		return nil, ClassifyError(err)
+	}
+
+	return &CreatedAPIKeyDTO{
+		APIKeyDTO: *newAPIKeyDTO(apiKey),
+		Key:       APIKeyPrefix + id + "_" + secret,
+	}, nil
+}
+
+func (s *APIKeyService) GetAPIKeys() ([]*APIKeyDTO, error) {
+	apiKeys, err := s.apiKeyGetter.GetAPIKeys()
+	if err != nil {
+		return nil, ClassifyError(err)
+	}
+
+	apiKeyDTOs := make([]*APIKeyDTO, 0, len(apiKeys))
+	for _, apiKey := range apiKeys {
+		apiKeyDTOs = append(apiKeyDTOs, newAPIKeyDTO(apiKey))
+	}
+	return apiKeyDTOs, nil
+}
+
+// RevokeAPIKey は API キーを削除し、以降の認証を失敗させる。
+func (s *APIKeyService) RevokeAPIKey(id string) error {
+	if err := s.apiKeyDeleter.DeleteAPIKey(id); err != nil {
+		return ClassifyError(err)
+	}
+	return nil
+}
+
+// Authenticate は鍵を検証し、その権限を返す。
+// 鍵の形式が誤っている場合も存在しない場合も、区別せず ErrInvalidAPIKey を返す。
+func (s *APIKeyService) Authenticate(key string) (*Principal, error) {
+	id, secret, ok := strings.Cut(strings.TrimPrefix(key, APIKeyPrefix), "_")
+	if !ok || !strings.HasPrefix(key, APIKeyPrefix) {
+		return nil, ClassifyError(ErrInvalidAPIKey)
+	}
+
+	apiKey, err := s.apiKeyGetter.GetAPIKey(id)
+	if errors.Is(err, repository.ErrAPIKeyNotFound) {
+		return nil, ClassifyError(ErrInvalidAPIKey)
+	}
+	if err != nil {
+		return nil, ClassifyError(err)
+	}
+	if subtle.ConstantTimeCompare([]byte(hashAPIKeySecret(secret)), []byte(apiKey.SecretHash)) != 1 {
+		return nil, ClassifyError(ErrInvalidAPIKey)
+	}
+
+	return &Principal{
+		KeyID:    apiKey.ID,
+		Name:     apiKey.Name,
+		Scope:    apiKey.Scope,
+		Accounts: apiKey.Accounts,
+	}, nil
+}
+
+// hashAPIKeySecret は秘密部分の SHA-256 を返す。
+// 秘密部分は 128 ビットの乱数のため、パスワード向けの低速なハッシュは使わない。
+func hashAPIKeySecret(secret string) string {
+	hash := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(hash[:])
+}
+
+func newAPIKeyDTO(apiKey *entity.APIKey) *APIKeyDTO {
+	return &APIKeyDTO{
+		ID:        apiKey.ID,
+		Name:      apiKey.Name,
+		Scope:     string(apiKey.Scope),
+		Accounts:  apiKey.Accounts,
+		CreatedAt: apiKey.CreatedAt,
+	}
+}
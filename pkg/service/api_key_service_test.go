@@ -0,0 +1,271 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/repository"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAPIKeyServiceCreateAPIKey(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name string
+
+		// GIVEN
+		mockSaveAPIKeyError error
+
+		// WHEN
+		command CreateAPIKeyCommand
+
+		// THEN
+		expectedAPIKey    APIKeyDTO
+		expectedErrorCode string
+	}{
+		{
+			name: "ShouldCreateAPIKeySuccessfully",
+
+			command: CreateAPIKeyCommand{Name: "scanner", Scope: "read-write", Accounts: []string{"default", "alt"}},
+
+			expectedAPIKey: APIKeyDTO{Name: "scanner", Scope: "read-write", Accounts: []string{"default", "alt"}, CreatedAt: now},
+		},
+		{
+			name: "ShouldCreateAPIKeyForAllAccounts",
+
+			command: CreateAPIKeyCommand{Name: "admin", Scope: "read-only", Accounts: []string{entity.APIKeyAllAccounts}},
+
+			expectedAPIKey: APIKeyDTO{Name: "admin", Scope: "read-only", Accounts: []string{"*"}, CreatedAt: now},
+		},
+		{
+			name: "ShouldReturnValidationErrorWhenScopeIsUnknown",
+
+			command: CreateAPIKeyCommand{Name: "scanner", Scope: "admin", Accounts: []string{"default"}},
+
+			expectedErrorCode: "invalid_api_key_scope",
+		},
+		{
+			name: "ShouldReturnValidationErrorWhenAccountNameIsInvalid",
+
+			command: CreateAPIKeyCommand{Name: "scanner", Scope: "read-only", Accounts: []string{"../alt"}},
+
+			expectedErrorCode: "invalid_account_name",
+		},
+		{
+			name: "ShouldReturnValidationErrorWhenNoAccountIsGiven",
+
+			command: CreateAPIKeyCommand{Name: "scanner", Scope: "read-only"},
+
+			expectedErrorCode: "invalid_api_key_accounts",
+		},
+		{
+			name: "ShouldReturnInternalErrorWhenSaveFails",
+
+			mockSaveAPIKeyError: errors.New("disk full"),
+
+			command: CreateAPIKeyCommand{Name: "scanner", Scope: "read-only", Accounts: []string{"default"}},
+
+			expectedErrorCode: ErrorCodeInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiKeySaver := &repository.MockAPIKeySaver{SaveAPIKeyError: tt.mockSaveAPIKeyError}
+			apiKeyService := NewAPIKeyService(&repository.MockAPIKeyGetter{}, apiKeySaver, &repository.MockAPIKeyDeleter{})
+			apiKeyService.now = func() time.Time { return now }
+
+			created, err := apiKeyService.CreateAPIKey(tt.command)
+			if tt.expectedErrorCode != "" {
+				var serviceError *Error
+				if !errors.As(err, &serviceError) || serviceError.Code != tt.expectedErrorCode {
+					t.Fatalf("expected error code %s, got %v", tt.expectedErrorCode, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			tt.expectedAPIKey.ID = created.ID
+			if diff := cmp.Diff(tt.expectedAPIKey, created.APIKeyDTO); diff != "" {
+				t.Errorf("API key mismatch (-want +got):\n%s", diff)
+			}
+			if !strings.HasPrefix(created.Key, APIKeyPrefix+created.ID+"_") {
+				t.Errorf("expected key to start with %s%s_, got %s", APIKeyPrefix, created.ID, created.Key)
+			}
+			if strings.Contains(apiKeySaver.SavedAPIKey.SecretHash, strings.TrimPrefix(created.Key, APIKeyPrefix+created.ID+"_")) {
+				t.Errorf("expected secret not to be stored in plain text")
+			}
+		})
+	}
+}
+
+func TestAPIKeyServiceAuthenticate(t *testing.T) {
+	// GIVEN
+	apiKeyRepository := repository.NewInMemoryAPIKeyRepository()
+	apiKeyService := NewAPIKeyService(apiKeyRepository, apiKeyRepository, apiKeyRepository)
+	created, err := apiKeyService.CreateAPIKey(CreateAPIKeyCommand{Name: "scanner", Scope: "read-only", Accounts: []string{"alt"}})
+	if err != nil {
+		t.Fatalf("failed to create API key: %v", err)
+	}
+	revoked, err := apiKeyService.CreateAPIKey(CreateAPIKeyCommand{Name: "old", Scope: "read-write", Accounts: []string{"alt"}})
+	if err != nil {
+		t.Fatalf("failed to create API key: %v", err)
+	}
+	if err := apiKeyService.RevokeAPIKey(revoked.ID); err != nil {
+		t.Fatalf("failed to revoke API key: %v", err)
+	}
+
+	tests := []struct {
+		name string
+
+		// WHEN
+		key string
+
+		// THEN
+		expectedPrincipal *Principal
+		expectedErrorCode string
+	}{
+		{
+			name: "ShouldAuthenticateValidKey",
+
+			key: created.Key,
+
+			expectedPrincipal: &Principal{KeyID: created.ID, Name: "scanner", Scope: entity.API_KEY_SCOPE_READ_ONLY, Accounts: []string{"alt"}},
+		},
+		{
+			name: "ShouldRejectWrongSecret",
+
+			key: APIKeyPrefix + created.ID + "_WRONGSECRET",
+
+			expectedErrorCode: "invalid_api_key",
+		},
+		{
+			name: "ShouldRejectRevokedKey",
+
+			key: revoked.Key,
+
+			expectedErrorCode: "invalid_api_key",
+		},
+		{
+			name: "ShouldRejectKeyWithoutPrefix",
+
+			key: strings.TrimPrefix(created.Key, APIKeyPrefix),
+
+			expectedErrorCode: "invalid_api_key",
+		},
+		{
+			name: "ShouldRejectMalformedKey",
+
+			key: "not-a-key",
+
+			expectedErrorCode: "invalid_api_key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			principal, err := apiKeyService.Authenticate(tt.key)
+			if tt.expectedErrorCode != "" {
+				var serviceError *Error
+				if !errors.As(err, &serviceError) || serviceError.Code != tt.expectedErrorCode {
+					t.Fatalf("expected error code %s, got %v", tt.expectedErrorCode, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.expectedPrincipal, principal); diff != "" {
+				t.Errorf("principal mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPrincipalAuthorize(t *testing.T) {
+	tests := []struct {
+		name string
+
+		// GIVEN
+		principal *Principal
+
+		// WHEN
+		authorize func(p *Principal) error
+
+		// THEN
+		expectedErrorCode string
+	}{
+		{
+			name: "ShouldAllowWriteWithReadWriteScope",
+
+			principal: &Principal{Scope: entity.API_KEY_SCOPE_READ_WRITE, Accounts: []string{"alt"}},
+
+			authorize: func(p *Principal) error { return p.AuthorizeWrite() },
+		},
+		{
+			name: "ShouldRejectWriteWithReadOnlyScope",
+
+			principal: &Principal{Scope: entity.API_KEY_SCOPE_READ_ONLY, Accounts: []string{"alt"}},
+
+			authorize: func(p *Principal) error { return p.AuthorizeWrite() },
+
+			expectedErrorCode: "insufficient_scope",
+		},
+		{
+			name: "ShouldAllowListedAccount",
+
+			principal: &Principal{Scope: entity.API_KEY_SCOPE_READ_ONLY, Accounts: []string{"alt"}},
+
+			authorize: func(p *Principal) error { return p.AuthorizeAccount("alt") },
+		},
+		{
+			name: "ShouldRejectOtherAccount",
+
+			principal: &Principal{Scope: entity.API_KEY_SCOPE_READ_WRITE, Accounts: []string{"alt"}},
+
+			authorize: func(p *Principal) error { return p.AuthorizeAccount("default") },
+
+			expectedErrorCode: "account_forbidden",
+		},
+		{
+			name: "ShouldRejectAllAccountsOperationWithoutWildcard",
+
+			principal: &Principal{Scope: entity.API_KEY_SCOPE_READ_WRITE, Accounts: []string{"alt", "default"}},
+
+			authorize: func(p *Principal) error { return p.AuthorizeAllAccounts() },
+
+			expectedErrorCode: "account_forbidden",
+		},
+		{
+			name: "ShouldAllowAllAccountsOperationWithWildcard",
+
+			principal: &Principal{Scope: entity.API_KEY_SCOPE_READ_WRITE, Accounts: []string{entity.APIKeyAllAccounts}},
+
+			authorize: func(p *Principal) error { return p.AuthorizeAllAccounts() },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.authorize(tt.principal)
+			if tt.expectedErrorCode == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			var serviceError *Error
+			if !errors.As(err, &serviceError) || serviceError.Code != tt.expectedErrorCode || serviceError.Kind != ERROR_KIND_FORBIDDEN {
+				t.Fatalf("expected forbidden error code %s, got %v", tt.expectedErrorCode, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
+)
+
+var (
+	ErrArtifactSetNotFound = errors.New("artifact set not found")
+)
+
+type SetBonusStatDTO struct {
+	Type  string  `json:"type"`
+	Value float64 `json:"value"`
+}
+
+type SetBonusDTO struct {
+	Pieces      int               `json:"pieces"`
+	Description string            `json:"description"`
+	Stats       []SetBonusStatDTO `json:"stats"`
+}
+
+type ArtifactSetDTO struct {
+	Key       string            `json:"key"`
+	Name      string            `json:"name"`
+	Names     map[string]string `json:"names"`
+	MaxRarity int               `json:"max_rarity"`
+	Bonuses   []SetBonusDTO     `json:"bonuses"`
+}
+
+type GetArtifactSetsServiceInterface interface {
+	GetArtifactSets(lang string) []*ArtifactSetDTO
+}
+
+type GetArtifactSetServiceInterface interface {
+	GetArtifactSet(key, lang string) (*ArtifactSetDTO, error)
+}
+
+type ArtifactSetService struct{}
+
+func NewArtifactSetService() *ArtifactSetService {
+	return &ArtifactSetService{}
+}
+
+func (s *ArtifactSetService) GetArtifactSets(lang string) []*ArtifactSetDTO {
+	sets := entity.ArtifactSets()
+
+	artifactSetDTOs := make([]*ArtifactSetDTO, 0, len(sets))
+	for _, set := range sets {
+		artifactSetDTOs = append(artifactSetDTOs, newArtifactSetDTO(set, lang))
+	}
+	return artifactSetDTOs
+}
+
+func (s *ArtifactSetService) GetArtifactSet(key, lang string) (*ArtifactSetDTO, error) {
+	set, ok := entity.LookupArtifactSet(key)
+	if !ok {
+		return nil, ClassifyError(fmt.Errorf("%w: %s", ErrArtifactSetNotFound, key))
+	}
+	return newArtifactSetDTO(set, lang), nil
+}
+
+func newArtifactSetDTO(set *entity.ArtifactSetInfo, lang string) *ArtifactSetDTO {
+	artifactSetDTO := &ArtifactSetDTO{
+		Key:       string(set.Key),
+		Name:      set.Name(lang),
+		Names:     set.Names,
+		MaxRarity: set.MaxRarity,
+		Bonuses:   make([]SetBonusDTO, 0, len(set.Bonuses)),
+	}
+
+	for _, bonus := range set.Bonuses {
+		bonusDTO := SetBonusDTO{
+			Pieces:      bonus.Pieces,
+			Description: bonus.Description,
+			Stats:       make([]SetBonusStatDTO, 0, len(bonus.Stats)),
+		}
+		for _, stat := range bonus.Stats {
+			bonusDTO.Stats = append(bonusDTO.Stats, SetBonusStatDTO{
+				Type:  string(stat.Type),
+				Value: stat.Value,
+			})
+		}
+		artifactSetDTO.Bonuses = append(artifactSetDTO.Bonuses, bonusDTO)
+	}
+
+	return artifactSetDTO
+}
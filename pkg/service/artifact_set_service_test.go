@@ -0,0 +1,92 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
+)
+
+func TestArtifactSetServiceGetArtifactSets(t *testing.T) {
+	service := NewArtifactSetService()
+
+	result := service.GetArtifactSets("ja")
+
+	if len(result) != len(entity.ArtifactSets()) {
+		t.Errorf("expected %d artifact sets, got %d", len(entity.ArtifactSets()), len(result))
+	}
+	for _, set := range result {
+		if set.Name == "" {
+			t.Errorf("artifact set %s has empty name", set.Key)
+		}
+	}
+}
+
+func TestArtifactSetServiceGetArtifactSet(t *testing.T) {
+	tests := []struct {
+		name string
+
+		// WHEN
+		key  string
+		lang string
+
+		// THEN
+		expectedKey   string
+		expectedName  string
+		expectedStats []SetBonusStatDTO
+		expectedError error
+	}{
+		{
+			name: "ShouldGetArtifactSetSuccessfully",
+
+			key:  "GladiatorsFinale",
+			lang: "en",
+
+			expectedKey:   "GladiatorsFinale",
+			expectedName:  "Gladiator's Finale",
+			expectedStats: []SetBonusStatDTO{{Type: "ATK_PERCENT", Value: 18}},
+		},
+		{
+			name: "ShouldGetArtifactSetByLegacyAlias",
+
+			key:  "Noblesse",
+			lang: "ja",
+
+			expectedKey:   "NoblesseOblige",
+			expectedName:  "旧貴族のしつけ",
+			expectedStats: []SetBonusStatDTO{{Type: "ELEMENTAL_BURST_DMG_BONUS", Value: 20}},
+		},
+		{
+			name: "ShouldReturnErrorWhenArtifactSetNotFound",
+
+			key:  "INVALID_SET",
+			lang: "en",
+
+			expectedError: ErrArtifactSetNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewArtifactSetService()
+
+			result, err := service.GetArtifactSet(tt.key, tt.lang)
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("GetArtifactSet() error = %v, expectedError %v", err, tt.expectedError)
+			}
+			if err != nil {
+				return
+			}
+
+			if result.Key != tt.expectedKey {
+				t.Errorf("expected key %s, got %s", tt.expectedKey, result.Key)
+			}
+			if result.Name != tt.expectedName {
+				t.Errorf("expected name %s, got %s", tt.expectedName, result.Name)
+			}
+			if len(result.Bonuses) == 0 || len(result.Bonuses[0].Stats) != len(tt.expectedStats) || result.Bonuses[0].Stats[0] != tt.expectedStats[0] {
+				t.Errorf("expected 2-piece stats %v, got %v", tt.expectedStats, result.Bonuses)
+			}
+		})
+	}
+}
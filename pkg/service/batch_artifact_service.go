@@ -0,0 +1,212 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/repository"
+)
+
+// MaxArtifactBatchSize は一度のバッチ作成で受け付ける聖遺物の上限。
+const MaxArtifactBatchSize = 5000
+
+var (
+	ErrInvalidBatchMode   = errors.New("invalid batch mode")
+	ErrInvalidBatchSize   = errors.New("invalid batch size")
+	ErrBatchItemsRejected = errors.New("batch items rejected")
+)
+
+type BatchMode string
+
+// BATCH_MODE_ATOMIC は 1 件でも不正な聖遺物があればどれも作成しない。
+const BATCH_MODE_ATOMIC BatchMode = "atomic"
+
+// BATCH_MODE_BEST_EFFORT は作成できる聖遺物だけを作成し、残りは結果にエラーとして含める。
+const BATCH_MODE_BEST_EFFORT BatchMode = "best_effort"
+
+type BatchItemErrorDTO struct {
+	Code    string       `json:"code"`
+	Message string       `json:"message"`
+	Errors  []FieldError `json:"errors,omitempty"`
+}
+
+// BatchItemResultDTO はリクエストの配列の Index 番目の結果で、ID と Error のどちらか一方を持つ。
+// DuplicateOf は作成した聖遺物と内容が同じ聖遺物があった場合のみ、その ID を返す。
+type BatchItemResultDTO struct {
+	Index       int                `json:"index"`
+	ID          string             `json:"id,omitempty"`
+	DuplicateOf []string           `json:"duplicate_of,omitempty"`
+	Error       *BatchItemErrorDTO `json:"error,omitempty"`
+}
+
+type BatchCreateResultDTO struct {
+	Mode    BatchMode             `json:"mode"`
+	Created int                   `json:"created"`
+	Failed  int                   `json:"failed"`
+	Results []*BatchItemResultDTO `json:"results"`
+}
+
+type BatchCreateArtifactsServiceInterface interface {
+	BatchCreateArtifacts(mode string, duplicatePolicy DuplicatePolicy, artifactCommands []CreateArtifactCommand) (*BatchCreateResultDTO, error)
+}
+
+type BatchArtifactService struct {
+	artifactGetter     repository.ArtifactGetter
+	artifactBatchSaver repository.ArtifactBatchSaver
+}
+
+func NewBatchArtifactService(
+	artifactGetter repository.ArtifactGetter,
+	artifactBatchSaver repository.ArtifactBatchSaver,
+) *BatchArtifactService {
+	return &BatchArtifactService{
+		artifactGetter:     artifactGetter,
+		artifactBatchSaver: artifactBatchSaver,
+	}
+}
+
+// BatchCreateArtifacts は CreateArtifact と同じ検証を各聖遺物に行い、まとめて作成する。
+// mode が空の場合は atomic として扱う。
+// 内容が同じ聖遺物は duplicatePolicy に従って扱い、保存済みの聖遺物に加えて同じバッチの先の要素との重複も検出する。
+// 各要素の DuplicatePolicy は使わない。クライアントが指定した ID が同じバッチの先の要素と重なる場合は、その要素の検証エラーにする。
+// atomic モードで不正な聖遺物がある場合は、項目名に "[添字]." を付けた検証エラーを返す。
+// 保存先の障害はどちらのモードでも内部エラーとして返す。
+func (s *BatchArtifactService) BatchCreateArtifacts(mode string, duplicatePolicy DuplicatePolicy, artifactCommands []CreateArtifactCommand) (*BatchCreateResultDTO, error) {
+	batchMode := BatchMode(mode)
+	switch batchMode {
+	case BATCH_MODE_ATOMIC, BATCH_MODE_BEST_EFFORT:
+	case "":
+		batchMode = BATCH_MODE_ATOMIC
+	default:
+		return nil, NewValidationError("invalid_batch_mode", "mode", fmt.Errorf("%w: mode must be %s or %s, got %q", ErrInvalidBatchMode, BATCH_MODE_ATOMIC, BATCH_MODE_BEST_EFFORT, mode))
+	}
+	if len(artifactCommands) == 0 || len(artifactCommands) > MaxArtifactBatchSize {
+		return nil, NewValidationError("invalid_batch_size", "", fmt.Errorf("%w: batch must contain 1 to %d artifacts, got %d", ErrInvalidBatchSize, MaxArtifactBatchSize, len(artifactCommands)))
+	}
+
+	duplicates, err := newDuplicateChecker(s.artifactGetter, duplicatePolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BatchCreateResultDTO{
+		Mode:    batchMode,
+		Results: make([]*BatchItemResultDTO, 0, len(artifactCommands)),
+	}
+	artifacts := make([]*entity.Artifact, 0, len(artifactCommands))
+	checker := newEquipmentChecker(s.artifactGetter)
+	idIndexes := make(map[string]int, len(artifactCommands))
+	for i, artifactCommand := range artifactCommands {
+		id, err := newArtifactIDFromCommand(artifactCommand)
+		if err == nil {
+			err = checkBatchArtifactID(idIndexes, id, i)
+		}
+		if err != nil {
+			result.Results = append(result.Results, newBatchItemErrorResult(i, ClassifyError(err)))
+			continue
+		}
+		artifact, err := newArtifactFromCommand(id, artifactCommand)
+		if err != nil {
+			result.Results = append(result.Results, newBatchItemErrorResult(i, ClassifyError(err)))
+			continue
+		}
+		duplicateIDs, err := duplicates.check(artifact)
+		if err == nil {
+			err = checker.check(artifact)
+		}
+		if err != nil {
+			serviceError := ClassifyError(err)
+			if serviceError.Kind == ERROR_KIND_INTERNAL {
+				return nil, serviceError
+			}
+			result.Results = append(result.Results, newBatchItemErrorResult(i, serviceError))
+			continue
+		}
+		artifacts = append(artifacts, artifact)
+		result.Results = append(result.Results, &BatchItemResultDTO{Index: i, ID: artifact.ID, DuplicateOf: duplicateIDs})
+	}
+
+	if batchMode == BATCH_MODE_ATOMIC {
+		if len(artifacts) != len(artifactCommands) {
+			return nil, newBatchRejectedError(result.Results)
+		}
+		if err := s.artifactBatchSaver.SaveArtifacts(artifacts, duplicates.saveOptions()); err != nil {
+			return nil, ClassifyError(err)
+		}
+		result.Created = len(artifacts)
+		return result, nil
+	}
+
+	// 検証を通った聖遺物は 1 回の呼び出しで保存し、保存できなかった聖遺物だけを結果のエラーにする
+	saveErrors, err := s.artifactBatchSaver.SaveEachArtifact(artifacts, duplicates.saveOptions())
+	if err != nil {
+		return nil, ClassifyError(err)
+	}
+	artifactIndex := 0
+	for _, itemResult := range result.Results {
+		if itemResult.Error != nil {
+			result.Failed++
+			continue
+		}
+		saveError := saveErrors[artifactIndex]
+		artifactIndex++
+		if saveError != nil {
+			serviceError := ClassifyError(saveError)
+			if serviceError.Kind == ERROR_KIND_INTERNAL {
+				return nil, serviceError
+			}
+			*itemResult = *newBatchItemErrorResult(itemResult.Index, serviceError)
+			result.Failed++
+			continue
+		}
+		result.Created++
+	}
+	return result, nil
+}
+
+// checkBatchArtifactID はクライアントが指定した ID が、同じバッチの先の聖遺物で使われていないことを確かめる。
+func checkBatchArtifactID(idIndexes map[string]int, id string, index int) error {
+	if first, ok := idIndexes[id]; ok {
+		return NewValidationError("duplicate_artifact_id", "id", fmt.Errorf("%w: %s is also used at index %d", repository.ErrArtifactIDDuplicated, id, first))
+	}
+	idIndexes[id] = index
+	return nil
+}
+
+func newBatchItemErrorResult(index int, serviceError *Error) *BatchItemResultDTO {
+	return &BatchItemResultDTO{
+		Index: index,
+		Error: &BatchItemErrorDTO{
+			Code:    serviceError.Code,
+			Message: serviceError.Error(),
+			Errors:  serviceError.Fields,
+		},
+	}
+}
+
+// newBatchRejectedError は各聖遺物の検証エラーを、添字付きの項目名でひとつの検証エラーにまとめる。
+func newBatchRejectedError(results []*BatchItemResultDTO) *Error {
+	batchError := &Error{
+		Kind: ERROR_KIND_VALIDATION,
+		Code: "batch_items_rejected",
+	}
+	rejected := 0
+	for _, itemResult := range results {
+		if itemResult.Error == nil {
+			continue
+		}
+		rejected++
+		prefix := fmt.Sprintf("[%d]", itemResult.Index)
+		if len(itemResult.Error.Errors) == 0 {
+			batchError.Fields = append(batchError.Fields, FieldError{Field: prefix, Code: itemResult.Error.Code, Message: itemResult.Error.Message})
+			continue
+		}
+		for _, fieldError := range itemResult.Error.Errors {
+			fieldError.Field = prefix + "." + fieldError.Field
+			batchError.Fields = append(batchError.Fields, fieldError)
+		}
+	}
+	batchError.Err = fmt.Errorf("%w: %d of %d artifacts are invalid", ErrBatchItemsRejected, rejected, len(results))
+	return batchError
+}
@@ -0,0 +1,404 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/repository"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestBatchArtifactServiceBatchCreateArtifacts(t *testing.T) {
+	validCommand := CreateArtifactCommand{
+		ArtifactSet: "GladiatorsFinale",
+		Type:        "FLOWER",
+		Rarity:      5,
+		Level:       0,
+		PrimaryStat: PrimaryStatCommand{Type: "HP_FLAT"},
+		Substats: []StatCommand{
+			{Type: "CRIT_RATE", Value: 3.9},
+			{Type: "CRIT_DMG", Value: 7.8},
+			{Type: "ATK_PERCENT", Value: 5.8},
+		},
+	}
+	otherCommand := validCommand
+	otherCommand.Type = "PLUME"
+	otherCommand.PrimaryStat = PrimaryStatCommand{Type: "ATK_FLAT"}
+	invalidRarityCommand := validCommand
+	invalidRarityCommand.Rarity = 6
+	clientIDCommand := validCommand
+	clientIDCommand.ID = "client-id"
+	sameClientIDCommand := otherCommand
+	sameClientIDCommand.ID = "client-id"
+
+	tests := []struct {
+		name string
+
+		// GIVEN
+		mockSaveArtifactsError     error
+		mockSaveEachArtifactErrors []error
+		mockSaveEachArtifactError  error
+
+		// WHEN
+		mode             string
+		duplicatePolicy  DuplicatePolicy
+		artifactCommands []CreateArtifactCommand
+
+		// THEN
+		expectedResult     *BatchCreateResultDTO
+		expectedBatchSaved int
+		expectedEachSaved  int
+		expectedErrorCode  string
+		expectedFields     []FieldError
+	}{
+		{
+			name: "ShouldCreateAllArtifactsAtomically",
+
+			mode:             "atomic",
+			artifactCommands: []CreateArtifactCommand{validCommand, otherCommand},
+
+			expectedResult: &BatchCreateResultDTO{
+				Mode:    BATCH_MODE_ATOMIC,
+				Created: 2,
+				Results: []*BatchItemResultDTO{{Index: 0}, {Index: 1}},
+			},
+			expectedBatchSaved: 2,
+		},
+		{
+			name: "ShouldDefaultToAtomicMode",
+
+			artifactCommands: []CreateArtifactCommand{validCommand},
+
+			expectedResult: &BatchCreateResultDTO{
+				Mode:    BATCH_MODE_ATOMIC,
+				Created: 1,
+				Results: []*BatchItemResultDTO{{Index: 0}},
+			},
+			expectedBatchSaved: 1,
+		},
+		{
+			name: "ShouldRejectWholeBatchWhenAnyArtifactIsInvalidInAtomicMode",
+
+			mode:             "atomic",
+			artifactCommands: []CreateArtifactCommand{validCommand, invalidRarityCommand},
+
+			expectedErrorCode: "batch_items_rejected",
+			expectedFields: []FieldError{
+				{Field: "[1].rarity", Code: "invalid_rarity", Message: "invalid rarity: 6"},
+			},
+		},
+		{
+			name: "ShouldReportPerIndexErrorsInBestEffortMode",
+
+			mode:             "best_effort",
+			artifactCommands: []CreateArtifactCommand{invalidRarityCommand, validCommand},
+
+			expectedResult: &BatchCreateResultDTO{
+				Mode:    BATCH_MODE_BEST_EFFORT,
+				Created: 1,
+				Failed:  1,
+				Results: []*BatchItemResultDTO{
+					{
+						Index: 0,
+						Error: &BatchItemErrorDTO{
+							Code:    "invalid_rarity",
+							Message: "invalid rarity: 6",
+							Errors:  []FieldError{{Field: "rarity", Code: "invalid_rarity", Message: "invalid rarity: 6"}},
+						},
+					},
+					{Index: 1},
+				},
+			},
+			expectedEachSaved: 1,
+		},
+		{
+			name: "ShouldReportErrorsFromRepositoryPerIndexInBestEffortMode",
+
+			mockSaveEachArtifactErrors: []error{nil, fmt.Errorf("%w: existing", repository.ErrArtifactAlreadyExists)},
+
+			mode:             "best_effort",
+			artifactCommands: []CreateArtifactCommand{validCommand, otherCommand},
+
+			expectedResult: &BatchCreateResultDTO{
+				Mode:    BATCH_MODE_BEST_EFFORT,
+				Created: 1,
+				Failed:  1,
+				Results: []*BatchItemResultDTO{
+					{Index: 0},
+					{
+						Index: 1,
+						Error: &BatchItemErrorDTO{Code: "artifact_already_exists", Message: "artifact already exists: existing"},
+					},
+				},
+			},
+			expectedEachSaved: 2,
+		},
+		{
+			name: "ShouldRejectWholeBatchWhenClientIDIsDuplicatedInAtomicMode",
+
+			mode:             "atomic",
+			artifactCommands: []CreateArtifactCommand{clientIDCommand, sameClientIDCommand},
+
+			expectedErrorCode: "batch_items_rejected",
+			expectedFields: []FieldError{
+				{Field: "[1].id", Code: "duplicate_artifact_id", Message: "artifact ID is duplicated in batch: client-id is also used at index 0"},
+			},
+		},
+		{
+			name: "ShouldReportDuplicatedClientIDPerIndexInBestEffortMode",
+
+			mode:             "best_effort",
+			artifactCommands: []CreateArtifactCommand{clientIDCommand, sameClientIDCommand},
+
+			expectedResult: &BatchCreateResultDTO{
+				Mode:    BATCH_MODE_BEST_EFFORT,
+				Created: 1,
+				Failed:  1,
+				Results: []*BatchItemResultDTO{
+					{Index: 0},
+					{
+						Index: 1,
+						Error: &BatchItemErrorDTO{
+							Code:    "duplicate_artifact_id",
+							Message: "artifact ID is duplicated in batch: client-id is also used at index 0",
+							Errors:  []FieldError{{Field: "id", Code: "duplicate_artifact_id", Message: "artifact ID is duplicated in batch: client-id is also used at index 0"}},
+						},
+					},
+				},
+			},
+			expectedEachSaved: 1,
+		},
+		{
+			name: "ShouldReturnErrorWhenModeIsUnknown",
+
+			mode:             "partial",
+			artifactCommands: []CreateArtifactCommand{validCommand},
+
+			expectedErrorCode: "invalid_batch_mode",
+			expectedFields: []FieldError{
+				{Field: "mode", Code: "invalid_batch_mode", Message: `invalid batch mode: mode must be atomic or best_effort, got "partial"`},
+			},
+		},
+		{
+			name: "ShouldReturnErrorWhenDuplicatePolicyIsUnknown",
+
+			mode:             "atomic",
+			duplicatePolicy:  "ignore",
+			artifactCommands: []CreateArtifactCommand{validCommand},
+
+			expectedErrorCode: "invalid_duplicate_policy",
+			expectedFields: []FieldError{
+				{Field: "on_duplicate", Code: "invalid_duplicate_policy", Message: `invalid duplicate policy: "ignore"`},
+			},
+		},
+		{
+			name: "ShouldReturnErrorWhenBatchIsEmpty",
+
+			mode: "atomic",
+
+			expectedErrorCode: "invalid_batch_size",
+		},
+		{
+			name: "ShouldReturnErrorWhenBatchSaverFails",
+
+			mockSaveArtifactsError: errors.New("disk full"),
+
+			mode:             "atomic",
+			artifactCommands: []CreateArtifactCommand{validCommand},
+
+			expectedErrorCode: ErrorCodeInternal,
+		},
+		{
+			name: "ShouldReturnErrorWhenSaverFailsInBestEffortMode",
+
+			mockSaveEachArtifactError: errors.New("disk full"),
+
+			mode:             "best_effort",
+			artifactCommands: []CreateArtifactCommand{validCommand},
+
+			expectedErrorCode: ErrorCodeInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockArtifactBatchSaver := &repository.MockArtifactBatchSaver{
+				SaveArtifactsError:     tt.mockSaveArtifactsError,
+				SaveEachArtifactErrors: tt.mockSaveEachArtifactErrors,
+				SaveEachArtifactError:  tt.mockSaveEachArtifactError,
+			}
+			batchService := NewBatchArtifactService(
+				&repository.MockArtifactGetter{QueryArtifactsResponse: &repository.ArtifactQueryResult{}},
+				mockArtifactBatchSaver,
+			)
+
+			result, err := batchService.BatchCreateArtifacts(tt.mode, tt.duplicatePolicy, tt.artifactCommands)
+			if tt.expectedErrorCode != "" {
+				var serviceError *Error
+				if !errors.As(err, &serviceError) || serviceError.Code != tt.expectedErrorCode {
+					t.Fatalf("expected error code %s, got %v", tt.expectedErrorCode, err)
+				}
+				if diff := cmp.Diff(tt.expectedFields, serviceError.Fields); diff != "" {
+					t.Errorf("fields mismatch (-want +got):\n%s", diff)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.expectedResult, result, cmpopts.IgnoreFields(BatchItemResultDTO{}, "ID")); diff != "" {
+				t.Errorf("result mismatch (-want +got):\n%s", diff)
+			}
+			for _, itemResult := range result.Results {
+				if (itemResult.ID == "") == (itemResult.Error == nil) {
+					t.Errorf("expected result %d to have either ID or error", itemResult.Index)
+				}
+			}
+			if len(mockArtifactBatchSaver.SavedArtifacts) != tt.expectedBatchSaved {
+				t.Errorf("expected %d artifacts to be saved as a batch, got %d", tt.expectedBatchSaved, len(mockArtifactBatchSaver.SavedArtifacts))
+			}
+			if len(mockArtifactBatchSaver.SavedEachArtifacts) != tt.expectedEachSaved {
+				t.Errorf("expected %d artifacts to be passed to SaveEachArtifact, got %d", tt.expectedEachSaved, len(mockArtifactBatchSaver.SavedEachArtifacts))
+			}
+		})
+	}
+}
+
+func TestBatchArtifactServiceBatchCreateArtifactsDuplicates(t *testing.T) {
+	validCommand := CreateArtifactCommand{
+		ArtifactSet: "GladiatorsFinale",
+		Type:        "FLOWER",
+		Rarity:      5,
+		Level:       0,
+		PrimaryStat: PrimaryStatCommand{Type: "HP_FLAT"},
+		Substats: []StatCommand{
+			{Type: "CRIT_RATE", Value: 3.9},
+			{Type: "CRIT_DMG", Value: 7.8},
+			{Type: "ATK_PERCENT", Value: 5.8},
+		},
+	}
+	commandWithID := func(id string, command CreateArtifactCommand) CreateArtifactCommand {
+		command.ID = id
+		return command
+	}
+	otherCommand := validCommand
+	otherCommand.Type = "PLUME"
+	otherCommand.PrimaryStat = PrimaryStatCommand{Type: "ATK_FLAT"}
+	// 保存済みの聖遺物と重複する要素、重複しない要素、保存済みの聖遺物と先の要素の両方と重複する要素
+	artifactCommands := []CreateArtifactCommand{
+		commandWithID("a", validCommand),
+		commandWithID("b", otherCommand),
+		commandWithID("c", validCommand),
+	}
+	duplicateError := &BatchItemErrorDTO{Code: "duplicate_artifact", Message: "duplicate artifact: same content as existing"}
+
+	tests := []struct {
+		name string
+
+		// WHEN
+		mode            string
+		duplicatePolicy DuplicatePolicy
+
+		// THEN
+		expectedResult    *BatchCreateResultDTO
+		expectedErrorCode string
+		expectedFields    []FieldError
+	}{
+		{
+			name: "ShouldReportDuplicatesPerIndexWhenPolicyIsWarn",
+
+			mode: "best_effort",
+
+			expectedResult: &BatchCreateResultDTO{
+				Mode:    BATCH_MODE_BEST_EFFORT,
+				Created: 3,
+				Results: []*BatchItemResultDTO{
+					{Index: 0, ID: "a", DuplicateOf: []string{"existing"}},
+					{Index: 1, ID: "b"},
+					{Index: 2, ID: "c", DuplicateOf: []string{"existing", "a"}},
+				},
+			},
+		},
+		{
+			name: "ShouldRejectDuplicatesPerIndexWhenPolicyIsReject",
+
+			mode:            "best_effort",
+			duplicatePolicy: DUPLICATE_POLICY_REJECT,
+
+			expectedResult: &BatchCreateResultDTO{
+				Mode:    BATCH_MODE_BEST_EFFORT,
+				Created: 1,
+				Failed:  2,
+				Results: []*BatchItemResultDTO{
+					{Index: 0, Error: duplicateError},
+					{Index: 1, ID: "b"},
+					{Index: 2, Error: duplicateError},
+				},
+			},
+		},
+		{
+			name: "ShouldRejectWholeBatchWhenDuplicateIsRejectedInAtomicMode",
+
+			mode:            "atomic",
+			duplicatePolicy: DUPLICATE_POLICY_REJECT,
+
+			expectedErrorCode: "batch_items_rejected",
+			expectedFields: []FieldError{
+				{Field: "[0]", Code: "duplicate_artifact", Message: "duplicate artifact: same content as existing"},
+				{Field: "[2]", Code: "duplicate_artifact", Message: "duplicate artifact: same content as existing"},
+			},
+		},
+		{
+			name: "ShouldNotCheckDuplicatesWhenPolicyIsAllow",
+
+			mode:            "atomic",
+			duplicatePolicy: DUPLICATE_POLICY_ALLOW,
+
+			expectedResult: &BatchCreateResultDTO{
+				Mode:    BATCH_MODE_ATOMIC,
+				Created: 3,
+				Results: []*BatchItemResultDTO{{Index: 0, ID: "a"}, {Index: 1, ID: "b"}, {Index: 2, ID: "c"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			artifactRepository := repository.NewInMemoryArtifactRepository()
+			existing, err := newArtifactFromCommand("existing", validCommand)
+			if err != nil {
+				t.Fatalf("failed to create existing artifact: %v", err)
+			}
+			if err := artifactRepository.SaveArtifact(existing); err != nil {
+				t.Fatalf("failed to save existing artifact: %v", err)
+			}
+			batchService := NewBatchArtifactService(artifactRepository, artifactRepository)
+
+			// WHEN
+			result, err := batchService.BatchCreateArtifacts(tt.mode, tt.duplicatePolicy, artifactCommands)
+
+			// THEN
+			if tt.expectedErrorCode != "" {
+				var serviceError *Error
+				if !errors.As(err, &serviceError) || serviceError.Code != tt.expectedErrorCode {
+					t.Fatalf("expected error code %s, got %v", tt.expectedErrorCode, err)
+				}
+				if diff := cmp.Diff(tt.expectedFields, serviceError.Fields); diff != "" {
+					t.Errorf("fields mismatch (-want +got):\n%s", diff)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.expectedResult, result); diff != "" {
+				t.Errorf("result mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
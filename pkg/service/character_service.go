@@ -0,0 +1,90 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
+)
+
+var (
+	ErrCharacterNotFound = errors.New("character not found")
+)
+
+type CharacterBaseStatsDTO struct {
+	HP  float64 `json:"hp"`
+	ATK float64 `json:"atk"`
+	DEF float64 `json:"def"`
+}
+
+type CharacterDTO struct {
+	Key           string                `json:"key"`
+	Name          string                `json:"name"`
+	Names         map[string]string     `json:"names"`
+	Element       string                `json:"element"`
+	WeaponType    string                `json:"weapon_type"`
+	Rarity        int                   `json:"rarity"`
+	BaseStats     CharacterBaseStatsDTO `json:"base_stats"`
+	AscensionStat StatusDTO             `json:"ascension_stat"`
+}
+
+type GetCharactersServiceInterface interface {
+	GetCharacters(lang string) []*CharacterDTO
+}
+
+type GetCharacterServiceInterface interface {
+	GetCharacter(key, lang string) (*CharacterDTO, error)
+}
+
+type CharacterService struct{}
+
+func NewCharacterService() *CharacterService {
+	return &CharacterService{}
+}
+
+func (s *CharacterService) GetCharacters(lang string) []*CharacterDTO {
+	characters := entity.Characters()
+
+	characterDTOs := make([]*CharacterDTO, 0, len(characters))
+	for _, character := range characters {
+		characterDTOs = append(characterDTOs, newCharacterDTO(character, lang))
+	}
+	return characterDTOs
+}
+
+func (s *CharacterService) GetCharacter(key, lang string) (*CharacterDTO, error) {
+	character, err := lookupCharacter(key)
+	if err != nil {
+		return nil, err
+	}
+	return newCharacterDTO(character, lang), nil
+}
+
+// lookupCharacter はパスで指定されたキャラクターを引き、カタログになければ ErrCharacterNotFound を返す。
+func lookupCharacter(key string) (*entity.CharacterInfo, error) {
+	character, ok := entity.LookupCharacter(key)
+	if !ok {
+		return nil, ClassifyError(fmt.Errorf("%w: %s", ErrCharacterNotFound, key))
+	}
+	return character, nil
+}
+
+func newCharacterDTO(character *entity.CharacterInfo, lang string) *CharacterDTO {
+	return &CharacterDTO{
+		Key:        string(character.Key),
+		Name:       character.Name(lang),
+		Names:      character.Names,
+		Element:    string(character.Element),
+		WeaponType: string(character.WeaponType),
+		Rarity:     character.Rarity,
+		BaseStats: CharacterBaseStatsDTO{
+			HP:  character.BaseStats.HP,
+			ATK: character.BaseStats.ATK,
+			DEF: character.BaseStats.DEF,
+		},
+		AscensionStat: StatusDTO{
+			Type:  string(character.AscensionStat.Type),
+			Value: character.AscensionStat.Value,
+		},
+	}
+}
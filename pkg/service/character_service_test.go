@@ -0,0 +1,83 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCharacterServiceGetCharacters(t *testing.T) {
+	service := NewCharacterService()
+
+	result := service.GetCharacters("ja")
+
+	if len(result) != len(entity.Characters()) {
+		t.Errorf("expected %d characters, got %d", len(entity.Characters()), len(result))
+	}
+	for _, character := range result {
+		if character.Name == "" {
+			t.Errorf("character %s has empty name", character.Key)
+		}
+	}
+}
+
+func TestCharacterServiceGetCharacter(t *testing.T) {
+	tests := []struct {
+		name string
+
+		// WHEN
+		key  string
+		lang string
+
+		// THEN
+		expectedCharacter *CharacterDTO
+		expectedError     error
+	}{
+		{
+			name: "ShouldGetCharacterSuccessfully",
+
+			key:  "RaidenShogun",
+			lang: "ja",
+
+			expectedCharacter: &CharacterDTO{
+				Key:           "RaidenShogun",
+				Name:          "雷電将軍",
+				Names:         map[string]string{"en": "Raiden Shogun", "ja": "雷電将軍"},
+				Element:       "ELECTRO",
+				WeaponType:    "POLEARM",
+				Rarity:        5,
+				BaseStats:     CharacterBaseStatsDTO{HP: 12907, ATK: 337, DEF: 789},
+				AscensionStat: StatusDTO{Type: "ENERGY_RECHARGE", Value: 32},
+			},
+		},
+		{
+			name: "ShouldReturnErrorWhenCharacterNotFound",
+
+			key:  "Raiden",
+			lang: "en",
+
+			expectedError: ErrCharacterNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewCharacterService()
+
+			result, err := service.GetCharacter(tt.key, tt.lang)
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("GetCharacter() error = %v, expectedError %v", err, tt.expectedError)
+			}
+			if err != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tt.expectedCharacter, result); diff != "" {
+				t.Errorf("GetCharacter() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
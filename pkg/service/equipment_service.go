@@ -0,0 +1,212 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/repository"
+)
+
+// LoadoutDTO はキャラクターが部位ごとに装備している聖遺物で、装備していない部位は null になる。
+type LoadoutDTO struct {
+	Character string       `json:"character"`
+	Flower    *ArtifactDTO `json:"flower"`
+	Plume     *ArtifactDTO `json:"plume"`
+	Sands     *ArtifactDTO `json:"sands"`
+	Goblet    *ArtifactDTO `json:"goblet"`
+	Circlet   *ArtifactDTO `json:"circlet"`
+}
+
+type EquipArtifactCommand struct {
+	ArtifactID string
+}
+
+type GetLoadoutServiceInterface interface {
+	GetLoadout(character string) (*LoadoutDTO, error)
+}
+
+type EquipArtifactServiceInterface interface {
+	EquipArtifact(character string, equipCommand EquipArtifactCommand) (*LoadoutDTO, error)
+}
+
+type UnequipArtifactServiceInterface interface {
+	UnequipArtifact(character, artifactType string) (*LoadoutDTO, error)
+}
+
+type EquipmentService struct {
+	artifactGetter   repository.ArtifactGetter
+	artifactEquipper repository.ArtifactEquipper
+}
+
+func NewEquipmentService(
+	artifactGetter repository.ArtifactGetter,
+	artifactEquipper repository.ArtifactEquipper,
+) *EquipmentService {
+	return &EquipmentService{
+		artifactGetter:   artifactGetter,
+		artifactEquipper: artifactEquipper,
+	}
+}
+
+// GetLoadout はキャラクターが装備している聖遺物を部位ごとに返す。
+// カタログにないキャラクターの場合は ErrCharacterNotFound を返す。
+func (s *EquipmentService) GetLoadout(character string) (*LoadoutDTO, error) {
+	characterInfo, err := lookupCharacter(character)
+	if err != nil {
+		return nil, err
+	}
+	return s.getLoadout(characterInfo.Key)
+}
+
+// EquipArtifact はゲーム内と同じように聖遺物をキャラクターに装備させる。
+// 同じ部位に別の聖遺物を装備していた場合、その聖遺物は装備させた聖遺物の元の持ち主に渡し、
+// 元の持ち主がいなければ外す。入れ替えはリポジトリが書き込みロックの中でまとめて行うため、
+// 同時に装備を変更しても同じ部位に二つの聖遺物を装備することはない。
+func (s *EquipmentService) EquipArtifact(character string, equipCommand EquipArtifactCommand) (*LoadoutDTO, error) {
+	characterInfo, err := lookupCharacter(character)
+	if err != nil {
+		return nil, err
+	}
+	if equipCommand.ArtifactID == "" {
+		return nil, NewValidationError("invalid_artifact_id", "artifact_id", entity.ErrInvalidArtifactID)
+	}
+
+	if err := s.artifactEquipper.EquipArtifact(equipCommand.ArtifactID, characterInfo.Key); err != nil {
+		return nil, ClassifyError(err)
+	}
+
+	return s.getLoadout(characterInfo.Key)
+}
+
+// UnequipArtifact はキャラクターの指定した部位の聖遺物を外す。何も装備していなければ何もしない。
+func (s *EquipmentService) UnequipArtifact(character, artifactType string) (*LoadoutDTO, error) {
+	characterInfo, err := lookupCharacter(character)
+	if err != nil {
+		return nil, err
+	}
+	artifactTypeEnum, err := parseArtifactType(artifactType)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.artifactEquipper.UnequipArtifact(characterInfo.Key, artifactTypeEnum); err != nil {
+		return nil, ClassifyError(err)
+	}
+
+	return s.getLoadout(characterInfo.Key)
+}
+
+func (s *EquipmentService) getLoadout(character entity.CharacterKey) (*LoadoutDTO, error) {
+	result, err := s.artifactGetter.QueryArtifacts(repository.ArtifactQuery{
+		EquippedBy: []entity.CharacterKey{character},
+	})
+	if err != nil {
+		return nil, ClassifyError(err)
+	}
+
+	loadoutDTO := &LoadoutDTO{Character: string(character)}
+	for _, artifact := range result.Artifacts {
+		var slot **ArtifactDTO
+		switch artifact.Type {
+		case entity.ARTIFACT_TYPE_FLOWER:
+			slot = &loadoutDTO.Flower
+		case entity.ARTIFACT_TYPE_PLUME:
+			slot = &loadoutDTO.Plume
+		case entity.ARTIFACT_TYPE_SANDS:
+			slot = &loadoutDTO.Sands
+		case entity.ARTIFACT_TYPE_GOBLET:
+			slot = &loadoutDTO.Goblet
+		case entity.ARTIFACT_TYPE_CIRCLET:
+			slot = &loadoutDTO.Circlet
+		default:
+			continue
+		}
+		// 旧データで同じ部位を複数装備している場合は ID 順で先の聖遺物を返す
+		if *slot == nil {
+			*slot = newArtifactDTO(artifact)
+		}
+	}
+	return loadoutDTO, nil
+}
+
+// findEquippedArtifact はキャラクターがその部位に装備している聖遺物を返し、なければ nil を返す。
+func findEquippedArtifact(artifactGetter repository.ArtifactGetter, character entity.CharacterKey, artifactType entity.ArtifactType) (*entity.Artifact, error) {
+	result, err := artifactGetter.QueryArtifacts(repository.ArtifactQuery{
+		Types:      []entity.ArtifactType{artifactType},
+		EquippedBy: []entity.CharacterKey{character},
+		Limit:      1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Artifacts) == 0 {
+		return nil, nil
+	}
+	return result.Artifacts[0], nil
+}
+
+type equipmentSlot struct {
+	character    entity.CharacterKey
+	artifactType entity.ArtifactType
+}
+
+// equipmentChecker は作成・更新する聖遺物を装備するキャラクターがカタログにあり、
+// そのキャラクターの同じ部位に他の聖遺物を装備していないことを確かめる。
+// まとめて保存する聖遺物どうしの重複も検出するため、一度の処理では同じ checker を使うこと。
+// 部位の判定は書き込む前にエラーの位置を返すためのもので、同時に書き込まれた場合の最終的な判定はリポジトリが行う。
+type equipmentChecker struct {
+	artifactGetter repository.ArtifactGetter
+	// reserved は同じ処理の中で先に確かめた聖遺物の部位と ID
+	reserved map[equipmentSlot]string
+}
+
+func newEquipmentChecker(artifactGetter repository.ArtifactGetter) *equipmentChecker {
+	return &equipmentChecker{
+		artifactGetter: artifactGetter,
+		reserved:       make(map[equipmentSlot]string),
+	}
+}
+
+// check は保存済みの聖遺物から装備するキャラクターと部位が変わらない場合は確かめない。
+func (c *equipmentChecker) check(artifact *entity.Artifact) error {
+	if artifact.EquippedBy == "" {
+		return nil
+	}
+
+	current, err := c.artifactGetter.GetArtifactByID(artifact.ID)
+	if err != nil && !errors.Is(err, repository.ErrArtifactNotFound) {
+		return ClassifyError(err)
+	}
+	if !equipmentChanged(current, artifact) {
+		return nil
+	}
+
+	if _, err := entity.ParseCharacterKey(string(artifact.EquippedBy)); err != nil {
+		return ClassifyError(err)
+	}
+	slot := equipmentSlot{character: artifact.EquippedBy, artifactType: artifact.Type}
+	if id, reserved := c.reserved[slot]; reserved && id != artifact.ID {
+		return ClassifyError(fmt.Errorf("%w: %s already equips %s %s", repository.ErrEquipmentSlotOccupied, slot.character, slot.artifactType, id))
+	}
+	occupant, err := findEquippedArtifact(c.artifactGetter, slot.character, slot.artifactType)
+	if err != nil {
+		return ClassifyError(err)
+	}
+	if occupant != nil && occupant.ID != artifact.ID {
+		return ClassifyError(fmt.Errorf("%w: %s already equips %s %s", repository.ErrEquipmentSlotOccupied, slot.character, slot.artifactType, occupant.ID))
+	}
+
+	c.reserved[slot] = artifact.ID
+	return nil
+}
+
+// equipmentChanged は artifact がキャラクターに装備され、保存済みの聖遺物 current から
+// 装備するキャラクターか部位が変わる場合に true を返す。current が nil の場合は新しく保存する聖遺物として扱う。
+// カタログにないキャラクターを装備した旧データも、装備を変えなければ編集できるようにするため、変わらない場合は確かめない。
+func equipmentChanged(current, artifact *entity.Artifact) bool {
+	if artifact.EquippedBy == "" {
+		return false
+	}
+	return current == nil || current.EquippedBy != artifact.EquippedBy || current.Type != artifact.Type
+}
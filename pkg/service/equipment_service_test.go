@@ -0,0 +1,363 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/repository"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// newEquipmentTestRepository は雷電将軍が花と時計、香菱が花を装備し、花と冠が余っている状態を作る。
+func newEquipmentTestRepository(t *testing.T) *repository.InMemoryArtifactRepository {
+	t.Helper()
+
+	artifactRepository := repository.NewInMemoryArtifactRepository()
+	artifacts := []*entity.Artifact{
+		{ID: "raiden-flower", Type: entity.ARTIFACT_TYPE_FLOWER, EquippedBy: "RaidenShogun"},
+		{ID: "raiden-sands", Type: entity.ARTIFACT_TYPE_SANDS, EquippedBy: "RaidenShogun"},
+		{ID: "xiangling-flower", Type: entity.ARTIFACT_TYPE_FLOWER, EquippedBy: "Xiangling"},
+		{ID: "spare-flower", Type: entity.ARTIFACT_TYPE_FLOWER},
+		{ID: "spare-circlet", Type: entity.ARTIFACT_TYPE_CIRCLET},
+	}
+	for _, artifact := range artifacts {
+		artifact.ArtifactSet = entity.ARTIFACT_SET_GLADIATORS_FINALOFFERING
+		artifact.Rarity = 5
+		if err := artifactRepository.SaveArtifact(artifact); err != nil {
+			t.Fatalf("failed to save artifact: %v", err)
+		}
+	}
+	return artifactRepository
+}
+
+// loadoutIDs は花・羽・時計・杯・冠の順に装備している聖遺物の ID を返す。
+func loadoutIDs(loadoutDTO *LoadoutDTO) []string {
+	ids := make([]string, 0, 5)
+	for _, artifactDTO := range []*ArtifactDTO{loadoutDTO.Flower, loadoutDTO.Plume, loadoutDTO.Sands, loadoutDTO.Goblet, loadoutDTO.Circlet} {
+		if artifactDTO == nil {
+			ids = append(ids, "")
+			continue
+		}
+		ids = append(ids, artifactDTO.ID)
+	}
+	return ids
+}
+
+func equippedBy(t *testing.T, artifactRepository *repository.InMemoryArtifactRepository) map[string]entity.CharacterKey {
+	t.Helper()
+
+	result, err := artifactRepository.QueryArtifacts(repository.ArtifactQuery{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	equipped := make(map[string]entity.CharacterKey, len(result.Artifacts))
+	for _, artifact := range result.Artifacts {
+		equipped[artifact.ID] = artifact.EquippedBy
+	}
+	return equipped
+}
+
+func TestEquipmentServiceGetLoadout(t *testing.T) {
+	tests := []struct {
+		name string
+
+		// WHEN
+		character string
+
+		// THEN
+		expectedLoadoutIDs []string
+		expectedError      error
+	}{
+		{
+			name: "ShouldReturnEquippedArtifactsBySlot",
+
+			character: "RaidenShogun",
+
+			expectedLoadoutIDs: []string{"raiden-flower", "", "raiden-sands", "", ""},
+		},
+		{
+			name: "ShouldReturnEmptyLoadoutWhenNothingIsEquipped",
+
+			character: "Bennett",
+
+			expectedLoadoutIDs: []string{"", "", "", "", ""},
+		},
+		{
+			name: "ShouldReturnErrorWhenCharacterIsNotInCatalog",
+
+			character: "Raiden",
+
+			expectedError: ErrCharacterNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			artifactRepository := newEquipmentTestRepository(t)
+			equipmentService := NewEquipmentService(artifactRepository, artifactRepository)
+
+			// WHEN
+			result, err := equipmentService.GetLoadout(tt.character)
+
+			// THEN
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error: %v, got: %v", tt.expectedError, err)
+			}
+			if err != nil {
+				return
+			}
+			if result.Character != tt.character {
+				t.Errorf("expected character %s, got %s", tt.character, result.Character)
+			}
+			if diff := cmp.Diff(tt.expectedLoadoutIDs, loadoutIDs(result)); diff != "" {
+				t.Errorf("loadout mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEquipmentServiceEquipArtifact(t *testing.T) {
+	tests := []struct {
+		name string
+
+		// WHEN
+		character    string
+		equipCommand EquipArtifactCommand
+
+		// THEN
+		expectedLoadoutIDs []string
+		expectedEquippedBy map[string]entity.CharacterKey
+		expectedError      error
+	}{
+		{
+			name: "ShouldEquipArtifactToEmptySlot",
+
+			character:    "RaidenShogun",
+			equipCommand: EquipArtifactCommand{ArtifactID: "spare-circlet"},
+
+			expectedLoadoutIDs: []string{"raiden-flower", "", "raiden-sands", "", "spare-circlet"},
+			expectedEquippedBy: map[string]entity.CharacterKey{
+				"raiden-flower": "RaidenShogun", "raiden-sands": "RaidenShogun", "xiangling-flower": "Xiangling",
+				"spare-flower": "", "spare-circlet": "RaidenShogun",
+			},
+		},
+		{
+			name: "ShouldUnequipReplacedArtifactWhenEquippingUnusedArtifact",
+
+			character:    "RaidenShogun",
+			equipCommand: EquipArtifactCommand{ArtifactID: "spare-flower"},
+
+			expectedLoadoutIDs: []string{"spare-flower", "", "raiden-sands", "", ""},
+			expectedEquippedBy: map[string]entity.CharacterKey{
+				"raiden-flower": "", "raiden-sands": "RaidenShogun", "xiangling-flower": "Xiangling",
+				"spare-flower": "RaidenShogun", "spare-circlet": "",
+			},
+		},
+		{
+			name: "ShouldSwapArtifactsWithPreviousOwner",
+
+			character:    "RaidenShogun",
+			equipCommand: EquipArtifactCommand{ArtifactID: "xiangling-flower"},
+
+			expectedLoadoutIDs: []string{"xiangling-flower", "", "raiden-sands", "", ""},
+			expectedEquippedBy: map[string]entity.CharacterKey{
+				"raiden-flower": "Xiangling", "raiden-sands": "RaidenShogun", "xiangling-flower": "RaidenShogun",
+				"spare-flower": "", "spare-circlet": "",
+			},
+		},
+		{
+			name: "ShouldDoNothingWhenArtifactIsAlreadyEquipped",
+
+			character:    "RaidenShogun",
+			equipCommand: EquipArtifactCommand{ArtifactID: "raiden-flower"},
+
+			expectedLoadoutIDs: []string{"raiden-flower", "", "raiden-sands", "", ""},
+			expectedEquippedBy: map[string]entity.CharacterKey{
+				"raiden-flower": "RaidenShogun", "raiden-sands": "RaidenShogun", "xiangling-flower": "Xiangling",
+				"spare-flower": "", "spare-circlet": "",
+			},
+		},
+		{
+			name: "ShouldReturnErrorWhenCharacterIsNotInCatalog",
+
+			character:    "Raiden",
+			equipCommand: EquipArtifactCommand{ArtifactID: "spare-flower"},
+
+			expectedError: ErrCharacterNotFound,
+		},
+		{
+			name: "ShouldReturnErrorWhenArtifactIDIsEmpty",
+
+			character:    "RaidenShogun",
+			equipCommand: EquipArtifactCommand{},
+
+			expectedError: entity.ErrInvalidArtifactID,
+		},
+		{
+			name: "ShouldReturnErrorWhenArtifactDoesNotExist",
+
+			character:    "RaidenShogun",
+			equipCommand: EquipArtifactCommand{ArtifactID: "non-existent-id"},
+
+			expectedError: repository.ErrArtifactNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			artifactRepository := newEquipmentTestRepository(t)
+			equipmentService := NewEquipmentService(artifactRepository, artifactRepository)
+
+			// WHEN
+			result, err := equipmentService.EquipArtifact(tt.character, tt.equipCommand)
+
+			// THEN
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error: %v, got: %v", tt.expectedError, err)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.expectedLoadoutIDs, loadoutIDs(result)); diff != "" {
+				t.Errorf("loadout mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.expectedEquippedBy, equippedBy(t, artifactRepository)); diff != "" {
+				t.Errorf("equipped by mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEquipmentServiceEquipArtifactClassifiesRepositoryError(t *testing.T) {
+	tests := []struct {
+		name string
+
+		// GIVEN
+		equipArtifactError error
+
+		// THEN
+		expectedKind ErrorKind
+		expectedCode string
+	}{
+		{
+			name: "ShouldReturnConflictWhenSlotIsOccupiedConcurrently",
+
+			equipArtifactError: fmt.Errorf("%w: RaidenShogun already equips FLOWER other-flower", repository.ErrEquipmentSlotOccupied),
+
+			expectedKind: ERROR_KIND_CONFLICT,
+			expectedCode: "equipment_slot_occupied",
+		},
+		{
+			name: "ShouldReturnNotFoundWhenArtifactIsDeletedConcurrently",
+
+			equipArtifactError: repository.ErrArtifactNotFound,
+
+			expectedKind: ERROR_KIND_NOT_FOUND,
+			expectedCode: "artifact_not_found",
+		},
+		{
+			name: "ShouldReturnInternalErrorWhenRepositoryFails",
+
+			equipArtifactError: errors.New("disk full"),
+
+			expectedKind: ERROR_KIND_INTERNAL,
+			expectedCode: ErrorCodeInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			artifactRepository := newEquipmentTestRepository(t)
+			equipmentService := NewEquipmentService(artifactRepository, &repository.MockArtifactEquipper{
+				EquipArtifactError: tt.equipArtifactError,
+			})
+
+			// WHEN
+			_, err := equipmentService.EquipArtifact("RaidenShogun", EquipArtifactCommand{ArtifactID: "xiangling-flower"})
+
+			// THEN
+			var serviceError *Error
+			if !errors.As(err, &serviceError) {
+				t.Fatalf("expected *Error, got %v", err)
+			}
+			if diff := cmp.Diff([]string{string(tt.expectedKind), tt.expectedCode}, []string{string(serviceError.Kind), serviceError.Code}); diff != "" {
+				t.Errorf("error mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEquipmentServiceUnequipArtifact(t *testing.T) {
+	tests := []struct {
+		name string
+
+		// WHEN
+		character    string
+		artifactType string
+
+		// THEN
+		expectedLoadoutIDs []string
+		expectedError      error
+	}{
+		{
+			name: "ShouldUnequipArtifactInSlot",
+
+			character:    "RaidenShogun",
+			artifactType: "FLOWER",
+
+			expectedLoadoutIDs: []string{"", "", "raiden-sands", "", ""},
+		},
+		{
+			name: "ShouldDoNothingWhenSlotIsEmpty",
+
+			character:    "RaidenShogun",
+			artifactType: "GOBLET",
+
+			expectedLoadoutIDs: []string{"raiden-flower", "", "raiden-sands", "", ""},
+		},
+		{
+			name: "ShouldReturnErrorWhenArtifactTypeIsInvalid",
+
+			character:    "RaidenShogun",
+			artifactType: "RING",
+
+			expectedError: entity.ErrInvalidArtifactType,
+		},
+		{
+			name: "ShouldReturnErrorWhenCharacterIsNotInCatalog",
+
+			character:    "Raiden",
+			artifactType: "FLOWER",
+
+			expectedError: ErrCharacterNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			artifactRepository := newEquipmentTestRepository(t)
+			equipmentService := NewEquipmentService(artifactRepository, artifactRepository)
+
+			// WHEN
+			result, err := equipmentService.UnequipArtifact(tt.character, tt.artifactType)
+
+			// THEN
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error: %v, got: %v", tt.expectedError, err)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.expectedLoadoutIDs, loadoutIDs(result)); diff != "" {
+				t.Errorf("loadout mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
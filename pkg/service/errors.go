@@ -0,0 +1,137 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/repository"
+)
+
+// ErrorKind はエラーの分類で、ハンドラーはこれを HTTP ステータスに対応付ける。
+type ErrorKind string
+
+const ERROR_KIND_VALIDATION ErrorKind = "validation"
+const ERROR_KIND_NOT_FOUND ErrorKind = "not_found"
+const ERROR_KIND_CONFLICT ErrorKind = "conflict"
+const ERROR_KIND_UNAUTHENTICATED ErrorKind = "unauthenticated"
+const ERROR_KIND_FORBIDDEN ErrorKind = "forbidden"
+const ERROR_KIND_TOO_LARGE ErrorKind = "too_large"
+const ERROR_KIND_INTERNAL ErrorKind = "internal"
+
+const ErrorCodeInternal = "internal"
+
+// FieldError は入力のどの項目がなぜ不正だったかを表す。
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Error はサービスが返すエラーで、分類と機械可読なコードを持つ。
+// 元のエラーは Unwrap で取り出せるため、errors.Is で個別のエラーを判定できる。
+type Error struct {
+	Kind   ErrorKind
+	Code   string
+	Fields []FieldError
+	Err    error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// NewValidationError は入力の誤りを表すエラーを返す。field が空の場合は項目を特定しない。
+func NewValidationError(code, field string, err error) *Error {
+	validationError := &Error{
+		Kind: ERROR_KIND_VALIDATION,
+		Code: code,
+		Err:  err,
+	}
+	if field != "" {
+		validationError.Fields = []FieldError{{Field: field, Code: code, Message: err.Error()}}
+	}
+	return validationError
+}
+
+func newInternalError(err error) *Error {
+	return &Error{Kind: ERROR_KIND_INTERNAL, Code: ErrorCodeInternal, Err: err}
+}
+
+type errorClassification struct {
+	target error
+	kind   ErrorKind
+	code   string
+	field  string
+}
+
+// errorClassifications はエンティティ・リポジトリのエラーと分類の対応表。
+// field はリクエストボディの項目名で、パスやクエリの値を検証する場合は呼び出し側で NewValidationError を使う。
+var errorClassifications = []errorClassification{
+	{entity.ErrInvalidArtifactID, ERROR_KIND_VALIDATION, "invalid_artifact_id", "id"},
+	{entity.ErrInvalidArtifactType, ERROR_KIND_VALIDATION, "invalid_artifact_type", "type"},
+	{entity.ErrInvalidArtifactSet, ERROR_KIND_VALIDATION, "invalid_artifact_set", "artifact_set"},
+	{entity.ErrInvalidPrimaryStatType, ERROR_KIND_VALIDATION, "invalid_primary_stat", "primary_stat.type"},
+	{entity.ErrPrimaryStatNotAllowedForType, ERROR_KIND_VALIDATION, "primary_stat_not_allowed_for_type", "primary_stat.type"},
+	{entity.ErrPrimaryStatValueMismatch, ERROR_KIND_VALIDATION, "primary_stat_value_mismatch", "primary_stat.value"},
+	{entity.ErrInvalidSubstatType, ERROR_KIND_VALIDATION, "invalid_substat_type", "substats"},
+	{entity.ErrSubstatDuplicatesPrimaryStat, ERROR_KIND_VALIDATION, "substat_duplicates_primary_stat", "substats"},
+	{entity.ErrDuplicateSubstat, ERROR_KIND_VALIDATION, "duplicate_substat", "substats"},
+	{entity.ErrSubstatCountMismatchRarity, ERROR_KIND_VALIDATION, "substat_count_mismatch_rarity", "substats"},
+	{entity.ErrImpossibleSubstatValue, ERROR_KIND_VALIDATION, "impossible_substat_value", "substats"},
+	{entity.ErrSubstatRollCountMismatch, ERROR_KIND_VALIDATION, "substat_roll_count_mismatch", "substats"},
+	{entity.ErrInvalidRarity, ERROR_KIND_VALIDATION, "invalid_rarity", "rarity"},
+	{entity.ErrRarityExceedsSetMaxRarity, ERROR_KIND_VALIDATION, "rarity_exceeds_set_max_rarity", "rarity"},
+	{entity.ErrInvalidLevel, ERROR_KIND_VALIDATION, "invalid_level", "level"},
+	{entity.ErrInvalidScoreProfileName, ERROR_KIND_VALIDATION, "invalid_score_profile_name", "name"},
+	{entity.ErrInvalidScoreProfileWeight, ERROR_KIND_VALIDATION, "invalid_score_profile_weight", "weights"},
+	{repository.ErrArtifactIDIsEmpty, ERROR_KIND_VALIDATION, "invalid_artifact_id", "id"},
+	{repository.ErrInvalidCursor, ERROR_KIND_VALIDATION, "invalid_cursor", "cursor"},
+	{repository.ErrInvalidArtifactQuery, ERROR_KIND_VALIDATION, "invalid_artifact_query", ""},
+	{repository.ErrInvalidAccountName, ERROR_KIND_VALIDATION, "invalid_account_name", "name"},
+	{entity.ErrInvalidAPIKeyName, ERROR_KIND_VALIDATION, "invalid_api_key_name", "name"},
+	{entity.ErrInvalidAPIKeyScope, ERROR_KIND_VALIDATION, "invalid_api_key_scope", "scope"},
+	{entity.ErrInvalidAPIKeyAccounts, ERROR_KIND_VALIDATION, "invalid_api_key_accounts", "accounts"},
+	{entity.ErrInvalidCharacter, ERROR_KIND_VALIDATION, "invalid_character", "location"},
+
+	{repository.ErrArtifactNotFound, ERROR_KIND_NOT_FOUND, "artifact_not_found", ""},
+	{repository.ErrScoreProfileNotFound, ERROR_KIND_NOT_FOUND, "score_profile_not_found", ""},
+	{ErrArtifactSetNotFound, ERROR_KIND_NOT_FOUND, "artifact_set_not_found", ""},
+	{repository.ErrAccountNotFound, ERROR_KIND_NOT_FOUND, "account_not_found", ""},
+	{repository.ErrAPIKeyNotFound, ERROR_KIND_NOT_FOUND, "api_key_not_found", ""},
+	{ErrCharacterNotFound, ERROR_KIND_NOT_FOUND, "character_not_found", ""},
+
+	{repository.ErrArtifactAlreadyExists, ERROR_KIND_CONFLICT, "artifact_already_exists", ""},
+	{repository.ErrDuplicateArtifact, ERROR_KIND_CONFLICT, "duplicate_artifact", ""},
+	{repository.ErrAccountAlreadyExists, ERROR_KIND_CONFLICT, "account_already_exists", ""},
+	{repository.ErrEquipmentSlotOccupied, ERROR_KIND_CONFLICT, "equipment_slot_occupied", ""},
+
+	{ErrAuthenticationRequired, ERROR_KIND_UNAUTHENTICATED, "authentication_required", ""},
+	{ErrInvalidAPIKey, ERROR_KIND_UNAUTHENTICATED, "invalid_api_key", ""},
+
+	{ErrReadOnlyAPIKey, ERROR_KIND_FORBIDDEN, "insufficient_scope", ""},
+	{ErrAccountForbidden, ERROR_KIND_FORBIDDEN, "account_forbidden", ""},
+}
+
+// ClassifyError は err を *Error に変換する。すでに *Error であればそのまま返し、
+// 対応表にないエラーは内部エラーとして扱う。err は nil であってはならない。
+func ClassifyError(err error) *Error {
+	var serviceError *Error
+	if errors.As(err, &serviceError) {
+		return serviceError
+	}
+
+	for _, classification := range errorClassifications {
+		if !errors.Is(err, classification.target) {
+			continue
+		}
+		if classification.kind == ERROR_KIND_VALIDATION {
+			return NewValidationError(classification.code, classification.field, err)
+		}
+		return &Error{Kind: classification.kind, Code: classification.code, Err: err}
+	}
+	return newInternalError(err)
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/repository"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClassifyError(t *testing.T) {
+	alreadyClassified := NewValidationError("invalid_artifact_type", "type", entity.ErrInvalidArtifactType)
+
+	tests := []struct {
+		name string
+
+		// WHEN
+		err error
+
+		// THEN
+		expectedKind   ErrorKind
+		expectedCode   string
+		expectedFields []FieldError
+	}{
+		{
+			name: "ShouldClassifyWrappedEntityErrorAsValidationWithField",
+
+			err: fmt.Errorf("%w: 6", entity.ErrInvalidRarity),
+
+			expectedKind: ERROR_KIND_VALIDATION,
+			expectedCode: "invalid_rarity",
+			expectedFields: []FieldError{
+				{Field: "rarity", Code: "invalid_rarity", Message: "invalid rarity: 6"},
+			},
+		},
+		{
+			name: "ShouldClassifyNotFound",
+
+			err: repository.ErrArtifactNotFound,
+
+			expectedKind: ERROR_KIND_NOT_FOUND,
+			expectedCode: "artifact_not_found",
+		},
+		{
+			name: "ShouldClassifyConflict",
+
+			err: repository.ErrArtifactAlreadyExists,
+
+			expectedKind: ERROR_KIND_CONFLICT,
+			expectedCode: "artifact_already_exists",
+		},
+		{
+			name: "ShouldClassifyUnknownErrorAsInternal",
+
+			err: errors.New("disk full"),
+
+			expectedKind: ERROR_KIND_INTERNAL,
+			expectedCode: ErrorCodeInternal,
+		},
+		{
+			name: "ShouldKeepAlreadyClassifiedError",
+
+			err: fmt.Errorf("wrapped: %w", alreadyClassified),
+
+			expectedKind:   ERROR_KIND_VALIDATION,
+			expectedCode:   "invalid_artifact_type",
+			expectedFields: alreadyClassified.Fields,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serviceError := ClassifyError(tt.err)
+
+			if serviceError.Kind != tt.expectedKind {
+				t.Errorf("expected kind: %s, got: %s", tt.expectedKind, serviceError.Kind)
+			}
+			if serviceError.Code != tt.expectedCode {
+				t.Errorf("expected code: %s, got: %s", tt.expectedCode, serviceError.Code)
+			}
+			if diff := cmp.Diff(tt.expectedFields, serviceError.Fields); diff != "" {
+				t.Errorf("fields mismatch (-want +got):\n%s", diff)
+			}
+			if !errors.Is(serviceError, tt.err) && !errors.Is(tt.err, serviceError) {
+				t.Errorf("expected classified error to wrap %v", tt.err)
+			}
+		})
+	}
+}
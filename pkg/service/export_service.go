@@ -0,0 +1,254 @@
+package service
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/repository"
+)
+
+var ErrInvalidExportFormat = errors.New("invalid export format")
+
+type ExportFormat string
+
+const EXPORT_FORMAT_GOOD ExportFormat = "good"
+const EXPORT_FORMAT_CSV ExportFormat = "csv"
+const EXPORT_FORMAT_JSONL ExportFormat = "jsonl"
+
+// GOODExportSource は GOOD 形式で出力する際の source フィールドの値。
+const GOODExportSource = "genshin-artifact-db"
+
+// goodExportVersion は出力する GOOD 形式のバージョン。
+const goodExportVersion = 2
+
+// csvSubstatColumns は CSV でサブステータスごとに設ける列の順序。
+var csvSubstatColumns = []entity.SubstatType{
+	entity.SUBSTAT_HP_FLAT,
+	entity.SUBSTAT_ATK_FLAT,
+	entity.SUBSTAT_DEF_FLAT,
+	entity.SUBSTAT_HP_PERCENT,
+	entity.SUBSTAT_ATK_PERCENT,
+	entity.SUBSTAT_DEF_PERCENT,
+	entity.SUBSTAT_ELEMENTAL_MASTERY,
+	entity.SUBSTAT_ENERGY_RECHARGE,
+	entity.SUBSTAT_CRIT_RATE,
+	entity.SUBSTAT_CRIT_DMG,
+}
+
+// ExportPageSize は書き出す際に 1 回の検索で読む聖遺物の数。
+const ExportPageSize = 1000
+
+// ArtifactExport は検索条件に合う聖遺物を指定の形式で書き出す。
+// 検索や形式の誤りは ExportArtifacts の時点でエラーになるため、書き出し中のエラーは保存先か出力先の障害のみとなる。
+type ArtifactExport struct {
+	Format      ExportFormat
+	ContentType string
+	FileName    string
+	// Skipped は出力形式で表現できず書き出さなかった聖遺物の数で、Stream が終わるまで確定しない
+	Skipped int
+
+	write func(w io.Writer) error
+}
+
+// Stream は聖遺物をページごとに読みながら、1件ずつ w に書き出す。
+func (e *ArtifactExport) Stream(w io.Writer) error {
+	e.Skipped = 0
+	return e.write(w)
+}
+
+// artifactPages は検索条件に合う聖遺物を ExportPageSize 件ずつカーソルで読む。
+// 最初のページは ExportArtifacts で読み、検索の誤りを書き出しの前に返せるようにする。
+type artifactPages struct {
+	artifactGetter repository.ArtifactGetter
+	query          repository.ArtifactQuery
+	first          *repository.ArtifactQueryResult
+}
+
+// each は聖遺物を並び順に visit に渡す。visit がエラーを返した場合はそこで止める。
+func (p *artifactPages) each(visit func(artifact *entity.Artifact) error) error {
+	result := p.first
+	for {
+		for _, artifact := range result.Artifacts {
+			if err := visit(artifact); err != nil {
+				return err
+			}
+		}
+		if result.NextCursor == "" {
+			return nil
+		}
+
+		query := p.query
+		query.Cursor = result.NextCursor
+		var err error
+		result, err = p.artifactGetter.QueryArtifacts(query)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+type ExportArtifactsServiceInterface interface {
+	ExportArtifacts(format string, queryCommand QueryArtifactsCommand) (*ArtifactExport, error)
+}
+
+type ExportService struct {
+	artifactGetter repository.ArtifactGetter
+}
+
+func NewExportService(artifactGetter repository.ArtifactGetter) *ExportService {
+	return &ExportService{
+		artifactGetter: artifactGetter,
+	}
+}
+
+// ExportArtifacts は一覧の検索と同じ条件で絞り込んだ聖遺物をすべて書き出す。
+// ページングの指定 (limit, offset, cursor) は無視する。
+func (s *ExportService) ExportArtifacts(format string, queryCommand QueryArtifactsCommand) (*ArtifactExport, error) {
+	exportFormat := ExportFormat(format)
+	switch exportFormat {
+	case EXPORT_FORMAT_GOOD, EXPORT_FORMAT_CSV, EXPORT_FORMAT_JSONL:
+	default:
+		return nil, NewValidationError("invalid_export_format", "format", fmt.Errorf("%w: %q", ErrInvalidExportFormat, format))
+	}
+
+	queryCommand.Limit, queryCommand.Offset, queryCommand.Cursor = 0, 0, ""
+	query, err := newArtifactQuery(queryCommand)
+	if err != nil {
+		return nil, err
+	}
+	query.Limit = ExportPageSize
+
+	first, err := s.artifactGetter.QueryArtifacts(*query)
+	if err != nil {
+		return nil, ClassifyError(err)
+	}
+	pages := &artifactPages{artifactGetter: s.artifactGetter, query: *query, first: first}
+
+	switch exportFormat {
+	case EXPORT_FORMAT_GOOD:
+		return newGOODExport(pages), nil
+	case EXPORT_FORMAT_CSV:
+		return newCSVExport(pages), nil
+	default:
+		return newJSONLExport(pages), nil
+	}
+}
+
+// newGOODExport は GOOD のキーで表現できない聖遺物 (元素不明の元素ダメージバフなど) を除いて書き出す。
+func newGOODExport(pages *artifactPages) *ArtifactExport {
+	export := &ArtifactExport{
+		Format:      EXPORT_FORMAT_GOOD,
+		ContentType: "application/json",
+		FileName:    "artifacts.json",
+	}
+	export.write = func(w io.Writer) error {
+		// 件数が多くてもドキュメント全体をメモリに組み立てないよう、聖遺物ごとに書き出す
+		header := fmt.Sprintf(`{"format":%q,"version":%d,"source":%q,"artifacts":[`, GOODFormat, goodExportVersion, GOODExportSource)
+		if _, err := io.WriteString(w, header); err != nil {
+			return err
+		}
+		written := 0
+		err := pages.each(func(artifact *entity.Artifact) error {
+			goodArtifact, ok := newGOODArtifact(artifact)
+			if !ok {
+				export.Skipped++
+				return nil
+			}
+			if written > 0 {
+				if _, err := io.WriteString(w, ","); err != nil {
+					return err
+				}
+			}
+			written++
+			data, err := json.Marshal(goodArtifact)
+			if err != nil {
+				return err
+			}
+			_, err = w.Write(data)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, "]}\n")
+		return err
+	}
+	return export
+}
+
+func newJSONLExport(pages *artifactPages) *ArtifactExport {
+	return &ArtifactExport{
+		Format:      EXPORT_FORMAT_JSONL,
+		ContentType: "application/jsonl",
+		FileName:    "artifacts.jsonl",
+		write: func(w io.Writer) error {
+			encoder := json.NewEncoder(w)
+			return pages.each(func(artifact *entity.Artifact) error {
+				return encoder.Encode(newArtifactDTO(artifact))
+			})
+		},
+	}
+}
+
+// newCSVExport はサブステータスごとに列を設け、持っていないサブステータスは空欄にする。
+func newCSVExport(pages *artifactPages) *ArtifactExport {
+	return &ArtifactExport{
+		Format:      EXPORT_FORMAT_CSV,
+		ContentType: "text/csv; charset=utf-8",
+		FileName:    "artifacts.csv",
+		write: func(w io.Writer) error {
+			csvWriter := csv.NewWriter(w)
+			header := []string{"id", "set", "type", "rarity", "level", "main_stat", "main_stat_value"}
+			for _, substatType := range csvSubstatColumns {
+				header = append(header, string(substatType))
+			}
+			header = append(header, "crit_value", "roll_value", "locked", "location")
+			if err := csvWriter.Write(header); err != nil {
+				return err
+			}
+
+			err := pages.each(func(artifact *entity.Artifact) error {
+				artifactDTO := newArtifactDTO(artifact)
+				record := []string{
+					artifactDTO.ID,
+					artifactDTO.Set,
+					artifactDTO.Type,
+					strconv.Itoa(artifactDTO.Rarity),
+					strconv.Itoa(artifactDTO.Level),
+					artifactDTO.PrimaryStat.Type,
+					formatCSVFloat(artifactDTO.PrimaryStat.Value),
+				}
+				for _, substatType := range csvSubstatColumns {
+					value := ""
+					for _, substat := range artifact.Substats {
+						if substat.Type == substatType {
+							value = formatCSVFloat(substat.Value)
+						}
+					}
+					record = append(record, value)
+				}
+				record = append(record,
+					formatCSVFloat(artifactDTO.CritValue),
+					formatCSVFloat(artifactDTO.RollValue),
+					strconv.FormatBool(artifactDTO.Locked),
+					artifactDTO.Location,
+				)
+				return csvWriter.Write(record)
+			})
+			if err != nil {
+				return err
+			}
+			csvWriter.Flush()
+			return csvWriter.Error()
+		},
+	}
+}
+
+func formatCSVFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,228 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/repository"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExportServiceExportArtifacts(t *testing.T) {
+	testArtifact := &entity.Artifact{
+		ID:          "test-id",
+		ArtifactSet: entity.ARTIFACT_SET_GLADIATORS_FINALOFFERING,
+		Type:        entity.ARTIFACT_TYPE_FLOWER,
+		Rarity:      5,
+		Level:       0,
+		PrimaryStat: entity.PrimaryStat{Type: entity.HP_FLAT, Value: 717},
+		Substats: []entity.Substat{
+			{Type: entity.SUBSTAT_CRIT_RATE, Value: 3.9},
+			{Type: entity.SUBSTAT_CRIT_DMG, Value: 7.8},
+		},
+		Locked:     true,
+		EquippedBy: "Diluc",
+	}
+	legacyArtifact := &entity.Artifact{
+		ID:          "legacy-id",
+		ArtifactSet: entity.ARTIFACT_SET_GLADIATORS_FINALOFFERING,
+		Type:        entity.ARTIFACT_TYPE_GOBLET,
+		Rarity:      5,
+		Level:       0,
+		PrimaryStat: entity.PrimaryStat{Type: entity.UNKNOWN_ELEMENT_DMG_BONUS, Value: 7},
+		Substats:    []entity.Substat{},
+	}
+
+	tests := []struct {
+		name string
+
+		// GIVEN
+		mockArtifacts  []*entity.Artifact
+		mockQueryError error
+
+		// WHEN
+		format       string
+		queryCommand QueryArtifactsCommand
+
+		// THEN
+		expectedOutput    string
+		expectedSkipped   int
+		expectedErrorCode string
+	}{
+		{
+			name: "ShouldExportGOODAndSkipArtifactsWithoutGOODKeys",
+
+			mockArtifacts: []*entity.Artifact{testArtifact, legacyArtifact},
+
+			format: "good",
+
+			expectedOutput: `{"format":"GOOD","version":2,"source":"genshin-artifact-db","artifacts":[` +
+				`{"setKey":"GladiatorsFinale","slotKey":"flower","level":0,"rarity":5,"mainStatKey":"hp","location":"Diluc","lock":true,` +
+				`"substats":[{"key":"critRate_","value":3.9},{"key":"critDMG_","value":7.8}]}]}` + "\n",
+			expectedSkipped: 1,
+		},
+		{
+			name: "ShouldExportCSVWithOneColumnPerSubstat",
+
+			mockArtifacts: []*entity.Artifact{testArtifact},
+
+			format: "csv",
+
+			expectedOutput: "id,set,type,rarity,level,main_stat,main_stat_value,HP_FLAT,ATK_FLAT,DEF_FLAT,HP_PERCENT,ATK_PERCENT,DEF_PERCENT,ELEMENTAL_MASTERY,ENERGY_RECHARGE,CRIT_RATE,CRIT_DMG,crit_value,roll_value,locked,location\n" +
+				"test-id,GladiatorsFinale,FLOWER,5,0,HP_FLAT,717,,,,,,,,,3.9,7.8,15.6,200.64316979484073,true,Diluc\n",
+		},
+		{
+			name: "ShouldExportJSONLines",
+
+			mockArtifacts: []*entity.Artifact{testArtifact, legacyArtifact},
+
+			format: "jsonl",
+
+			expectedOutput: `{"id":"test-id","set":"GladiatorsFinale","type":"FLOWER","rarity":5,"level":0,"primary_stat":{"type":"HP_FLAT","value":717},"sub_stat":[{"type":"CRIT_RATE","value":3.9},{"type":"CRIT_DMG","value":7.8}],"crit_value":15.6,"roll_value":200.64316979484073,"locked":true,"location":"Diluc"}` + "\n" +
+				`{"id":"legacy-id","set":"GladiatorsFinale","type":"GOBLET","rarity":5,"level":0,"primary_stat":{"type":"UNKNOWN_ELEMENT_DMG_BONUS","value":7},"sub_stat":[],"crit_value":0,"roll_value":0,"locked":false}` + "\n",
+		},
+		{
+			name: "ShouldReturnErrorWhenFormatIsUnknown",
+
+			format: "xml",
+
+			expectedErrorCode: "invalid_export_format",
+		},
+		{
+			name: "ShouldReturnErrorWhenQueryIsInvalid",
+
+			format:       "csv",
+			queryCommand: QueryArtifactsCommand{Types: []string{"HAT"}},
+
+			expectedErrorCode: "invalid_artifact_query",
+		},
+		{
+			name: "ShouldReturnErrorWhenQueryFails",
+
+			mockQueryError: errors.New("disk full"),
+
+			format: "jsonl",
+
+			expectedErrorCode: ErrorCodeInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockArtifactGetter := &repository.MockArtifactGetter{
+				QueryArtifactsResponse: &repository.ArtifactQueryResult{Artifacts: tt.mockArtifacts, Total: len(tt.mockArtifacts)},
+				QueryArtifactsError:    tt.mockQueryError,
+			}
+			exportService := NewExportService(mockArtifactGetter)
+
+			export, err := exportService.ExportArtifacts(tt.format, tt.queryCommand)
+			if tt.expectedErrorCode != "" {
+				var serviceError *Error
+				if !errors.As(err, &serviceError) || serviceError.Code != tt.expectedErrorCode {
+					t.Fatalf("expected error code %s, got %v", tt.expectedErrorCode, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var output bytes.Buffer
+			if err := export.Stream(&output); err != nil {
+				t.Fatalf("unexpected stream error: %v", err)
+			}
+			if diff := cmp.Diff(tt.expectedOutput, output.String()); diff != "" {
+				t.Errorf("output mismatch (-want +got):\n%s", diff)
+			}
+			if export.Skipped != tt.expectedSkipped {
+				t.Errorf("expected skipped: %d, got: %d", tt.expectedSkipped, export.Skipped)
+			}
+			if mockArtifactGetter.QueriedArtifactQuery.Limit != ExportPageSize {
+				t.Errorf("expected export to query %d artifacts per page, got: %d", ExportPageSize, mockArtifactGetter.QueriedArtifactQuery.Limit)
+			}
+		})
+	}
+}
+
+func TestExportServiceExportArtifactsAcrossPages(t *testing.T) {
+	// GIVEN
+	artifactRepository := repository.NewInMemoryArtifactRepository()
+	artifactCount := ExportPageSize*2 + 1
+	for i := range artifactCount {
+		artifact := &entity.Artifact{
+			ID:          fmt.Sprintf("artifact-%04d", i),
+			ArtifactSet: entity.ARTIFACT_SET_GLADIATORS_FINALOFFERING,
+			Type:        entity.ARTIFACT_TYPE_FLOWER,
+			Rarity:      5,
+			PrimaryStat: entity.PrimaryStat{Type: entity.HP_FLAT, Value: 717},
+			Substats:    []entity.Substat{},
+		}
+		if err := artifactRepository.SaveArtifact(artifact); err != nil {
+			t.Fatalf("failed to save artifact: %v", err)
+		}
+	}
+
+	// WHEN
+	export, err := NewExportService(artifactRepository).ExportArtifacts("jsonl", QueryArtifactsCommand{})
+	if err != nil {
+		t.Fatalf("failed to export: %v", err)
+	}
+	var output bytes.Buffer
+	if err := export.Stream(&output); err != nil {
+		t.Fatalf("failed to stream: %v", err)
+	}
+
+	// THEN
+	var ids []string
+	decoder := json.NewDecoder(&output)
+	for decoder.More() {
+		var artifactDTO ArtifactDTO
+		if err := decoder.Decode(&artifactDTO); err != nil {
+			t.Fatalf("failed to decode line: %v", err)
+		}
+		ids = append(ids, artifactDTO.ID)
+	}
+	if len(ids) != artifactCount {
+		t.Fatalf("expected %d artifacts, got %d", artifactCount, len(ids))
+	}
+	for i, id := range ids {
+		if expected := fmt.Sprintf("artifact-%04d", i); id != expected {
+			t.Fatalf("expected artifact %s at line %d, got %s", expected, i, id)
+		}
+	}
+}
+
+func TestExportServiceGOODRoundTrip(t *testing.T) {
+	// GIVEN
+	artifactRepository := repository.NewInMemoryArtifactRepository()
+	importService := NewImportService(artifactRepository, artifactRepository)
+	document := `{"format":"GOOD","version":2,"source":"scanner","artifacts":[` +
+		`{"setKey":"GladiatorsFinale","slotKey":"sands","level":20,"rarity":5,"mainStatKey":"atk_","location":"Diluc","lock":true,` +
+		`"substats":[{"key":"critRate_","value":10.5},{"key":"critDMG_","value":21},{"key":"hp","value":538},{"key":"eleMas","value":23}]}]}`
+	if _, err := importService.ImportGOOD([]byte(document), ""); err != nil {
+		t.Fatalf("failed to import: %v", err)
+	}
+
+	// WHEN
+	export, err := NewExportService(artifactRepository).ExportArtifacts("good", QueryArtifactsCommand{})
+	if err != nil {
+		t.Fatalf("failed to export: %v", err)
+	}
+	var output bytes.Buffer
+	if err := export.Stream(&output); err != nil {
+		t.Fatalf("failed to stream: %v", err)
+	}
+
+	// THEN
+	expected := `{"format":"GOOD","version":2,"source":"genshin-artifact-db","artifacts":[` +
+		`{"setKey":"GladiatorsFinale","slotKey":"sands","level":20,"rarity":5,"mainStatKey":"atk_","location":"Diluc","lock":true,` +
+		`"substats":[{"key":"critRate_","value":10.5},{"key":"critDMG_","value":21},{"key":"hp","value":538},{"key":"eleMas","value":23}]}]}` + "\n"
+	if diff := cmp.Diff(expected, output.String()); diff != "" {
+		t.Errorf("output mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -1,6 +1,9 @@
 package service
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
 	"github.com/YutoOkawa/genshin-artifact-db/pkg/repository"
 )
@@ -8,165 +11,339 @@ import (
 type StatusDTO struct {
 	Type  string  `json:"type"`
 	Value float64 `json:"value"`
+	// Rolls はサブステータスのロール回数。分解できない場合やメインステータスでは省略する
+	Rolls int `json:"rolls,omitempty"`
 }
 
 type ArtifactDTO struct {
+	ID          string      `json:"id"`
 	Set         string      `json:"set"`
 	Type        string      `json:"type"`
+	Rarity      int         `json:"rarity"`
 	Level       int         `json:"level"`
 	PrimaryStat StatusDTO   `json:"primary_stat"`
 	SubStat     []StatusDTO `json:"sub_stat"`
+	CritValue   float64     `json:"crit_value"`
+	RollValue   float64     `json:"roll_value"`
+	Locked      bool        `json:"locked"`
+	Location    string      `json:"location,omitempty"`
+	// DuplicateOf は作成時に内容が同じ聖遺物がすでにあった場合のみ、その ID を返す
+	DuplicateOf []string `json:"duplicate_of,omitempty"`
+	// Score は一覧の取得でプロファイルを指定した場合のみ、そのプロファイルによる点数を返す
+	Score *float64 `json:"score,omitempty"`
+}
+
+// parseArtifactType はパスで指定された部位を検証し、不正な場合は項目名 "type" の検証エラーを返す。
+func parseArtifactType(artifactType string) (entity.ArtifactType, error) {
+	artifactTypeEnum, err := entity.ParseArtifactType(artifactType)
+	if err != nil {
+		return "", NewValidationError("invalid_artifact_type", "type", fmt.Errorf("%w: %s", err, artifactType))
+	}
+	return artifactTypeEnum, nil
+}
+
+// parseArtifactSet はパスで指定されたセットを検証し、不正な場合は項目名 "set" の検証エラーを返す。
+func parseArtifactSet(artifactSet string) (entity.ArtifactSet, error) {
+	artifactSetKey, err := entity.ParseArtifactSet(artifactSet)
+	if err != nil {
+		return "", NewValidationError("invalid_artifact_set", "set", fmt.Errorf("%w: %s", err, artifactSet))
+	}
+	return artifactSetKey, nil
 }
 
 type GetArtifactServiceInterface interface {
 	GetArtifact(id string) (*ArtifactDTO, error)
 }
 
+// 一覧を返すメソッドは profileName が空でなければ、各聖遺物にそのプロファイルによる点数を付ける。
 type GetArtifactsServiceInterface interface {
-	GetArtifactsByTypeAndSet(artifactType, artifactSet string) ([]*ArtifactDTO, error)
+	GetArtifactsByTypeAndSet(artifactType, artifactSet, profileName string) ([]*ArtifactDTO, error)
 }
 
 type GetArtifactsByTypeServiceInterface interface {
-	GetArtifactsByType(artifactType string) ([]*ArtifactDTO, error)
+	GetArtifactsByType(artifactType, profileName string) ([]*ArtifactDTO, error)
 }
 
 type GetArtifactsBySetServiceInterface interface {
-	GetArtifactsBySet(artifactSet string) ([]*ArtifactDTO, error)
+	GetArtifactsBySet(artifactSet, profileName string) ([]*ArtifactDTO, error)
 }
 
 type GetArtifactService struct {
-	arrifactGetter repository.ArtifactGetter
+	arrifactGetter     repository.ArtifactGetter
+	scoreProfileGetter repository.ScoreProfileGetter
 }
 
-func NewGetArtifactService(arrifactGetter repository.ArtifactGetter) *GetArtifactService {
+func NewGetArtifactService(arrifactGetter repository.ArtifactGetter, scoreProfileGetter repository.ScoreProfileGetter) *GetArtifactService {
 	return &GetArtifactService{
-		arrifactGetter: arrifactGetter,
+		arrifactGetter:     arrifactGetter,
+		scoreProfileGetter: scoreProfileGetter,
 	}
 }
 
 func (s *GetArtifactService) GetArtifact(id string) (*ArtifactDTO, error) {
 	artifact, err := s.arrifactGetter.GetArtifactByID(id)
+	if err != nil {
+		return nil, ClassifyError(err)
+	}
+
+	return newArtifactDTO(artifact), nil
+}
+
+// GetArtifactsByTypeAndSet は該当がなければ空のスライスを返す。
+// 部位やセットが存在しない値の場合は検証エラーを返す。
+func (s *GetArtifactService) GetArtifactsByTypeAndSet(artifactType, artifactSet, profileName string) ([]*ArtifactDTO, error) {
+	artifactTypeEnum, err := parseArtifactType(artifactType)
+	if err != nil {
+		return nil, err
+	}
+	artifactSetKey, err := parseArtifactSet(artifactSet)
+	if err != nil {
+		return nil, err
+	}
+
+	artifacts, err := s.arrifactGetter.GetArtifactByTypeAndSet(artifactTypeEnum, artifactSetKey)
+	if err != nil {
+		return nil, ClassifyError(err)
+	}
+
+	return s.newScoredArtifactDTOs(artifacts, profileName)
+}
+
+func (s *GetArtifactService) GetArtifactsByType(artifactType, profileName string) ([]*ArtifactDTO, error) {
+	artifactTypeEnum, err := parseArtifactType(artifactType)
 	if err != nil {
 		return nil, err
 	}
 
+	artifacts, err := s.arrifactGetter.GetArtifactByType(artifactTypeEnum)
+	if err != nil {
+		return nil, ClassifyError(err)
+	}
+
+	return s.newScoredArtifactDTOs(artifacts, profileName)
+}
+
+func (s *GetArtifactService) GetArtifactsBySet(artifactSet, profileName string) ([]*ArtifactDTO, error) {
+	artifactSetKey, err := parseArtifactSet(artifactSet)
+	if err != nil {
+		return nil, err
+	}
+
+	artifacts, err := s.arrifactGetter.GetArtifactBySet(artifactSetKey)
+	if err != nil {
+		return nil, ClassifyError(err)
+	}
+
+	return s.newScoredArtifactDTOs(artifacts, profileName)
+}
+
+// newScoredArtifactDTOs は profileName が空でなければ、各聖遺物にプロファイルによる点数を付ける。
+// プロファイルが存在しない場合は repository.ErrScoreProfileNotFound を返す。
+func (s *GetArtifactService) newScoredArtifactDTOs(artifacts []*entity.Artifact, profileName string) ([]*ArtifactDTO, error) {
+	artifactDTOs := newArtifactDTOs(artifacts)
+	if profileName == "" {
+		return artifactDTOs, nil
+	}
+
+	profile, err := s.scoreProfileGetter.GetScoreProfile(profileName)
+	if err != nil {
+		return nil, ClassifyError(err)
+	}
+	for i, artifact := range artifacts {
+		// 旧データなど採点できない聖遺物も一覧から除かないよう、失敗した場合は点数を省略する
+		score, err := profile.Score(artifact)
+		if err != nil {
+			continue
+		}
+		artifactDTOs[i].Score = &score
+	}
+	return artifactDTOs, nil
+}
+
+func newArtifactDTO(artifact *entity.Artifact) *ArtifactDTO {
 	artifactDTO := &ArtifactDTO{
-		Set:   string(artifact.ArtifactSet),
-		Type:  string(artifact.Type),
-		Level: artifact.Level,
+		ID:     artifact.ID,
+		Set:    string(artifact.ArtifactSet),
+		Type:   string(artifact.Type),
+		Rarity: artifact.Rarity,
+		Level:  artifact.Level,
 		PrimaryStat: StatusDTO{
 			Type:  string(artifact.PrimaryStat.Type),
 			Value: artifact.PrimaryStat.Value,
 		},
+		Locked:   artifact.Locked,
+		Location: string(artifact.EquippedBy),
 	}
 
+	// 旧データなど採点できない聖遺物もそのまま返せるよう、失敗した場合は 0 とする
+	artifactDTO.CritValue = entity.CritValue(artifact)
+	artifactDTO.RollValue, _ = entity.RollValue(artifact)
+
+	// 旧データなど分解できない聖遺物もそのまま返せるよう、失敗した場合はロール回数を省略する
+	substatRolls, _ := entity.DecomposeSubstats(artifact.Rarity, artifact.Level, artifact.Substats)
+
 	artifactDTO.SubStat = make([]StatusDTO, 0, len(artifact.Substats))
-	for _, subStat := range artifact.Substats {
+	for i, subStat := range artifact.Substats {
 		subStatDTO := StatusDTO{
 			Type:  string(subStat.Type),
 			Value: subStat.Value,
 		}
+		if substatRolls != nil {
+			subStatDTO.Rolls = substatRolls[i].Count()
+		}
 		artifactDTO.SubStat = append(artifactDTO.SubStat, subStatDTO)
 	}
 
-	return artifactDTO, nil
+	return artifactDTO
 }
 
-func (s *GetArtifactService) GetArtifactsByTypeAndSet(artifactType, artifactSet string) ([]*ArtifactDTO, error) {
-	artifacts, err := s.arrifactGetter.GetArtifactByTypeAndSet(entity.ArtifactType(artifactType), entity.ArtifactSet(artifactSet))
-	if err != nil {
-		return nil, err
-	}
-
+func newArtifactDTOs(artifacts []*entity.Artifact) []*ArtifactDTO {
 	artifactDTOs := make([]*ArtifactDTO, 0, len(artifacts))
 	for _, artifact := range artifacts {
-		artifactDTO := &ArtifactDTO{
-			Set:   string(artifact.ArtifactSet),
-			Type:  string(artifact.Type),
-			Level: artifact.Level,
-			PrimaryStat: StatusDTO{
-				Type:  string(artifact.PrimaryStat.Type),
-				Value: artifact.PrimaryStat.Value,
-			},
-		}
+		artifactDTOs = append(artifactDTOs, newArtifactDTO(artifact))
+	}
+	return artifactDTOs
+}
 
-		artifactDTO.SubStat = make([]StatusDTO, 0, len(artifact.Substats))
-		for _, subStat := range artifact.Substats {
-			subStatDTO := StatusDTO{
-				Type:  string(subStat.Type),
-				Value: subStat.Value,
-			}
-			artifactDTO.SubStat = append(artifactDTO.SubStat, subStatDTO)
-		}
+const (
+	DefaultArtifactQueryLimit = 100
+	MaxArtifactQueryLimit     = 1000
+)
 
-		artifactDTOs = append(artifactDTOs, artifactDTO)
-	}
+// EquippedByNone は QueryArtifactsCommand の EquippedBy で、どのキャラクターも装備していない聖遺物を表す。
+const EquippedByNone = "none"
 
-	return artifactDTOs, nil
+// QueryArtifactsCommand はリクエストの文字列をそのまま保持し、値の検証は QueryArtifacts で行う。
+// EquippedBy はキャラクターのキーか EquippedByNone で指定する。
+// Sort は "crit_value" のようなキーで、先頭に "-" を付けると降順になる。
+// Limit が 0 の場合は DefaultArtifactQueryLimit 件まで返す。
+// Profile が空でなければ、各聖遺物にそのプロファイルによる点数を付ける。書き出しでは使わない。
+type QueryArtifactsCommand struct {
+	Types            []string
+	Sets             []string
+	PrimaryStats     []string
+	Rarities         []int
+	MinLevel         *int
+	MaxLevel         *int
+	RequiredSubstats []string
+	MinSubstatValues map[string]float64
+	EquippedBy       []string
+	Sort             string
+	Limit            int
+	Offset           int
+	Cursor           string
+	Profile          string
 }
 
-func (s *GetArtifactService) GetArtifactsByType(artifactType string) ([]*ArtifactDTO, error) {
-	artifacts, err := s.arrifactGetter.GetArtifactByType(entity.ArtifactType(artifactType))
+type ArtifactListDTO struct {
+	Artifacts  []*ArtifactDTO `json:"artifacts"`
+	Total      int            `json:"total"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
+
+type QueryArtifactsServiceInterface interface {
+	QueryArtifacts(queryCommand QueryArtifactsCommand) (*ArtifactListDTO, error)
+}
+
+func (s *GetArtifactService) QueryArtifacts(queryCommand QueryArtifactsCommand) (*ArtifactListDTO, error) {
+	query, err := newArtifactQuery(queryCommand)
 	if err != nil {
 		return nil, err
 	}
 
-	artifactDTOs := make([]*ArtifactDTO, 0, len(artifacts))
-	for _, artifact := range artifacts {
-		artifactDTO := &ArtifactDTO{
-			Set:   string(artifact.ArtifactSet),
-			Type:  string(artifact.Type),
-			Level: artifact.Level,
-			PrimaryStat: StatusDTO{
-				Type:  string(artifact.PrimaryStat.Type),
-				Value: artifact.PrimaryStat.Value,
-			},
-		}
-
-		artifactDTO.SubStat = make([]StatusDTO, 0, len(artifact.Substats))
-		for _, subStat := range artifact.Substats {
-			subStatDTO := StatusDTO{
-				Type:  string(subStat.Type),
-				Value: subStat.Value,
-			}
-			artifactDTO.SubStat = append(artifactDTO.SubStat, subStatDTO)
-		}
-
-		artifactDTOs = append(artifactDTOs, artifactDTO)
+	result, err := s.arrifactGetter.QueryArtifacts(*query)
+	if err != nil {
+		return nil, ClassifyError(err)
+	}
+	artifactDTOs, err := s.newScoredArtifactDTOs(result.Artifacts, queryCommand.Profile)
+	if err != nil {
+		return nil, err
 	}
 
-	return artifactDTOs, nil
+	return &ArtifactListDTO{
+		Artifacts:  artifactDTOs,
+		Total:      result.Total,
+		NextCursor: result.NextCursor,
+	}, nil
 }
 
-func (s *GetArtifactService) GetArtifactsBySet(artifactSet string) ([]*ArtifactDTO, error) {
-	artifacts, err := s.arrifactGetter.GetArtifactBySet(entity.ArtifactSet(artifactSet))
-	if err != nil {
-		return nil, err
+func newArtifactQuery(queryCommand QueryArtifactsCommand) (*repository.ArtifactQuery, error) {
+	query := &repository.ArtifactQuery{
+		Rarities: queryCommand.Rarities,
+		MinLevel: queryCommand.MinLevel,
+		MaxLevel: queryCommand.MaxLevel,
+		Limit:    queryCommand.Limit,
+		Offset:   queryCommand.Offset,
+		Cursor:   queryCommand.Cursor,
 	}
 
-	artifactDTOs := make([]*ArtifactDTO, 0, len(artifacts))
-	for _, artifact := range artifacts {
-		artifactDTO := &ArtifactDTO{
-			Set:   string(artifact.ArtifactSet),
-			Type:  string(artifact.Type),
-			Level: artifact.Level,
-			PrimaryStat: StatusDTO{
-				Type:  string(artifact.PrimaryStat.Type),
-				Value: artifact.PrimaryStat.Value,
-			},
-		}
+	switch {
+	case query.Limit == 0:
+		query.Limit = DefaultArtifactQueryLimit
+	case query.Limit > MaxArtifactQueryLimit:
+		return nil, invalidQueryError("limit", fmt.Errorf("limit must be at most %d", MaxArtifactQueryLimit))
+	}
 
-		artifactDTO.SubStat = make([]StatusDTO, 0, len(artifact.Substats))
-		for _, subStat := range artifact.Substats {
-			subStatDTO := StatusDTO{
-				Type:  string(subStat.Type),
-				Value: subStat.Value,
+	for _, artifactType := range queryCommand.Types {
+		artifactTypeEnum, err := entity.ParseArtifactType(artifactType)
+		if err != nil {
+			return nil, invalidQueryError("type", fmt.Errorf("%w: %s", err, artifactType))
+		}
+		query.Types = append(query.Types, artifactTypeEnum)
+	}
+	for _, artifactSet := range queryCommand.Sets {
+		artifactSetKey, err := entity.ParseArtifactSet(artifactSet)
+		if err != nil {
+			return nil, invalidQueryError("set", fmt.Errorf("%w: %s", err, artifactSet))
+		}
+		query.Sets = append(query.Sets, artifactSetKey)
+	}
+	for _, primaryStat := range queryCommand.PrimaryStats {
+		primaryStatEnum, err := entity.NewPrimaryStat(primaryStat, 0)
+		if err != nil {
+			return nil, invalidQueryError("main_stat", fmt.Errorf("%w: %s", err, primaryStat))
+		}
+		query.PrimaryStats = append(query.PrimaryStats, primaryStatEnum.Type)
+	}
+	for _, substat := range queryCommand.RequiredSubstats {
+		substatEnum, err := entity.NewSubstat(substat, 0)
+		if err != nil {
+			return nil, invalidQueryError("substat", fmt.Errorf("%w: %s", err, substat))
+		}
+		query.RequiredSubstats = append(query.RequiredSubstats, substatEnum.Type)
+	}
+	if len(queryCommand.MinSubstatValues) > 0 {
+		query.MinSubstatValues = make(map[entity.SubstatType]float64, len(queryCommand.MinSubstatValues))
+		for substat, minValue := range queryCommand.MinSubstatValues {
+			substatEnum, err := entity.NewSubstat(substat, minValue)
+			if err != nil {
+				return nil, invalidQueryError("min_substat", fmt.Errorf("%w: %s", err, substat))
 			}
-			artifactDTO.SubStat = append(artifactDTO.SubStat, subStatDTO)
+			query.MinSubstatValues[substatEnum.Type] = substatEnum.Value
 		}
+	}
 
-		artifactDTOs = append(artifactDTOs, artifactDTO)
+	for _, character := range queryCommand.EquippedBy {
+		if character == EquippedByNone {
+			query.EquippedBy = append(query.EquippedBy, "")
+			continue
+		}
+		characterKey, err := entity.ParseCharacterKey(character)
+		if err != nil {
+			return nil, invalidQueryError("equipped_by", err)
+		}
+		query.EquippedBy = append(query.EquippedBy, characterKey)
 	}
 
-	return artifactDTOs, nil
+	sortKey, descending := strings.CutPrefix(queryCommand.Sort, "-")
+	query.SortKey = repository.ArtifactSortKey(sortKey)
+	query.SortDescending = descending
+
+	return query, nil
+}
+
+// invalidQueryError はクエリパラメータ field の検証エラーを返す。
+// errors.Is で repository.ErrInvalidArtifactQuery と判定できるようにする。
+func invalidQueryError(field string, err error) *Error {
+	return NewValidationError("invalid_artifact_query", field, fmt.Errorf("%w: %w", repository.ErrInvalidArtifactQuery, err))
 }
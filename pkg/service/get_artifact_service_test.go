@@ -1,13 +1,16 @@
 package service
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
 	"github.com/YutoOkawa/genshin-artifact-db/pkg/repository"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 )
 
 func TestGetArtifactServiceGetArtifactByID(t *testing.T) {
@@ -29,6 +32,7 @@ func TestGetArtifactServiceGetArtifactByID(t *testing.T) {
 	}
 
 	testArtifactDTO := ArtifactDTO{
+		ID:    "test-id",
 		Set:   "test-set",
 		Type:  "test-type",
 		Level: 0,
@@ -141,6 +145,7 @@ func TestGetArtifactServicegetArtifactByTypeAndSet(t *testing.T) {
 	}
 
 	testArtifactDTO := &ArtifactDTO{
+		ID:    "test-id",
 		Set:   "test-set",
 		Type:  "test-type",
 		Level: 0,
@@ -157,6 +162,7 @@ func TestGetArtifactServicegetArtifactByTypeAndSet(t *testing.T) {
 	}
 
 	testArtifactDTO2 := &ArtifactDTO{
+		ID:    "test-id-2",
 		Set:   "test-set",
 		Type:  "test-type",
 		Level: 0,
@@ -216,7 +222,7 @@ func TestGetArtifactServicegetArtifactByTypeAndSet(t *testing.T) {
 			service := GetArtifactService{
 				arrifactGetter: repo,
 			}
-			result, err := service.GetArtifactsByTypeAndSet("test-type", "test-set")
+			result, err := service.GetArtifactsByTypeAndSet("FLOWER", "GladiatorsFinale", "")
 
 			if diff := cmp.Diff(tt.expectedArtifacts, result); diff != "" {
 				t.Errorf("GetArtifactByTypeAndSet() mismatch (-want +got):\n%s", diff)
@@ -248,6 +254,7 @@ func TestGetArtifactServiceGetArtifactByType(t *testing.T) {
 	}
 
 	testArtifactDTO := &ArtifactDTO{
+		ID:    "test-id",
 		Set:   "test-set",
 		Type:  "test-type",
 		Level: 0,
@@ -299,7 +306,7 @@ func TestGetArtifactServiceGetArtifactByType(t *testing.T) {
 			service := GetArtifactService{
 				arrifactGetter: repo,
 			}
-			result, err := service.GetArtifactsByType("test-type")
+			result, err := service.GetArtifactsByType("FLOWER", "")
 
 			if diff := cmp.Diff(tt.expectedArtifacts, result); diff != "" {
 				t.Errorf("GetArtifactByType() mismatch (-want +got):\n%s", diff)
@@ -331,6 +338,7 @@ func TestGetArtifactServiceGetArtifactBySet(t *testing.T) {
 	}
 
 	testArtifactDTO := &ArtifactDTO{
+		ID:    "test-id",
 		Set:   "test-set",
 		Type:  "test-type",
 		Level: 0,
@@ -382,7 +390,7 @@ func TestGetArtifactServiceGetArtifactBySet(t *testing.T) {
 			service := GetArtifactService{
 				arrifactGetter: repo,
 			}
-			result, err := service.GetArtifactsBySet("test-set")
+			result, err := service.GetArtifactsBySet("GladiatorsFinale", "")
 
 			if diff := cmp.Diff(tt.expectedArtifacts, result); diff != "" {
 				t.Errorf("GetArtifactByTypeAndSet() mismatch (-want +got):\n%s", diff)
@@ -394,3 +402,439 @@ func TestGetArtifactServiceGetArtifactBySet(t *testing.T) {
 		})
 	}
 }
+
+func TestNewArtifactDTORolls(t *testing.T) {
+	tests := []struct {
+		name string
+
+		// WHEN
+		artifact *entity.Artifact
+
+		// THEN
+		expectedSubStat []StatusDTO
+	}{
+		{
+			name: "ShouldIncludeRollCountsWhenSubstatsDecompose",
+
+			artifact: &entity.Artifact{
+				ID:          "test-id",
+				ArtifactSet: entity.ARTIFACT_SET_GLADIATORS_FINALOFFERING,
+				Type:        entity.ARTIFACT_TYPE_SANDS,
+				Rarity:      5,
+				Level:       20,
+				PrimaryStat: entity.PrimaryStat{Type: entity.ATK_PERCENT, Value: 46.6},
+				Substats: []entity.Substat{
+					{Type: entity.SUBSTAT_CRIT_RATE, Value: 10.5},
+					{Type: entity.SUBSTAT_CRIT_DMG, Value: 21.0},
+					{Type: entity.SUBSTAT_HP_FLAT, Value: 269},
+					{Type: entity.SUBSTAT_ENERGY_RECHARGE, Value: 11.0},
+				},
+			},
+
+			expectedSubStat: []StatusDTO{
+				{Type: "CRIT_RATE", Value: 10.5, Rolls: 3},
+				{Type: "CRIT_DMG", Value: 21.0, Rolls: 3},
+				{Type: "HP_FLAT", Value: 269, Rolls: 1},
+				{Type: "ENERGY_RECHARGE", Value: 11.0, Rolls: 2},
+			},
+		},
+		{
+			name: "ShouldOmitRollCountsWhenSubstatsCannotDecompose",
+
+			artifact: &entity.Artifact{
+				ID:          "test-id",
+				ArtifactSet: entity.ARTIFACT_SET_GLADIATORS_FINALOFFERING,
+				Type:        entity.ARTIFACT_TYPE_SANDS,
+				Rarity:      5,
+				Level:       0,
+				PrimaryStat: entity.PrimaryStat{Type: entity.ATK_PERCENT, Value: 7.0},
+				Substats: []entity.Substat{
+					{Type: entity.SUBSTAT_CRIT_RATE, Value: 0.1},
+				},
+			},
+
+			expectedSubStat: []StatusDTO{
+				{Type: "CRIT_RATE", Value: 0.1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			artifactDTO := newArtifactDTO(tt.artifact)
+
+			if diff := cmp.Diff(tt.expectedSubStat, artifactDTO.SubStat); diff != "" {
+				t.Errorf("newArtifactDTO() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetArtifactServiceQueryArtifacts(t *testing.T) {
+	testArtifact := &entity.Artifact{
+		ID:          "test-id",
+		ArtifactSet: "test-set",
+		Type:        "test-type",
+		PrimaryStat: entity.PrimaryStat{Type: "test-type"},
+	}
+	minLevel := 16
+
+	tests := []struct {
+		name string
+
+		// GIVEN
+		mockQueryArtifactsResponse *repository.ArtifactQueryResult
+		mockQueryArtifactsError    error
+
+		// WHEN
+		queryCommand QueryArtifactsCommand
+
+		// THEN
+		expectedQuery     repository.ArtifactQuery
+		expectedArtifacts *ArtifactListDTO
+		expectedError     error
+	}{
+		{
+			name: "ShouldConvertCommandToQuerySuccessfully",
+
+			mockQueryArtifactsResponse: &repository.ArtifactQueryResult{
+				Artifacts:  []*entity.Artifact{testArtifact},
+				Total:      3,
+				NextCursor: "next",
+			},
+
+			queryCommand: QueryArtifactsCommand{
+				Types:            []string{"SANDS"},
+				Sets:             []string{"Vermillion"},
+				PrimaryStats:     []string{"ATK_PERCENT"},
+				Rarities:         []int{5},
+				MinLevel:         &minLevel,
+				RequiredSubstats: []string{"CRIT_RATE"},
+				MinSubstatValues: map[string]float64{"CRIT_DMG": 20},
+				EquippedBy:       []string{"RaidenShogun", EquippedByNone},
+				Sort:             "-crit_value",
+				Limit:            1,
+			},
+
+			expectedQuery: repository.ArtifactQuery{
+				Types:            []entity.ArtifactType{entity.ARTIFACT_TYPE_SANDS},
+				Sets:             []entity.ArtifactSet{entity.ARTIFACT_SET_VIRIDESCENT_VENERER},
+				PrimaryStats:     []entity.PrimaryStatType{entity.ATK_PERCENT},
+				Rarities:         []int{5},
+				MinLevel:         &minLevel,
+				RequiredSubstats: []entity.SubstatType{entity.SUBSTAT_CRIT_RATE},
+				MinSubstatValues: map[entity.SubstatType]float64{entity.SUBSTAT_CRIT_DMG: 20},
+				EquippedBy:       []entity.CharacterKey{"RaidenShogun", ""},
+				SortKey:          repository.SORT_BY_CRIT_VALUE,
+				SortDescending:   true,
+				Limit:            1,
+			},
+			expectedArtifacts: &ArtifactListDTO{
+				Artifacts:  []*ArtifactDTO{newArtifactDTO(testArtifact)},
+				Total:      3,
+				NextCursor: "next",
+			},
+		},
+		{
+			name: "ShouldApplyDefaultLimitAndReturnEmptyList",
+
+			mockQueryArtifactsResponse: &repository.ArtifactQueryResult{
+				Artifacts: []*entity.Artifact{},
+			},
+
+			queryCommand: QueryArtifactsCommand{},
+
+			expectedQuery: repository.ArtifactQuery{
+				Limit: DefaultArtifactQueryLimit,
+			},
+			expectedArtifacts: &ArtifactListDTO{
+				Artifacts: []*ArtifactDTO{},
+			},
+		},
+		{
+			name: "ShouldReturnErrorWhenLimitExceedsMaximum",
+
+			queryCommand: QueryArtifactsCommand{
+				Limit: MaxArtifactQueryLimit + 1,
+			},
+
+			expectedError: repository.ErrInvalidArtifactQuery,
+		},
+		{
+			name: "ShouldReturnErrorWhenTypeIsInvalid",
+
+			queryCommand: QueryArtifactsCommand{
+				Types: []string{"RING"},
+			},
+
+			expectedError: entity.ErrInvalidArtifactType,
+		},
+		{
+			name: "ShouldReturnErrorWhenMinSubstatTypeIsInvalid",
+
+			queryCommand: QueryArtifactsCommand{
+				MinSubstatValues: map[string]float64{"HEALING_BONUS": 1},
+			},
+
+			expectedError: repository.ErrInvalidArtifactQuery,
+		},
+		{
+			name: "ShouldReturnErrorWhenEquippedCharacterIsUnknown",
+
+			queryCommand: QueryArtifactsCommand{
+				EquippedBy: []string{"Raiden Shogun"},
+			},
+
+			expectedError: entity.ErrInvalidCharacter,
+		},
+		{
+			name: "ShouldReturnErrorWhenQueryArtifactsFails",
+
+			mockQueryArtifactsError: repository.ErrInvalidCursor,
+
+			queryCommand: QueryArtifactsCommand{
+				Cursor: "broken",
+			},
+
+			expectedQuery: repository.ArtifactQuery{
+				Limit:  DefaultArtifactQueryLimit,
+				Cursor: "broken",
+			},
+			expectedError: repository.ErrInvalidCursor,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &repository.MockArtifactGetter{
+				QueryArtifactsResponse: tt.mockQueryArtifactsResponse,
+				QueryArtifactsError:    tt.mockQueryArtifactsError,
+			}
+			service := GetArtifactService{
+				arrifactGetter: repo,
+			}
+			result, err := service.QueryArtifacts(tt.queryCommand)
+
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error: %v, got: %v", tt.expectedError, err)
+			}
+			if diff := cmp.Diff(tt.expectedArtifacts, result); diff != "" {
+				t.Errorf("QueryArtifacts() mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.expectedQuery, repo.QueriedArtifactQuery); diff != "" {
+				t.Errorf("query mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetArtifactServiceRejectsUnknownPathValues(t *testing.T) {
+	tests := []struct {
+		name string
+
+		// WHEN
+		call func(service *GetArtifactService) ([]*ArtifactDTO, error)
+
+		// THEN
+		expectedParam string
+		expectedValue string
+		expectedError error
+	}{
+		{
+			name: "ShouldRejectUnknownTypeInGetArtifactsByType",
+
+			call: func(service *GetArtifactService) ([]*ArtifactDTO, error) {
+				return service.GetArtifactsByType("RING", "")
+			},
+
+			expectedParam: "type",
+			expectedValue: "RING",
+			expectedError: entity.ErrInvalidArtifactType,
+		},
+		{
+			name: "ShouldRejectUnknownSetInGetArtifactsBySet",
+
+			call: func(service *GetArtifactService) ([]*ArtifactDTO, error) {
+				return service.GetArtifactsBySet("UnknownSet", "")
+			},
+
+			expectedParam: "set",
+			expectedValue: "UnknownSet",
+			expectedError: entity.ErrInvalidArtifactSet,
+		},
+		{
+			name: "ShouldRejectUnknownSetInGetArtifactsByTypeAndSet",
+
+			call: func(service *GetArtifactService) ([]*ArtifactDTO, error) {
+				return service.GetArtifactsByTypeAndSet("FLOWER", "UnknownSet", "")
+			},
+
+			expectedParam: "set",
+			expectedValue: "UnknownSet",
+			expectedError: entity.ErrInvalidArtifactSet,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &GetArtifactService{
+				arrifactGetter: &repository.MockArtifactGetter{},
+			}
+			result, err := tt.call(service)
+
+			if result != nil {
+				t.Errorf("expected nil result, got: %v", result)
+			}
+			var serviceError *Error
+			if !errors.As(err, &serviceError) || serviceError.Kind != ERROR_KIND_VALIDATION {
+				t.Fatalf("expected validation error, got: %v", err)
+			}
+			if len(serviceError.Fields) != 1 || serviceError.Fields[0].Field != tt.expectedParam ||
+				!strings.HasSuffix(serviceError.Fields[0].Message, tt.expectedValue) {
+				t.Errorf("expected %s=%s, got %+v", tt.expectedParam, tt.expectedValue, serviceError.Fields)
+			}
+			if !errors.Is(err, tt.expectedError) {
+				t.Errorf("expected error: %v, got: %v", tt.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestGetArtifactServiceScoresListsWithProfile(t *testing.T) {
+	scoredArtifact := &entity.Artifact{
+		ID:          "scored",
+		ArtifactSet: entity.ARTIFACT_SET_GLADIATORS_FINALOFFERING,
+		Type:        entity.ARTIFACT_TYPE_FLOWER,
+		Rarity:      5,
+		PrimaryStat: entity.PrimaryStat{Type: entity.HP_FLAT, Value: 717},
+		Substats: []entity.Substat{
+			{Type: entity.SUBSTAT_CRIT_RATE, Value: 3.89},
+			{Type: entity.SUBSTAT_CRIT_DMG, Value: 7.77},
+			{Type: entity.SUBSTAT_HP_PERCENT, Value: 5.83},
+		},
+	}
+	// 旧データのようにレアリティが不正で採点できない聖遺物
+	legacyArtifact := &entity.Artifact{
+		ID:          "legacy",
+		ArtifactSet: entity.ARTIFACT_SET_GLADIATORS_FINALOFFERING,
+		Type:        entity.ARTIFACT_TYPE_FLOWER,
+		Substats:    []entity.Substat{{Type: entity.SUBSTAT_CRIT_DMG, Value: 7.77}},
+	}
+	testProfile := &entity.ScoreProfile{
+		Name:    "HuTao",
+		Weights: map[entity.SubstatType]float64{entity.SUBSTAT_CRIT_DMG: 1, entity.SUBSTAT_HP_PERCENT: 0.5},
+	}
+	profileScore := 1.5
+
+	tests := []struct {
+		name string
+
+		// GIVEN
+		mockGetScoreProfileError error
+
+		// WHEN
+		call func(service *GetArtifactService) ([]*ArtifactDTO, error)
+
+		// THEN
+		expectedScores map[string]*float64
+		expectedError  error
+	}{
+		{
+			name: "ShouldNotScoreWhenProfileIsNotSpecified",
+
+			call: func(service *GetArtifactService) ([]*ArtifactDTO, error) {
+				return service.GetArtifactsByType("FLOWER", "")
+			},
+
+			expectedScores: map[string]*float64{"scored": nil, "legacy": nil},
+		},
+		{
+			name: "ShouldScoreArtifactsByType",
+
+			call: func(service *GetArtifactService) ([]*ArtifactDTO, error) {
+				return service.GetArtifactsByType("FLOWER", "HuTao")
+			},
+
+			expectedScores: map[string]*float64{"scored": &profileScore, "legacy": nil},
+		},
+		{
+			name: "ShouldScoreArtifactsBySet",
+
+			call: func(service *GetArtifactService) ([]*ArtifactDTO, error) {
+				return service.GetArtifactsBySet("GladiatorsFinale", "HuTao")
+			},
+
+			expectedScores: map[string]*float64{"scored": &profileScore, "legacy": nil},
+		},
+		{
+			name: "ShouldScoreArtifactsByTypeAndSet",
+
+			call: func(service *GetArtifactService) ([]*ArtifactDTO, error) {
+				return service.GetArtifactsByTypeAndSet("FLOWER", "GladiatorsFinale", "HuTao")
+			},
+
+			expectedScores: map[string]*float64{"scored": &profileScore, "legacy": nil},
+		},
+		{
+			name: "ShouldScoreQueriedArtifacts",
+
+			call: func(service *GetArtifactService) ([]*ArtifactDTO, error) {
+				result, err := service.QueryArtifacts(QueryArtifactsCommand{Profile: "HuTao"})
+				if err != nil {
+					return nil, err
+				}
+				return result.Artifacts, nil
+			},
+
+			expectedScores: map[string]*float64{"scored": &profileScore, "legacy": nil},
+		},
+		{
+			name: "ShouldReturnErrorWhenProfileNotFound",
+
+			mockGetScoreProfileError: repository.ErrScoreProfileNotFound,
+
+			call: func(service *GetArtifactService) ([]*ArtifactDTO, error) {
+				return service.GetArtifactsByType("FLOWER", "Unknown")
+			},
+
+			expectedError: repository.ErrScoreProfileNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			artifacts := []*entity.Artifact{scoredArtifact, legacyArtifact}
+			service := NewGetArtifactService(
+				&repository.MockArtifactGetter{
+					GetArtifactByTypeResponse:       artifacts,
+					GetArtifactBySetResponse:        artifacts,
+					GetArtifactByTypeAndSetResponse: artifacts,
+					QueryArtifactsResponse:          &repository.ArtifactQueryResult{Artifacts: artifacts, Total: len(artifacts)},
+				},
+				&repository.MockScoreProfileGetter{
+					GetScoreProfileResponse: testProfile,
+					GetScoreProfileError:    tt.mockGetScoreProfileError,
+				},
+			)
+
+			// WHEN
+			result, err := tt.call(service)
+
+			// THEN
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error: %v, got: %v", tt.expectedError, err)
+			}
+			var scores map[string]*float64
+			if result != nil {
+				scores = make(map[string]*float64, len(result))
+				for _, artifactDTO := range result {
+					scores[artifactDTO.ID] = artifactDTO.Score
+				}
+			}
+			if diff := cmp.Diff(tt.expectedScores, scores, cmpopts.EquateApprox(0, 1e-9)); diff != "" {
+				t.Errorf("scores mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
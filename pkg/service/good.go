@@ -0,0 +1,166 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
+)
+
+// GOODFormat は GOOD (Genshin Open Object Description) の format フィールドの値。
+const GOODFormat = "GOOD"
+
+var ErrInvalidGOODDocument = errors.New("invalid GOOD document")
+
+// GOODDocument は GOOD 形式のインベントリのうち、聖遺物に関する部分を表す。
+// https://frzyc.github.io/genshin-optimizer/#/doc
+type GOODDocument struct {
+	Format    string         `json:"format"`
+	Version   int            `json:"version"`
+	Source    string         `json:"source"`
+	Artifacts []GOODArtifact `json:"artifacts"`
+}
+
+type GOODArtifact struct {
+	SetKey      string        `json:"setKey"`
+	SlotKey     string        `json:"slotKey"`
+	Level       int           `json:"level"`
+	Rarity      int           `json:"rarity"`
+	MainStatKey string        `json:"mainStatKey"`
+	Location    string        `json:"location"`
+	Lock        bool          `json:"lock"`
+	Substats    []GOODSubstat `json:"substats"`
+}
+
+type GOODSubstat struct {
+	Key   string  `json:"key"`
+	Value float64 `json:"value"`
+}
+
+var goodSlotKeys = map[string]entity.ArtifactType{
+	"flower":  entity.ARTIFACT_TYPE_FLOWER,
+	"plume":   entity.ARTIFACT_TYPE_PLUME,
+	"sands":   entity.ARTIFACT_TYPE_SANDS,
+	"goblet":  entity.ARTIFACT_TYPE_GOBLET,
+	"circlet": entity.ARTIFACT_TYPE_CIRCLET,
+}
+
+var goodMainStatKeys = map[string]entity.PrimaryStatType{
+	"hp":            entity.HP_FLAT,
+	"atk":           entity.ATK_FLAT,
+	"hp_":           entity.HP_PERCENT,
+	"atk_":          entity.ATK_PERCENT,
+	"def_":          entity.DEF_PERCENT,
+	"eleMas":        entity.ELEMENTAL_MASTERY,
+	"enerRech_":     entity.ENERGY_RECHARGE,
+	"critRate_":     entity.CRIT_RATE,
+	"critDMG_":      entity.CRIT_DMG,
+	"heal_":         entity.HEALING_BONUS,
+	"physical_dmg_": entity.PHYSICAL_DMG_BONUS,
+	"pyro_dmg_":     entity.PYRO_DMG_BONUS,
+	"hydro_dmg_":    entity.HYDRO_DMG_BONUS,
+	"electro_dmg_":  entity.ELECTRO_DMG_BONUS,
+	"cryo_dmg_":     entity.CRYO_DMG_BONUS,
+	"anemo_dmg_":    entity.ANEMO_DMG_BONUS,
+	"geo_dmg_":      entity.GEO_DMG_BONUS,
+	"dendro_dmg_":   entity.DENDRO_DMG_BONUS,
+}
+
+var goodSubstatKeys = map[string]entity.SubstatType{
+	"hp":        entity.SUBSTAT_HP_FLAT,
+	"atk":       entity.SUBSTAT_ATK_FLAT,
+	"def":       entity.SUBSTAT_DEF_FLAT,
+	"hp_":       entity.SUBSTAT_HP_PERCENT,
+	"atk_":      entity.SUBSTAT_ATK_PERCENT,
+	"def_":      entity.SUBSTAT_DEF_PERCENT,
+	"eleMas":    entity.SUBSTAT_ELEMENTAL_MASTERY,
+	"enerRech_": entity.SUBSTAT_ENERGY_RECHARGE,
+	"critRate_": entity.SUBSTAT_CRIT_RATE,
+	"critDMG_":  entity.SUBSTAT_CRIT_DMG,
+}
+
+// newCommandFromGOODArtifact は GOOD の聖遺物を作成コマンドに変換する。
+// GOOD はメインステータスの値を持たないため、レアリティとレベルから算出させる。
+// 未開放のサブステータスはキーが空で出力されるため読み飛ばす。
+func newCommandFromGOODArtifact(goodArtifact GOODArtifact) (*CreateArtifactCommand, error) {
+	artifactType, ok := goodSlotKeys[goodArtifact.SlotKey]
+	if !ok {
+		return nil, fmt.Errorf("%w: slotKey %q", entity.ErrInvalidArtifactType, goodArtifact.SlotKey)
+	}
+	primaryStatType, ok := goodMainStatKeys[goodArtifact.MainStatKey]
+	if !ok {
+		return nil, fmt.Errorf("%w: mainStatKey %q", entity.ErrInvalidPrimaryStatType, goodArtifact.MainStatKey)
+	}
+
+	artifactCommand := &CreateArtifactCommand{
+		ArtifactSet: goodArtifact.SetKey,
+		Type:        string(artifactType),
+		Rarity:      goodArtifact.Rarity,
+		Level:       goodArtifact.Level,
+		PrimaryStat: PrimaryStatCommand{Type: string(primaryStatType)},
+		Substats:    make([]StatCommand, 0, len(goodArtifact.Substats)),
+		Locked:      goodArtifact.Lock,
+		Location:    goodArtifact.Location,
+	}
+	for _, goodSubstat := range goodArtifact.Substats {
+		if goodSubstat.Key == "" {
+			continue
+		}
+		substatType, ok := goodSubstatKeys[goodSubstat.Key]
+		if !ok {
+			return nil, fmt.Errorf("%w: substat key %q", entity.ErrInvalidSubstatType, goodSubstat.Key)
+		}
+		artifactCommand.Substats = append(artifactCommand.Substats, StatCommand{
+			Type:  string(substatType),
+			Value: goodSubstat.Value,
+		})
+	}
+	return artifactCommand, nil
+}
+
+// goodSlotKeysByType などは出力用の逆引き表で、取り込み用の表から作る。
+var (
+	goodSlotKeysByType     = reverseGOODKeys(goodSlotKeys)
+	goodMainStatKeysByType = reverseGOODKeys(goodMainStatKeys)
+	goodSubstatKeysByType  = reverseGOODKeys(goodSubstatKeys)
+)
+
+func reverseGOODKeys[T comparable](keys map[string]T) map[T]string {
+	reversed := make(map[T]string, len(keys))
+	for key, value := range keys {
+		reversed[value] = key
+	}
+	return reversed
+}
+
+// newGOODArtifact は聖遺物を GOOD の聖遺物に変換する。
+// GOOD のキーで表現できないステータスを持つ場合は false を返す。
+func newGOODArtifact(artifact *entity.Artifact) (*GOODArtifact, bool) {
+	slotKey, ok := goodSlotKeysByType[artifact.Type]
+	if !ok {
+		return nil, false
+	}
+	mainStatKey, ok := goodMainStatKeysByType[artifact.PrimaryStat.Type]
+	if !ok {
+		return nil, false
+	}
+
+	goodArtifact := &GOODArtifact{
+		SetKey:      string(artifact.ArtifactSet),
+		SlotKey:     slotKey,
+		Level:       artifact.Level,
+		Rarity:      artifact.Rarity,
+		MainStatKey: mainStatKey,
+		Location:    string(artifact.EquippedBy),
+		Lock:        artifact.Locked,
+		Substats:    make([]GOODSubstat, 0, len(artifact.Substats)),
+	}
+	for _, substat := range artifact.Substats {
+		substatKey, ok := goodSubstatKeysByType[substat.Type]
+		if !ok {
+			return nil, false
+		}
+		goodArtifact.Substats = append(goodArtifact.Substats, GOODSubstat{Key: substatKey, Value: substat.Value})
+	}
+	return goodArtifact, true
+}
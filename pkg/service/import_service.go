@@ -0,0 +1,132 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/repository"
+)
+
+// ImportRejectionDTO は取り込めなかった聖遺物と、その理由を表す。
+// Index は取り込み元の artifacts 配列での位置。
+type ImportRejectionDTO struct {
+	Index  int    `json:"index"`
+	Code   string `json:"code"`
+	Reason string `json:"reason"`
+}
+
+// ImportDuplicateDTO は取り込んだ聖遺物と内容が同じ聖遺物があったことを表す。
+// Index は取り込み元の artifacts 配列での位置で、ID は取り込んだ聖遺物の ID。
+type ImportDuplicateDTO struct {
+	Index       int      `json:"index"`
+	ID          string   `json:"id"`
+	DuplicateOf []string `json:"duplicate_of"`
+}
+
+type ImportResultDTO struct {
+	Imported   int                   `json:"imported"`
+	Rejected   int                   `json:"rejected"`
+	Artifacts  []*ArtifactDTO        `json:"artifacts"`
+	Rejections []*ImportRejectionDTO `json:"rejections"`
+	Duplicates []*ImportDuplicateDTO `json:"duplicates"`
+}
+
+type ImportGOODServiceInterface interface {
+	ImportGOOD(data []byte, duplicatePolicy DuplicatePolicy) (*ImportResultDTO, error)
+}
+
+type ImportService struct {
+	artifactGetter     repository.ArtifactGetter
+	artifactBatchSaver repository.ArtifactBatchSaver
+}
+
+func NewImportService(
+	artifactGetter repository.ArtifactGetter,
+	artifactBatchSaver repository.ArtifactBatchSaver,
+) *ImportService {
+	return &ImportService{
+		artifactGetter:     artifactGetter,
+		artifactBatchSaver: artifactBatchSaver,
+	}
+}
+
+// ImportGOOD は GOOD 形式のインベントリから聖遺物を取り込む。
+// 不正な聖遺物があっても残りは取り込み、拒否した聖遺物は理由とともに結果に含める。
+// カタログにないキャラクターや、すでに埋まっている部位を装備した聖遺物も拒否する。
+// 内容が同じ聖遺物は duplicatePolicy に従って扱い、保存済みの聖遺物に加えて同じドキュメントの先の聖遺物との重複も検出する。
+// DUPLICATE_POLICY_WARN では取り込んだうえで duplicates に、DUPLICATE_POLICY_REJECT では rejections に含める。
+// ドキュメント自体が GOOD 形式として読めない場合のみエラーを返す。
+func (s *ImportService) ImportGOOD(data []byte, duplicatePolicy DuplicatePolicy) (*ImportResultDTO, error) {
+	duplicates, err := newDuplicateChecker(s.artifactGetter, duplicatePolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	var document GOODDocument
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, NewValidationError("invalid_good_document", "", fmt.Errorf("%w: %w", ErrInvalidGOODDocument, err))
+	}
+	if document.Format != GOODFormat {
+		return nil, NewValidationError("invalid_good_document", "format", fmt.Errorf("%w: format must be %s, got %q", ErrInvalidGOODDocument, GOODFormat, document.Format))
+	}
+
+	result := &ImportResultDTO{
+		Artifacts:  make([]*ArtifactDTO, 0, len(document.Artifacts)),
+		Rejections: make([]*ImportRejectionDTO, 0),
+		Duplicates: make([]*ImportDuplicateDTO, 0),
+	}
+	checker := newEquipmentChecker(s.artifactGetter)
+	for i, goodArtifact := range document.Artifacts {
+		artifactDTO, err := s.importGOODArtifact(checker, duplicates, goodArtifact)
+		if err != nil {
+			serviceError := ClassifyError(err)
+			// 保存先の障害は聖遺物ごとの問題ではないため、取り込みを中断する
+			if serviceError.Kind == ERROR_KIND_INTERNAL {
+				return nil, serviceError
+			}
+			result.Rejections = append(result.Rejections, &ImportRejectionDTO{
+				Index:  i,
+				Code:   serviceError.Code,
+				Reason: serviceError.Error(),
+			})
+			continue
+		}
+		result.Artifacts = append(result.Artifacts, artifactDTO)
+		if len(artifactDTO.DuplicateOf) > 0 {
+			result.Duplicates = append(result.Duplicates, &ImportDuplicateDTO{
+				Index:       i,
+				ID:          artifactDTO.ID,
+				DuplicateOf: artifactDTO.DuplicateOf,
+			})
+		}
+	}
+	result.Imported = len(result.Artifacts)
+	result.Rejected = len(result.Rejections)
+	return result, nil
+}
+
+func (s *ImportService) importGOODArtifact(checker *equipmentChecker, duplicates *duplicateChecker, goodArtifact GOODArtifact) (*ArtifactDTO, error) {
+	artifactCommand, err := newCommandFromGOODArtifact(goodArtifact)
+	if err != nil {
+		return nil, err
+	}
+	artifact, err := newArtifactFromCommand(rand.Text(), *artifactCommand)
+	if err != nil {
+		return nil, err
+	}
+	duplicateIDs, err := duplicates.check(artifact)
+	if err != nil {
+		return nil, err
+	}
+	if err := checker.check(artifact); err != nil {
+		return nil, err
+	}
+	if err := s.artifactBatchSaver.SaveArtifacts([]*entity.Artifact{artifact}, duplicates.saveOptions()); err != nil {
+		return nil, err
+	}
+	artifactDTO := newArtifactDTO(artifact)
+	artifactDTO.DuplicateOf = duplicateIDs
+	return artifactDTO, nil
+}
@@ -0,0 +1,277 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/repository"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestImportServiceImportGOOD(t *testing.T) {
+	validGOODArtifact := `{"setKey":"GladiatorsFinale","slotKey":"flower","level":0,"rarity":5,"mainStatKey":"hp","location":"Diluc","lock":true,` +
+		`"substats":[{"key":"critRate_","value":3.9},{"key":"critDMG_","value":7.8},{"key":"atk_","value":5.8},{"key":"","value":0}]}`
+
+	tests := []struct {
+		name string
+
+		// GIVEN
+		mockArtifactSaverError error
+
+		// WHEN
+		data string
+
+		// THEN
+		expectedResult    *ImportResultDTO
+		expectedErrorCode string
+	}{
+		{
+			name: "ShouldImportValidArtifactsAndRejectInvalidOnes",
+
+			data: `{"format":"GOOD","version":2,"source":"scanner","artifacts":[` + validGOODArtifact + `,` +
+				`{"setKey":"GladiatorsFinale","slotKey":"hat","level":0,"rarity":5,"mainStatKey":"hp","substats":[]},` +
+				`{"setKey":"GladiatorsFinale","slotKey":"flower","level":0,"rarity":5,"mainStatKey":"atk","substats":[]},` +
+				`{"setKey":"UnknownSet","slotKey":"flower","level":0,"rarity":5,"mainStatKey":"hp","substats":[]}]}`,
+
+			expectedResult: &ImportResultDTO{
+				Imported: 1,
+				Rejected: 3,
+				Artifacts: []*ArtifactDTO{
+					{
+						Set:         "GladiatorsFinale",
+						Type:        "FLOWER",
+						Rarity:      5,
+						Level:       0,
+						PrimaryStat: StatusDTO{Type: "HP_FLAT", Value: 717},
+						SubStat: []StatusDTO{
+							{Type: "CRIT_RATE", Value: 3.9, Rolls: 1},
+							{Type: "CRIT_DMG", Value: 7.8, Rolls: 1},
+							{Type: "ATK_PERCENT", Value: 5.8, Rolls: 1},
+						},
+						Locked:   true,
+						Location: "Diluc",
+					},
+				},
+				Rejections: []*ImportRejectionDTO{
+					{Index: 1, Code: "invalid_artifact_type", Reason: `invalid artifact type: slotKey "hat"`},
+					{Index: 2, Code: "primary_stat_not_allowed_for_type", Reason: "primary stat is not allowed for artifact type: FLOWER cannot have ATK_FLAT"},
+					{Index: 3, Code: "invalid_artifact_set", Reason: "invalid artifact set"},
+				},
+				Duplicates: []*ImportDuplicateDTO{},
+			},
+		},
+		{
+			name: "ShouldReturnErrorWhenFormatIsNotGOOD",
+
+			data: `{"format":"OTHER","artifacts":[]}`,
+
+			expectedErrorCode: "invalid_good_document",
+		},
+		{
+			name: "ShouldReturnErrorWhenDocumentIsNotJSON",
+
+			data: `not json`,
+
+			expectedErrorCode: "invalid_good_document",
+		},
+		{
+			name: "ShouldAbortWhenArtifactSaverFails",
+
+			mockArtifactSaverError: errors.New("disk full"),
+
+			data: `{"format":"GOOD","artifacts":[` + validGOODArtifact + `]}`,
+
+			expectedErrorCode: ErrorCodeInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			importService := NewImportService(&repository.MockArtifactGetter{
+				GetArtifactByIDError:   repository.ErrArtifactNotFound,
+				QueryArtifactsResponse: &repository.ArtifactQueryResult{},
+			}, &repository.MockArtifactBatchSaver{
+				SaveArtifactsError: tt.mockArtifactSaverError,
+			})
+
+			result, err := importService.ImportGOOD([]byte(tt.data), "")
+			if tt.expectedErrorCode != "" {
+				var serviceError *Error
+				if !errors.As(err, &serviceError) || serviceError.Code != tt.expectedErrorCode {
+					t.Fatalf("expected error code %s, got %v", tt.expectedErrorCode, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.expectedResult, result, cmpopts.IgnoreFields(ArtifactDTO{}, "ID", "CritValue", "RollValue")); diff != "" {
+				t.Errorf("result mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestImportServiceImportGOODEquipment(t *testing.T) {
+	goodArtifact := func(slotKey, mainStatKey, location string) string {
+		return `{"setKey":"GladiatorsFinale","slotKey":"` + slotKey + `","level":0,"rarity":5,"mainStatKey":"` + mainStatKey + `","location":"` + location + `",` +
+			`"substats":[{"key":"critRate_","value":3.9},{"key":"critDMG_","value":7.8},{"key":"atk_","value":5.8}]}`
+	}
+
+	// GIVEN
+	artifactRepository := repository.NewInMemoryArtifactRepository()
+	importService := NewImportService(artifactRepository, artifactRepository)
+	if _, err := importService.ImportGOOD([]byte(`{"format":"GOOD","artifacts":[`+goodArtifact("flower", "hp", "Diluc")+`]}`), ""); err != nil {
+		t.Fatalf("failed to import existing artifact: %v", err)
+	}
+
+	// WHEN
+	result, err := importService.ImportGOOD([]byte(`{"format":"GOOD","artifacts":[`+
+		goodArtifact("plume", "atk", "Diluc")+`,`+
+		goodArtifact("flower", "hp", "Diluc")+`,`+
+		goodArtifact("sands", "hp_", "Xiangling")+`,`+
+		goodArtifact("sands", "hp_", "Xiangling")+`,`+
+		goodArtifact("goblet", "hp_", "Traveler")+`,`+
+		goodArtifact("circlet", "hp_", "")+`]}`), "")
+
+	// THEN
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var rejections []ImportRejectionDTO
+	for _, rejection := range result.Rejections {
+		rejections = append(rejections, ImportRejectionDTO{Index: rejection.Index, Code: rejection.Code})
+	}
+	expectedRejections := []ImportRejectionDTO{
+		{Index: 1, Code: "equipment_slot_occupied"},
+		{Index: 3, Code: "equipment_slot_occupied"},
+		{Index: 4, Code: "invalid_character"},
+	}
+	if diff := cmp.Diff(expectedRejections, rejections); diff != "" {
+		t.Errorf("rejections mismatch (-want +got):\n%s", diff)
+	}
+	if result.Imported != 3 {
+		t.Errorf("expected 3 imported artifacts, got %d", result.Imported)
+	}
+}
+
+func TestImportServiceImportGOODDuplicates(t *testing.T) {
+	goodArtifact := func(slotKey, mainStatKey string) string {
+		return `{"setKey":"GladiatorsFinale","slotKey":"` + slotKey + `","level":0,"rarity":5,"mainStatKey":"` + mainStatKey + `",` +
+			`"substats":[{"key":"critRate_","value":3.9},{"key":"critDMG_","value":7.8},{"key":"atk_","value":5.8}]}`
+	}
+	// 保存済みの聖遺物と重複する聖遺物、重複しない聖遺物、保存済みの聖遺物と先の聖遺物の両方と重複する聖遺物
+	document := `{"format":"GOOD","artifacts":[` + goodArtifact("flower", "hp") + `,` + goodArtifact("plume", "atk") + `,` + goodArtifact("flower", "hp") + `]}`
+
+	tests := []struct {
+		name string
+
+		// WHEN
+		duplicatePolicy DuplicatePolicy
+
+		// THEN
+		expectedImported   int
+		expectedRejections []ImportRejectionDTO
+		// expectedDuplicates の DuplicateOf の "$existing" と "$0" は、保存済みの聖遺物と 0 番目に取り込んだ聖遺物の ID に置き換える
+		expectedDuplicates []ImportDuplicateDTO
+		expectedErrorCode  string
+	}{
+		{
+			name: "ShouldReportDuplicatesPerIndexWhenPolicyIsWarn",
+
+			duplicatePolicy: DUPLICATE_POLICY_WARN,
+
+			expectedImported: 3,
+			expectedDuplicates: []ImportDuplicateDTO{
+				{Index: 0, DuplicateOf: []string{"$existing"}},
+				{Index: 2, DuplicateOf: []string{"$existing", "$0"}},
+			},
+		},
+		{
+			name: "ShouldRejectDuplicatesPerIndexWhenPolicyIsReject",
+
+			duplicatePolicy: DUPLICATE_POLICY_REJECT,
+
+			expectedImported: 1,
+			expectedRejections: []ImportRejectionDTO{
+				{Index: 0, Code: "duplicate_artifact"},
+				{Index: 2, Code: "duplicate_artifact"},
+			},
+		},
+		{
+			name: "ShouldNotCheckDuplicatesWhenPolicyIsAllow",
+
+			duplicatePolicy: DUPLICATE_POLICY_ALLOW,
+
+			expectedImported: 3,
+		},
+		{
+			name: "ShouldReturnErrorWhenDuplicatePolicyIsUnknown",
+
+			duplicatePolicy: "ignore",
+
+			expectedErrorCode: "invalid_duplicate_policy",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			artifactRepository := repository.NewInMemoryArtifactRepository()
+			importService := NewImportService(artifactRepository, artifactRepository)
+			existing, err := importService.ImportGOOD([]byte(`{"format":"GOOD","artifacts":[`+goodArtifact("flower", "hp")+`]}`), DUPLICATE_POLICY_ALLOW)
+			if err != nil || existing.Imported != 1 {
+				t.Fatalf("failed to import existing artifact: %v", err)
+			}
+
+			// WHEN
+			result, err := importService.ImportGOOD([]byte(document), tt.duplicatePolicy)
+
+			// THEN
+			if tt.expectedErrorCode != "" {
+				var serviceError *Error
+				if !errors.As(err, &serviceError) || serviceError.Code != tt.expectedErrorCode {
+					t.Fatalf("expected error code %s, got %v", tt.expectedErrorCode, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Imported != tt.expectedImported {
+				t.Errorf("expected %d imported artifacts, got %d", tt.expectedImported, result.Imported)
+			}
+
+			var rejections []ImportRejectionDTO
+			for _, rejection := range result.Rejections {
+				rejections = append(rejections, ImportRejectionDTO{Index: rejection.Index, Code: rejection.Code})
+			}
+			if diff := cmp.Diff(tt.expectedRejections, rejections); diff != "" {
+				t.Errorf("rejections mismatch (-want +got):\n%s", diff)
+			}
+
+			ids := map[string]string{"$existing": existing.Artifacts[0].ID}
+			if len(result.Artifacts) > 0 {
+				ids["$0"] = result.Artifacts[0].ID
+			}
+			var expectedDuplicates []ImportDuplicateDTO
+			for _, duplicate := range tt.expectedDuplicates {
+				var duplicateOf []string
+				for _, id := range duplicate.DuplicateOf {
+					duplicateOf = append(duplicateOf, ids[id])
+				}
+				expectedDuplicates = append(expectedDuplicates, ImportDuplicateDTO{Index: duplicate.Index, DuplicateOf: duplicateOf})
+			}
+			var duplicates []ImportDuplicateDTO
+			for _, duplicate := range result.Duplicates {
+				duplicates = append(duplicates, ImportDuplicateDTO{Index: duplicate.Index, DuplicateOf: duplicate.DuplicateOf})
+			}
+			if diff := cmp.Diff(expectedDuplicates, duplicates, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+				t.Errorf("duplicates mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -14,7 +14,7 @@ type MockGetArtifactsByTypeService struct {
 	MockGetArtifactsByTypeError error
 }
 
-func (s *MockGetArtifactsByTypeService) GetArtifactsByType(artifactType string) ([]*ArtifactDTO, error) {
+func (s *MockGetArtifactsByTypeService) GetArtifactsByType(artifactType, profileName string) ([]*ArtifactDTO, error) {
 	return s.MockArtifacts, s.MockGetArtifactsByTypeError
 }
 
@@ -23,7 +23,7 @@ type MockGetArtifactsBySetService struct {
 	MockGetArtifactsBySetError error
 }
 
-func (s *MockGetArtifactsBySetService) GetArtifactsBySet(artifactSet string) ([]*ArtifactDTO, error) {
+func (s *MockGetArtifactsBySetService) GetArtifactsBySet(artifactSet, profileName string) ([]*ArtifactDTO, error) {
 	return s.MockArtifacts, s.MockGetArtifactsBySetError
 }
 
@@ -32,14 +32,248 @@ type MockGetArtifactByTypeAndSetService struct {
 	MockGetArtifactByTypeAndSetError error
 }
 
-func (s *MockGetArtifactByTypeAndSetService) GetArtifactsByTypeAndSet(artifactType, artifactSet string) ([]*ArtifactDTO, error) {
+func (s *MockGetArtifactByTypeAndSetService) GetArtifactsByTypeAndSet(artifactType, artifactSet, profileName string) ([]*ArtifactDTO, error) {
 	return s.MockArtifacts, s.MockGetArtifactByTypeAndSetError
 }
 
 type MockCreateArtifactService struct {
+	MockArtifact            *ArtifactDTO
 	MockCreateArtifactError error
 }
 
-func (s *MockCreateArtifactService) CreateArtifact(artifactCommand CreateArtifactCommand) error {
-	return s.MockCreateArtifactError
+func (s *MockCreateArtifactService) CreateArtifact(artifactCommand CreateArtifactCommand) (*ArtifactDTO, error) {
+	return s.MockArtifact, s.MockCreateArtifactError
+}
+
+type MockUpdateArtifactService struct {
+	MockArtifact            *ArtifactDTO
+	MockUpdateArtifactError error
+}
+
+func (s *MockUpdateArtifactService) UpdateArtifact(id string, artifactCommand CreateArtifactCommand) (*ArtifactDTO, error) {
+	return s.MockArtifact, s.MockUpdateArtifactError
+}
+
+type MockPatchArtifactService struct {
+	MockArtifact           *ArtifactDTO
+	MockPatchArtifactError error
+
+	// PatchCommand は最後に PatchArtifact に渡された値を保持する
+	PatchCommand PatchArtifactCommand
+}
+
+func (s *MockPatchArtifactService) PatchArtifact(id string, patchCommand PatchArtifactCommand) (*ArtifactDTO, error) {
+	s.PatchCommand = patchCommand
+	return s.MockArtifact, s.MockPatchArtifactError
+}
+
+type MockDeleteArtifactService struct {
+	MockDeleteArtifactError error
+}
+
+func (s *MockDeleteArtifactService) DeleteArtifact(id string) error {
+	return s.MockDeleteArtifactError
+}
+
+type MockGetArtifactSetsService struct {
+	MockArtifactSets []*ArtifactSetDTO
+}
+
+func (s *MockGetArtifactSetsService) GetArtifactSets(lang string) []*ArtifactSetDTO {
+	return s.MockArtifactSets
+}
+
+type MockGetArtifactSetService struct {
+	MockArtifactSet         *ArtifactSetDTO
+	MockGetArtifactSetError error
+}
+
+func (s *MockGetArtifactSetService) GetArtifactSet(key, lang string) (*ArtifactSetDTO, error) {
+	return s.MockArtifactSet, s.MockGetArtifactSetError
+}
+
+type MockGetCharactersService struct {
+	MockCharacters []*CharacterDTO
+}
+
+func (s *MockGetCharactersService) GetCharacters(lang string) []*CharacterDTO {
+	return s.MockCharacters
+}
+
+type MockGetCharacterService struct {
+	MockCharacter         *CharacterDTO
+	MockGetCharacterError error
+}
+
+func (s *MockGetCharacterService) GetCharacter(key, lang string) (*CharacterDTO, error) {
+	return s.MockCharacter, s.MockGetCharacterError
+}
+
+type MockGetArtifactScoreService struct {
+	MockScore                 *ScoreDTO
+	MockGetArtifactScoreError error
+}
+
+func (s *MockGetArtifactScoreService) GetArtifactScore(id, profileName string) (*ScoreDTO, error) {
+	return s.MockScore, s.MockGetArtifactScoreError
+}
+
+type MockGetScoreProfilesService struct {
+	MockScoreProfiles         []*ScoreProfileDTO
+	MockGetScoreProfilesError error
+}
+
+func (s *MockGetScoreProfilesService) GetScoreProfiles() ([]*ScoreProfileDTO, error) {
+	return s.MockScoreProfiles, s.MockGetScoreProfilesError
+}
+
+type MockGetScoreProfileService struct {
+	MockScoreProfile         *ScoreProfileDTO
+	MockGetScoreProfileError error
+}
+
+func (s *MockGetScoreProfileService) GetScoreProfile(name string) (*ScoreProfileDTO, error) {
+	return s.MockScoreProfile, s.MockGetScoreProfileError
+}
+
+type MockSaveScoreProfileService struct {
+	MockScoreProfile          *ScoreProfileDTO
+	MockSaveScoreProfileError error
+}
+
+func (s *MockSaveScoreProfileService) SaveScoreProfile(profileCommand SaveScoreProfileCommand) (*ScoreProfileDTO, error) {
+	return s.MockScoreProfile, s.MockSaveScoreProfileError
+}
+
+type MockDeleteScoreProfileService struct {
+	MockDeleteScoreProfileError error
+}
+
+func (s *MockDeleteScoreProfileService) DeleteScoreProfile(name string) error {
+	return s.MockDeleteScoreProfileError
+}
+
+type MockQueryArtifactsService struct {
+	MockArtifactList        *ArtifactListDTO
+	MockQueryArtifactsError error
+	QueriedCommand          QueryArtifactsCommand
+}
+
+func (s *MockQueryArtifactsService) QueryArtifacts(queryCommand QueryArtifactsCommand) (*ArtifactListDTO, error) {
+	s.QueriedCommand = queryCommand
+	return s.MockArtifactList, s.MockQueryArtifactsError
+}
+
+type MockImportGOODService struct {
+	MockImportResult    *ImportResultDTO
+	MockImportGOODError error
+	ImportedData        []byte
+	QueriedPolicy       DuplicatePolicy
+}
+
+func (s *MockImportGOODService) ImportGOOD(data []byte, duplicatePolicy DuplicatePolicy) (*ImportResultDTO, error) {
+	s.ImportedData = data
+	s.QueriedPolicy = duplicatePolicy
+	return s.MockImportResult, s.MockImportGOODError
+}
+
+type MockBatchCreateArtifactsService struct {
+	MockBatchCreateResult         *BatchCreateResultDTO
+	MockBatchCreateArtifactsError error
+	QueriedMode                   string
+	QueriedPolicy                 DuplicatePolicy
+	QueriedCommands               []CreateArtifactCommand
+}
+
+func (s *MockBatchCreateArtifactsService) BatchCreateArtifacts(mode string, duplicatePolicy DuplicatePolicy, artifactCommands []CreateArtifactCommand) (*BatchCreateResultDTO, error) {
+	s.QueriedMode = mode
+	s.QueriedPolicy = duplicatePolicy
+	s.QueriedCommands = artifactCommands
+	return s.MockBatchCreateResult, s.MockBatchCreateArtifactsError
+}
+
+type MockGetLoadoutService struct {
+	MockLoadout         *LoadoutDTO
+	MockGetLoadoutError error
+}
+
+func (s *MockGetLoadoutService) GetLoadout(character string) (*LoadoutDTO, error) {
+	return s.MockLoadout, s.MockGetLoadoutError
+}
+
+type MockEquipArtifactService struct {
+	MockLoadout            *LoadoutDTO
+	MockEquipArtifactError error
+	QueriedCharacter       string
+	QueriedCommand         EquipArtifactCommand
+}
+
+func (s *MockEquipArtifactService) EquipArtifact(character string, equipCommand EquipArtifactCommand) (*LoadoutDTO, error) {
+	s.QueriedCharacter = character
+	s.QueriedCommand = equipCommand
+	return s.MockLoadout, s.MockEquipArtifactError
+}
+
+type MockUnequipArtifactService struct {
+	MockLoadout              *LoadoutDTO
+	MockUnequipArtifactError error
+	QueriedCharacter         string
+	QueriedArtifactType      string
+}
+
+func (s *MockUnequipArtifactService) UnequipArtifact(character, artifactType string) (*LoadoutDTO, error) {
+	s.QueriedCharacter = character
+	s.QueriedArtifactType = artifactType
+	return s.MockLoadout, s.MockUnequipArtifactError
+}
+
+type MockGetAccountsService struct {
+	MockAccounts         []*AccountDTO
+	MockGetAccountsError error
+}
+
+func (s *MockGetAccountsService) GetAccounts() ([]*AccountDTO, error) {
+	return s.MockAccounts, s.MockGetAccountsError
+}
+
+type MockGetAccountService struct {
+	MockAccount         *AccountDTO
+	MockGetAccountError error
+}
+
+func (s *MockGetAccountService) GetAccount(name string) (*AccountDTO, error) {
+	return s.MockAccount, s.MockGetAccountError
+}
+
+type MockCreateAccountService struct {
+	MockAccount            *AccountDTO
+	MockCreateAccountError error
+	QueriedCommand         CreateAccountCommand
+}
+
+func (s *MockCreateAccountService) CreateAccount(accountCommand CreateAccountCommand) (*AccountDTO, error) {
+	s.QueriedCommand = accountCommand
+	return s.MockAccount, s.MockCreateAccountError
+}
+
+type MockAccountServicesResolver struct {
+	MockAccountServices      *AccountServices
+	MockAccountServicesError error
+	QueriedName              string
+}
+
+func (s *MockAccountServicesResolver) AccountServices(name string) (*AccountServices, error) {
+	s.QueriedName = name
+	return s.MockAccountServices, s.MockAccountServicesError
+}
+
+type MockAuthenticateService struct {
+	MockPrincipal         *Principal
+	MockAuthenticateError error
+	QueriedKey            string
+}
+
+func (s *MockAuthenticateService) Authenticate(key string) (*Principal, error) {
+	s.QueriedKey = key
+	return s.MockPrincipal, s.MockAuthenticateError
 }
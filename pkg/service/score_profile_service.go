@@ -0,0 +1,109 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/repository"
+)
+
+type ScoreProfileDTO struct {
+	Name    string             `json:"name"`
+	Weights map[string]float64 `json:"weights"`
+}
+
+type SaveScoreProfileCommand struct {
+	Name    string
+	Weights map[string]float64
+}
+
+type GetScoreProfilesServiceInterface interface {
+	GetScoreProfiles() ([]*ScoreProfileDTO, error)
+}
+
+type GetScoreProfileServiceInterface interface {
+	GetScoreProfile(name string) (*ScoreProfileDTO, error)
+}
+
+type SaveScoreProfileServiceInterface interface {
+	SaveScoreProfile(profileCommand SaveScoreProfileCommand) (*ScoreProfileDTO, error)
+}
+
+type DeleteScoreProfileServiceInterface interface {
+	DeleteScoreProfile(name string) error
+}
+
+// ScoreProfileService はアカウント間で共有するスコアプロファイルを管理する。
+type ScoreProfileService struct {
+	scoreProfileGetter  repository.ScoreProfileGetter
+	scoreProfileSaver   repository.ScoreProfileSaver
+	scoreProfileDeleter repository.ScoreProfileDeleter
+}
+
+func NewScoreProfileService(
+	scoreProfileGetter repository.ScoreProfileGetter,
+	scoreProfileSaver repository.ScoreProfileSaver,
+	scoreProfileDeleter repository.ScoreProfileDeleter,
+) *ScoreProfileService {
+	return &ScoreProfileService{
+		scoreProfileGetter:  scoreProfileGetter,
+		scoreProfileSaver:   scoreProfileSaver,
+		scoreProfileDeleter: scoreProfileDeleter,
+	}
+}
+
+func (s *ScoreProfileService) GetScoreProfiles() ([]*ScoreProfileDTO, error) {
+	profiles, err := s.scoreProfileGetter.GetScoreProfiles()
+	if err != nil {
+		return nil, ClassifyError(err)
+	}
+
+	profileDTOs := make([]*ScoreProfileDTO, 0, len(profiles))
+	for _, profile := range profiles {
+		profileDTOs = append(profileDTOs, newScoreProfileDTO(profile))
+	}
+	return profileDTOs, nil
+}
+
+func (s *ScoreProfileService) GetScoreProfile(name string) (*ScoreProfileDTO, error) {
+	profile, err := s.scoreProfileGetter.GetScoreProfile(name)
+	if err != nil {
+		return nil, ClassifyError(err)
+	}
+	return newScoreProfileDTO(profile), nil
+}
+
+// SaveScoreProfile は同名のプロファイルがあれば置き換える。
+func (s *ScoreProfileService) SaveScoreProfile(profileCommand SaveScoreProfileCommand) (*ScoreProfileDTO, error) {
+	profile, err := entity.NewScoreProfile(profileCommand.Name, profileCommand.Weights)
+	if err != nil {
+		// 重みのキーに使われたサブステータスの誤りは weights の誤りとして返す
+		if errors.Is(err, entity.ErrInvalidSubstatType) {
+			return nil, NewValidationError("invalid_substat_type", "weights", err)
+		}
+		return nil, ClassifyError(err)
+	}
+
+	if err := s.scoreProfileSaver.SaveScoreProfile(profile); err != nil {
+		return nil, ClassifyError(err)
+	}
+	return newScoreProfileDTO(profile), nil
+}
+
+func (s *ScoreProfileService) DeleteScoreProfile(name string) error {
+	if err := s.scoreProfileDeleter.DeleteScoreProfile(name); err != nil {
+		return ClassifyError(err)
+	}
+	return nil
+}
+
+func newScoreProfileDTO(profile *entity.ScoreProfile) *ScoreProfileDTO {
+	weights := make(map[string]float64, len(profile.Weights))
+	for substatType, weight := range profile.Weights {
+		weights[string(substatType)] = weight
+	}
+	return &ScoreProfileDTO{
+		Name:    profile.Name,
+		Weights: weights,
+	}
+}
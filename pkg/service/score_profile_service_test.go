@@ -0,0 +1,99 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/repository"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestScoreProfileServiceSaveScoreProfile(t *testing.T) {
+	errSaver := errors.New("saver error")
+
+	tests := []struct {
+		name string
+
+		// GIVEN
+		mockSaveScoreProfileError error
+
+		// WHEN
+		profileCommand SaveScoreProfileCommand
+
+		// THEN
+		expectedProfile *ScoreProfileDTO
+		expectedError   error
+	}{
+		{
+			name: "ShouldSaveScoreProfileSuccessfully",
+
+			profileCommand: SaveScoreProfileCommand{
+				Name:    "HuTao",
+				Weights: map[string]float64{"CRIT_DMG": 1, "HP_PERCENT": 0.8},
+			},
+
+			expectedProfile: &ScoreProfileDTO{
+				Name:    "HuTao",
+				Weights: map[string]float64{"CRIT_DMG": 1, "HP_PERCENT": 0.8},
+			},
+		},
+		{
+			name: "ShouldReturnErrorWhenWeightIsInvalid",
+
+			profileCommand: SaveScoreProfileCommand{
+				Name:    "HuTao",
+				Weights: map[string]float64{"CRIT_DMG": -1},
+			},
+
+			expectedError: entity.ErrInvalidScoreProfileWeight,
+		},
+		{
+			name: "ShouldReturnErrorWhenSaverFails",
+
+			mockSaveScoreProfileError: errSaver,
+
+			profileCommand: SaveScoreProfileCommand{Name: "HuTao"},
+
+			expectedError: errSaver,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewScoreProfileService(nil, &repository.MockScoreProfileSaver{
+				SaveScoreProfileError: tt.mockSaveScoreProfileError,
+			}, nil)
+
+			profile, err := service.SaveScoreProfile(tt.profileCommand)
+			if !errors.Is(err, tt.expectedError) {
+				t.Errorf("SaveScoreProfile() error = %v, expectedError %v", err, tt.expectedError)
+			}
+
+			if diff := cmp.Diff(tt.expectedProfile, profile); diff != "" {
+				t.Errorf("SaveScoreProfile() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestScoreProfileServiceGetScoreProfiles(t *testing.T) {
+	service := NewScoreProfileService(&repository.MockScoreProfileGetter{
+		GetScoreProfilesResponse: []*entity.ScoreProfile{
+			{Name: "HuTao", Weights: map[entity.SubstatType]float64{entity.SUBSTAT_CRIT_DMG: 1}},
+		},
+	}, nil, nil)
+
+	profiles, err := service.GetScoreProfiles()
+	if err != nil {
+		t.Fatalf("GetScoreProfiles() error = %v", err)
+	}
+
+	expectedProfiles := []*ScoreProfileDTO{
+		{Name: "HuTao", Weights: map[string]float64{"CRIT_DMG": 1}},
+	}
+	if diff := cmp.Diff(expectedProfiles, profiles); diff != "" {
+		t.Errorf("GetScoreProfiles() mismatch (-want +got):\n%s", diff)
+	}
+}
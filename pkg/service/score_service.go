@@ -0,0 +1,69 @@
+package service
+
+import (
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/repository"
+)
+
+type ScoreDTO struct {
+	ArtifactID string  `json:"artifact_id"`
+	CritValue  float64 `json:"crit_value"`
+	RollValue  float64 `json:"roll_value"`
+	// Profile と ProfileScore はプロファイルを指定した場合のみ返す
+	Profile      string   `json:"profile,omitempty"`
+	ProfileScore *float64 `json:"profile_score,omitempty"`
+}
+
+type GetArtifactScoreServiceInterface interface {
+	GetArtifactScore(id, profileName string) (*ScoreDTO, error)
+}
+
+type ScoreService struct {
+	artifactGetter     repository.ArtifactGetter
+	scoreProfileGetter repository.ScoreProfileGetter
+}
+
+func NewScoreService(
+	artifactGetter repository.ArtifactGetter,
+	scoreProfileGetter repository.ScoreProfileGetter,
+) *ScoreService {
+	return &ScoreService{
+		artifactGetter:     artifactGetter,
+		scoreProfileGetter: scoreProfileGetter,
+	}
+}
+
+// GetArtifactScore は会心値とロール値を返す。profileName が空でなければプロファイルによる点数も返す。
+func (s *ScoreService) GetArtifactScore(id, profileName string) (*ScoreDTO, error) {
+	artifact, err := s.artifactGetter.GetArtifactByID(id)
+	if err != nil {
+		return nil, ClassifyError(err)
+	}
+
+	// 保存済みの聖遺物を分解できないのはリクエストではなくデータの問題なので内部エラーとする
+	rollValue, err := entity.RollValue(artifact)
+	if err != nil {
+		return nil, newInternalError(err)
+	}
+
+	scoreDTO := &ScoreDTO{
+		ArtifactID: artifact.ID,
+		CritValue:  entity.CritValue(artifact),
+		RollValue:  rollValue,
+	}
+
+	if profileName != "" {
+		profile, err := s.scoreProfileGetter.GetScoreProfile(profileName)
+		if err != nil {
+			return nil, ClassifyError(err)
+		}
+		profileScore, err := profile.Score(artifact)
+		if err != nil {
+			return nil, newInternalError(err)
+		}
+		scoreDTO.Profile = profile.Name
+		scoreDTO.ProfileScore = &profileScore
+	}
+
+	return scoreDTO, nil
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/repository"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestScoreServiceGetArtifactScore(t *testing.T) {
+	testArtifact := &entity.Artifact{
+		ID:          "test-id",
+		ArtifactSet: entity.ARTIFACT_SET_GLADIATORS_FINALOFFERING,
+		Type:        entity.ARTIFACT_TYPE_FLOWER,
+		Rarity:      5,
+		Level:       0,
+		PrimaryStat: entity.PrimaryStat{Type: entity.HP_FLAT, Value: 717},
+		Substats: []entity.Substat{
+			{Type: entity.SUBSTAT_CRIT_RATE, Value: 3.89},
+			{Type: entity.SUBSTAT_CRIT_DMG, Value: 7.77},
+			{Type: entity.SUBSTAT_HP_PERCENT, Value: 5.83},
+		},
+	}
+	testProfile := &entity.ScoreProfile{
+		Name:    "HuTao",
+		Weights: map[entity.SubstatType]float64{entity.SUBSTAT_CRIT_DMG: 1, entity.SUBSTAT_HP_PERCENT: 0.5},
+	}
+	profileScore := 1.5
+
+	tests := []struct {
+		name string
+
+		// GIVEN
+		mockGetArtifactByIDError    error
+		mockGetScoreProfileResponse *entity.ScoreProfile
+		mockGetScoreProfileError    error
+
+		// WHEN
+		profileName string
+
+		// THEN
+		expectedScore *ScoreDTO
+		expectedError error
+	}{
+		{
+			name: "ShouldReturnCritValueAndRollValue",
+
+			expectedScore: &ScoreDTO{
+				ArtifactID: "test-id",
+				CritValue:  15.55,
+				RollValue:  300,
+			},
+		},
+		{
+			name: "ShouldReturnProfileScoreWhenProfileIsSpecified",
+
+			mockGetScoreProfileResponse: testProfile,
+
+			profileName: "HuTao",
+
+			expectedScore: &ScoreDTO{
+				ArtifactID:   "test-id",
+				CritValue:    15.55,
+				RollValue:    300,
+				Profile:      "HuTao",
+				ProfileScore: &profileScore,
+			},
+		},
+		{
+			name: "ShouldReturnErrorWhenProfileNotFound",
+
+			mockGetScoreProfileError: repository.ErrScoreProfileNotFound,
+
+			profileName: "Unknown",
+
+			expectedError: repository.ErrScoreProfileNotFound,
+		},
+		{
+			name: "ShouldReturnErrorWhenArtifactNotFound",
+
+			mockGetArtifactByIDError: repository.ErrArtifactNotFound,
+
+			expectedError: repository.ErrArtifactNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewScoreService(
+				&repository.MockArtifactGetter{
+					GetArtifactByIDResponse: testArtifact,
+					GetArtifactByIDError:    tt.mockGetArtifactByIDError,
+				},
+				&repository.MockScoreProfileGetter{
+					GetScoreProfileResponse: tt.mockGetScoreProfileResponse,
+					GetScoreProfileError:    tt.mockGetScoreProfileError,
+				},
+			)
+
+			score, err := service.GetArtifactScore("test-id", tt.profileName)
+			if !errors.Is(err, tt.expectedError) {
+				t.Errorf("GetArtifactScore() error = %v, expectedError %v", err, tt.expectedError)
+			}
+
+			if diff := cmp.Diff(tt.expectedScore, score, cmpopts.EquateApprox(0, 1e-9)); diff != "" {
+				t.Errorf("GetArtifactScore() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
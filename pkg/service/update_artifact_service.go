@@ -2,71 +2,382 @@ package service
 
 import (
 	"crypto/rand"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
 
 	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
 	"github.com/YutoOkawa/genshin-artifact-db/pkg/repository"
 )
 
+var (
+	ErrInvalidArtifactIDFormat = errors.New("invalid artifact ID format")
+	ErrArtifactIDMismatch      = errors.New("artifact ID in body does not match path")
+	ErrInvalidDuplicatePolicy  = errors.New("invalid duplicate policy")
+)
+
+// clientArtifactIDPattern はクライアントが指定できる ID の形式で、パスにそのまま使える文字に限る。
+var clientArtifactIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// DuplicatePolicy は内容が同じ聖遺物がすでにある場合の作成時の扱い。
+type DuplicatePolicy string
+
+// DUPLICATE_POLICY_WARN は作成したうえで、重複している聖遺物の ID を DuplicateOf で返す。
+const DUPLICATE_POLICY_WARN DuplicatePolicy = "warn"
+
+// DUPLICATE_POLICY_REJECT は作成せずに repository.ErrDuplicateArtifact を返す。
+// 同時に作成した場合の最終的な判定は、リポジトリが書き込みと同じロックの中で行う。
+const DUPLICATE_POLICY_REJECT DuplicatePolicy = "reject"
+
+// DUPLICATE_POLICY_ALLOW は重複を確認しない。
+const DUPLICATE_POLICY_ALLOW DuplicatePolicy = "allow"
+
 type StatCommand struct {
 	Type  string
 	Value float64
 }
 
+// PrimaryStatCommand の Value が nil の場合、レアリティとレベルから値を算出する。
+type PrimaryStatCommand struct {
+	Type  string
+	Value *float64
+}
+
+// CreateArtifactCommand の ID は作成時のみ使い、空の場合はサーバーで採番する。
+// DuplicatePolicy が空の場合は DUPLICATE_POLICY_WARN として扱う。
 type CreateArtifactCommand struct {
+	ID              string
+	DuplicatePolicy DuplicatePolicy
+
 	ArtifactSet string
 	Type        string
+	Rarity      int
 	Level       int
-	PrimaryStat StatCommand
+	PrimaryStat PrimaryStatCommand
 	Substats    []StatCommand
+	Locked      bool
+	Location    string
+}
+
+type PatchStatCommand struct {
+	Type  *string
+	Value *float64
+}
+
+// PatchArtifactCommand は nil のフィールドを変更しない。
+// Substats は指定された場合、既存のサブステータスをすべて置き換える。
+// レアリティ・レベル・メインステータスの種類を変更して値を指定しない場合、メインステータスの値は再計算する。
+// Location に空文字列を指定すると装備を外す。
+type PatchArtifactCommand struct {
+	ArtifactSet *string
+	Type        *string
+	Rarity      *int
+	Level       *int
+	PrimaryStat *PatchStatCommand
+	Substats    *[]StatCommand
+	Locked      *bool
+	Location    *string
 }
 
 type CreateArtifactServiceInterface interface {
-	CreateArtifact(artifactCommand CreateArtifactCommand) error
+	CreateArtifact(artifactCommand CreateArtifactCommand) (*ArtifactDTO, error)
+}
+
+type UpdateArtifactServiceInterface interface {
+	UpdateArtifact(id string, artifactCommand CreateArtifactCommand) (*ArtifactDTO, error)
+}
+
+type PatchArtifactServiceInterface interface {
+	PatchArtifact(id string, patchCommand PatchArtifactCommand) (*ArtifactDTO, error)
+}
+
+type DeleteArtifactServiceInterface interface {
+	DeleteArtifact(id string) error
 }
 
 type UpdateArtifactService struct {
-	artifactSaver repository.ArtifactSaver
+	artifactGetter     repository.ArtifactGetter
+	artifactBatchSaver repository.ArtifactBatchSaver
+	artifactUpdater    repository.ArtifactUpdater
+	artifactModifier   repository.ArtifactModifier
+	artifactDeleter    repository.ArtifactDeleter
 }
 
-func NewUpdateArtifactService(artifactSaver repository.ArtifactSaver) *UpdateArtifactService {
+func NewUpdateArtifactService(
+	artifactGetter repository.ArtifactGetter,
+	artifactBatchSaver repository.ArtifactBatchSaver,
+	artifactUpdater repository.ArtifactUpdater,
+	artifactModifier repository.ArtifactModifier,
+	artifactDeleter repository.ArtifactDeleter,
+) *UpdateArtifactService {
 	return &UpdateArtifactService{
-		artifactSaver: artifactSaver,
+		artifactGetter:     artifactGetter,
+		artifactBatchSaver: artifactBatchSaver,
+		artifactUpdater:    artifactUpdater,
+		artifactModifier:   artifactModifier,
+		artifactDeleter:    artifactDeleter,
 	}
 }
 
-func (s *UpdateArtifactService) CreateArtifact(artifactCommand CreateArtifactCommand) error {
-	primaryStat, err := entity.NewPrimaryStat(
-		artifactCommand.PrimaryStat.Type,
-		artifactCommand.PrimaryStat.Value,
-	)
+// CreateArtifact は指定された ID がすでに使われている場合 repository.ErrArtifactAlreadyExists を返す。
+// Location のキャラクターがその部位をすでに装備している場合は repository.ErrEquipmentSlotOccupied を返す。
+func (s *UpdateArtifactService) CreateArtifact(artifactCommand CreateArtifactCommand) (*ArtifactDTO, error) {
+	id, err := newArtifactIDFromCommand(artifactCommand)
+	if err != nil {
+		return nil, err
+	}
+
+	artifact, err := newArtifactFromCommand(id, artifactCommand)
+	if err != nil {
+		return nil, ClassifyError(err)
+	}
+
+	duplicates, err := newDuplicateChecker(s.artifactGetter, artifactCommand.DuplicatePolicy)
+	if err != nil {
+		return nil, err
+	}
+	duplicateIDs, err := duplicates.check(artifact)
+	if err != nil {
+		return nil, err
+	}
+	if err := newEquipmentChecker(s.artifactGetter).check(artifact); err != nil {
+		return nil, err
+	}
+
+	if err := s.artifactBatchSaver.SaveArtifacts([]*entity.Artifact{artifact}, duplicates.saveOptions()); err != nil {
+		return nil, ClassifyError(err)
+	}
+	artifactDTO := newArtifactDTO(artifact)
+	artifactDTO.DuplicateOf = duplicateIDs
+	return artifactDTO, nil
+}
+
+// duplicateChecker は DuplicatePolicy に従って、作成する聖遺物と内容が同じ聖遺物を探す。
+// まとめて作成する聖遺物どうしの重複も検出するため、一度の処理では同じ checker を使うこと。
+type duplicateChecker struct {
+	artifactGetter repository.ArtifactGetter
+	policy         DuplicatePolicy
+	// reserved は同じ処理の中で先に確かめた聖遺物
+	reserved []*entity.Artifact
+}
+
+// newDuplicateChecker は policy が空の場合 DUPLICATE_POLICY_WARN として扱い、不正な場合は検証エラーを返す。
+func newDuplicateChecker(artifactGetter repository.ArtifactGetter, policy DuplicatePolicy) (*duplicateChecker, error) {
+	switch policy {
+	case DUPLICATE_POLICY_WARN, DUPLICATE_POLICY_REJECT, DUPLICATE_POLICY_ALLOW:
+	case "":
+		policy = DUPLICATE_POLICY_WARN
+	default:
+		return nil, NewValidationError("invalid_duplicate_policy", "on_duplicate", fmt.Errorf("%w: %q", ErrInvalidDuplicatePolicy, policy))
+	}
+	return &duplicateChecker{
+		artifactGetter: artifactGetter,
+		policy:         policy,
+	}, nil
+}
+
+// check は内容が同じ聖遺物の ID を返す。DUPLICATE_POLICY_REJECT で重複がある場合は repository.ErrDuplicateArtifact を返す。
+// 保存する前にエラーの位置を返すための判定で、同時に保存された場合の最終的な判定は saveOptions を渡したリポジトリが行う。
+func (c *duplicateChecker) check(artifact *entity.Artifact) ([]string, error) {
+	if c.policy == DUPLICATE_POLICY_ALLOW {
+		return nil, nil
+	}
+
+	duplicateIDs, err := c.findDuplicateIDs(artifact)
+	if err != nil {
+		return nil, ClassifyError(err)
+	}
+	// 先に確かめた聖遺物がすでに保存されていれば、findDuplicateIDs の結果に含まれている
+	for _, reserved := range c.reserved {
+		if reserved.SameContent(artifact) && !slices.Contains(duplicateIDs, reserved.ID) {
+			duplicateIDs = append(duplicateIDs, reserved.ID)
+		}
+	}
+	if c.policy == DUPLICATE_POLICY_REJECT && len(duplicateIDs) > 0 {
+		return nil, ClassifyError(fmt.Errorf("%w: same content as %s", repository.ErrDuplicateArtifact, strings.Join(duplicateIDs, ", ")))
+	}
+
+	c.reserved = append(c.reserved, artifact)
+	return duplicateIDs, nil
+}
+
+// saveOptions は DUPLICATE_POLICY_REJECT の場合に、リポジトリにも保存と同じロックの中で重複を確かめさせる。
+func (c *duplicateChecker) saveOptions() repository.ArtifactSaveOptions {
+	return repository.ArtifactSaveOptions{RejectDuplicates: c.policy == DUPLICATE_POLICY_REJECT}
+}
+
+// findDuplicateIDs は部位・セットなどの索引で候補を絞り込んでから、保存済みの聖遺物から内容が同じ聖遺物を探す。
+func (c *duplicateChecker) findDuplicateIDs(artifact *entity.Artifact) ([]string, error) {
+	result, err := c.artifactGetter.QueryArtifacts(repository.DuplicateQuery(artifact))
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	var duplicateIDs []string
+	for _, candidate := range result.Artifacts {
+		if candidate.SameContent(artifact) {
+			duplicateIDs = append(duplicateIDs, candidate.ID)
+		}
+	}
+	return duplicateIDs, nil
+}
+
+// UpdateArtifact は artifactCommand の ID を使わないが、指定されていてパスの ID と異なる場合はエラーにする。
+func (s *UpdateArtifactService) UpdateArtifact(id string, artifactCommand CreateArtifactCommand) (*ArtifactDTO, error) {
+	if artifactCommand.ID != "" && artifactCommand.ID != id {
+		return nil, NewValidationError("artifact_id_mismatch", "id", fmt.Errorf("%w: %s != %s", ErrArtifactIDMismatch, artifactCommand.ID, id))
+	}
+
+	artifact, err := newArtifactFromCommand(id, artifactCommand)
+	if err != nil {
+		return nil, ClassifyError(err)
+	}
+	if err := newEquipmentChecker(s.artifactGetter).check(artifact); err != nil {
+		return nil, err
+	}
+
+	if err := s.artifactUpdater.UpdateArtifact(artifact); err != nil {
+		return nil, ClassifyError(err)
+	}
+	return newArtifactDTO(artifact), nil
+}
+
+// PatchArtifact は現在の聖遺物の読み取りから更新までをリポジトリの書き込みロックの中で行うため、
+// 同時に別のフィールドを変更しても互いの変更を取り消さない。
+func (s *UpdateArtifactService) PatchArtifact(id string, patchCommand PatchArtifactCommand) (*ArtifactDTO, error) {
+	artifact, err := s.artifactModifier.ModifyArtifact(id, func(current *entity.Artifact) (*entity.Artifact, error) {
+		artifact, err := newArtifactFromCommand(current.ID, patchedArtifactCommand(current, patchCommand))
+		if err != nil {
+			return nil, ClassifyError(err)
+		}
+		// 部位の重複はリポジトリが確かめる。ロックの中ではリポジトリを読めないため equipmentChecker は使わない
+		if equipmentChanged(current, artifact) {
+			if _, err := entity.ParseCharacterKey(string(artifact.EquippedBy)); err != nil {
+				return nil, ClassifyError(err)
+			}
+		}
+		return artifact, nil
+	})
+	if err != nil {
+		return nil, ClassifyError(err)
+	}
+	return newArtifactDTO(artifact), nil
+}
+
+// patchedArtifactCommand は現在の聖遺物に patchCommand を重ねた内容を返す。
+func patchedArtifactCommand(current *entity.Artifact, patchCommand PatchArtifactCommand) CreateArtifactCommand {
+	artifactCommand := CreateArtifactCommand{
+		ArtifactSet: string(current.ArtifactSet),
+		Type:        string(current.Type),
+		Rarity:      current.Rarity,
+		Level:       current.Level,
+		PrimaryStat: PrimaryStatCommand{
+			Type:  string(current.PrimaryStat.Type),
+			Value: &current.PrimaryStat.Value,
+		},
+		Substats: make([]StatCommand, 0, len(current.Substats)),
+		Locked:   current.Locked,
+		Location: string(current.EquippedBy),
+	}
+	for _, substat := range current.Substats {
+		artifactCommand.Substats = append(artifactCommand.Substats, StatCommand{
+			Type:  string(substat.Type),
+			Value: substat.Value,
+		})
+	}
+
+	if patchCommand.ArtifactSet != nil {
+		artifactCommand.ArtifactSet = *patchCommand.ArtifactSet
+	}
+	if patchCommand.Type != nil {
+		artifactCommand.Type = *patchCommand.Type
+	}
+	if patchCommand.Rarity != nil {
+		artifactCommand.Rarity = *patchCommand.Rarity
+		artifactCommand.PrimaryStat.Value = nil
+	}
+	if patchCommand.Level != nil {
+		artifactCommand.Level = *patchCommand.Level
+		artifactCommand.PrimaryStat.Value = nil
+	}
+	if patchCommand.PrimaryStat != nil {
+		if patchCommand.PrimaryStat.Type != nil {
+			artifactCommand.PrimaryStat.Type = *patchCommand.PrimaryStat.Type
+			artifactCommand.PrimaryStat.Value = nil
+		}
+		if patchCommand.PrimaryStat.Value != nil {
+			artifactCommand.PrimaryStat.Value = patchCommand.PrimaryStat.Value
+		}
+	}
+	if patchCommand.Substats != nil {
+		artifactCommand.Substats = *patchCommand.Substats
+	}
+	if patchCommand.Locked != nil {
+		artifactCommand.Locked = *patchCommand.Locked
+	}
+	if patchCommand.Location != nil {
+		artifactCommand.Location = *patchCommand.Location
+	}
+
+	return artifactCommand
+}
+
+func (s *UpdateArtifactService) DeleteArtifact(id string) error {
+	if err := s.artifactDeleter.DeleteArtifactByID(id); err != nil {
+		return ClassifyError(err)
+	}
+	return nil
+}
+
+// newArtifactIDFromCommand はクライアントが指定した ID を検証して返し、指定がなければ採番する。
+func newArtifactIDFromCommand(artifactCommand CreateArtifactCommand) (string, error) {
+	if artifactCommand.ID == "" {
+		return rand.Text(), nil
+	}
+	if !clientArtifactIDPattern.MatchString(artifactCommand.ID) {
+		return "", NewValidationError("invalid_artifact_id", "id", fmt.Errorf("%w: must be 1 to 64 characters of letters, digits, '-' or '_', got %q", ErrInvalidArtifactIDFormat, artifactCommand.ID))
+	}
+	return artifactCommand.ID, nil
+}
+
+func newArtifactFromCommand(id string, artifactCommand CreateArtifactCommand) (*entity.Artifact, error) {
+	primaryStat, err := entity.NewPrimaryStat(artifactCommand.PrimaryStat.Type, 0)
+	if err != nil {
+		return nil, err
+	}
+	if artifactCommand.PrimaryStat.Value != nil {
+		primaryStat.Value = *artifactCommand.PrimaryStat.Value
+	} else {
+		primaryStat.Value, err = entity.MainStatValue(artifactCommand.Rarity, artifactCommand.Level, primaryStat.Type)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	subStats := make([]entity.Substat, 0, len(artifactCommand.Substats))
 	for _, substat := range artifactCommand.Substats {
 		subStat, err := entity.NewSubstat(substat.Type, substat.Value)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		subStats = append(subStats, *subStat)
 	}
 
 	artifact, err := entity.NewArtifact(
-		rand.Text(),
+		id,
 		artifactCommand.ArtifactSet,
 		artifactCommand.Type,
+		artifactCommand.Rarity,
 		artifactCommand.Level,
 		*primaryStat,
 		subStats,
 	)
-
 	if err != nil {
-		return err
+		return nil, err
 	}
-
-	if err := s.artifactSaver.SaveArtifact(artifact); err != nil {
-		return err
-	}
-	return nil
+	artifact.Locked = artifactCommand.Locked
+	artifact.EquippedBy = entity.CharacterKey(artifactCommand.Location)
+	return artifact, nil
 }
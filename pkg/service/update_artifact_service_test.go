@@ -2,24 +2,41 @@ package service
 
 import (
 	"errors"
+	"fmt"
+	"slices"
+	"sync"
 	"testing"
 
+	"github.com/YutoOkawa/genshin-artifact-db/pkg/entity"
 	"github.com/YutoOkawa/genshin-artifact-db/pkg/repository"
+
+	"github.com/google/go-cmp/cmp"
 )
 
 func TestUpdateArtifactServiceCreateArtifact(t *testing.T) {
+	primaryValue := 717.0
+	typoPrimaryValue := 71.7
 	testArtifactCommand := CreateArtifactCommand{
 		ArtifactSet: "Gladiator",
 		Type:        "FLOWER",
+		Rarity:      5,
 		Level:       0,
-		PrimaryStat: StatCommand{
-			Type:  "ATK_PERCENT",
-			Value: 0,
+		PrimaryStat: PrimaryStatCommand{
+			Type:  "HP_FLAT",
+			Value: &primaryValue,
 		},
 		Substats: []StatCommand{
+			{
+				Type:  "CRIT_RATE",
+				Value: 3.9,
+			},
+			{
+				Type:  "CRIT_DMG",
+				Value: 7.8,
+			},
 			{
 				Type:  "ATK_PERCENT",
-				Value: 0,
+				Value: 5.8,
 			},
 		},
 	}
@@ -34,14 +51,48 @@ func TestUpdateArtifactServiceCreateArtifact(t *testing.T) {
 		artifactCommand CreateArtifactCommand
 
 		// THEN
-		expectedError bool
+		expectedPrimaryStatValue float64
+		expectedError            bool
 	}{
 		{
 			name: "ShouldCreateArtifactSuccessfully",
 
 			artifactCommand: testArtifactCommand,
 
-			expectedError: false,
+			expectedPrimaryStatValue: 717,
+			expectedError:            false,
+		},
+		{
+			name: "ShouldDerivePrimaryStatValueWhenOmitted",
+
+			artifactCommand: CreateArtifactCommand{
+				ArtifactSet: "Gladiator",
+				Type:        "FLOWER",
+				Rarity:      5,
+				Level:       4,
+				PrimaryStat: PrimaryStatCommand{Type: "HP_FLAT"},
+				Substats: append(slices.Clone(testArtifactCommand.Substats), StatCommand{
+					Type:  "ENERGY_RECHARGE",
+					Value: 6.5,
+				}),
+			},
+
+			expectedPrimaryStatValue: 1530,
+			expectedError:            false,
+		},
+		{
+			name: "ShouldReturnErrorWhenPrimaryStatValueMismatch",
+
+			artifactCommand: CreateArtifactCommand{
+				ArtifactSet: "Gladiator",
+				Type:        "FLOWER",
+				Rarity:      5,
+				Level:       0,
+				PrimaryStat: PrimaryStatCommand{Type: "HP_FLAT", Value: &typoPrimaryValue},
+				Substats:    testArtifactCommand.Substats,
+			},
+
+			expectedError: true,
 		},
 		{
 			name: "ShouldReturnErrorWhenArtifactSaverFails",
@@ -54,18 +105,746 @@ func TestUpdateArtifactServiceCreateArtifact(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockArtifactSaver := &repository.MockArtifactSaver{
-				SaveArtifactError: tt.mockArtifactSaverError,
+			mockArtifactBatchSaver := &repository.MockArtifactBatchSaver{
+				SaveArtifactsError: tt.mockArtifactSaverError,
 			}
 
 			service := UpdateArtifactService{
-				artifactSaver: mockArtifactSaver,
+				artifactGetter: &repository.MockArtifactGetter{
+					QueryArtifactsResponse: &repository.ArtifactQueryResult{},
+				},
+				artifactBatchSaver: mockArtifactBatchSaver,
 			}
 
-			err := service.CreateArtifact(tt.artifactCommand)
+			result, err := service.CreateArtifact(tt.artifactCommand)
 			if (err != nil) != tt.expectedError {
 				t.Errorf("CreateArtifact() error = %v, expectedError %v", err, tt.expectedError)
 			}
+
+			if tt.expectedError {
+				if result != nil {
+					t.Errorf("CreateArtifact() expected nil result, got %v", result)
+				}
+				return
+			}
+			if result == nil || result.ID == "" {
+				t.Errorf("CreateArtifact() expected result with generated ID, got %v", result)
+				return
+			}
+			if result.PrimaryStat.Value != tt.expectedPrimaryStatValue {
+				t.Errorf("CreateArtifact() primary stat value = %v, expected %v", result.PrimaryStat.Value, tt.expectedPrimaryStatValue)
+			}
+		})
+	}
+}
+
+func TestUpdateArtifactServiceCreateArtifactWithClientIDAndDuplicates(t *testing.T) {
+	existingCommand := CreateArtifactCommand{
+		ID:          "existing-id",
+		ArtifactSet: "GladiatorsFinale",
+		Type:        "FLOWER",
+		Rarity:      5,
+		Level:       0,
+		PrimaryStat: PrimaryStatCommand{Type: "HP_FLAT"},
+		Substats: []StatCommand{
+			{Type: "CRIT_RATE", Value: 3.9},
+			{Type: "CRIT_DMG", Value: 7.8},
+			{Type: "ATK_PERCENT", Value: 5.8},
+		},
+	}
+	withCommand := func(modify func(command *CreateArtifactCommand)) CreateArtifactCommand {
+		command := existingCommand
+		command.Substats = slices.Clone(existingCommand.Substats)
+		modify(&command)
+		return command
+	}
+
+	tests := []struct {
+		name string
+
+		// WHEN
+		artifactCommand CreateArtifactCommand
+
+		// THEN
+		expectedID          string
+		expectedDuplicateOf []string
+		expectedErrorCode   string
+	}{
+		{
+			name: "ShouldUseClientSuppliedID",
+
+			artifactCommand: withCommand(func(command *CreateArtifactCommand) {
+				command.ID = "scanner-001"
+				command.Level = 4
+				command.Substats = append(command.Substats, StatCommand{Type: "ENERGY_RECHARGE", Value: 6.5})
+			}),
+
+			expectedID: "scanner-001",
+		},
+		{
+			name: "ShouldReturnConflictWhenClientSuppliedIDAlreadyExists",
+
+			artifactCommand: withCommand(func(command *CreateArtifactCommand) {
+				command.DuplicatePolicy = DUPLICATE_POLICY_ALLOW
+			}),
+
+			expectedErrorCode: "artifact_already_exists",
+		},
+		{
+			name: "ShouldReturnErrorWhenClientSuppliedIDIsInvalid",
+
+			artifactCommand: withCommand(func(command *CreateArtifactCommand) {
+				command.ID = "../etc/passwd"
+			}),
+
+			expectedErrorCode: "invalid_artifact_id",
+		},
+		{
+			name: "ShouldWarnWhenSameContentExists",
+
+			artifactCommand: withCommand(func(command *CreateArtifactCommand) {
+				command.ID = "scanner-002"
+				command.Substats[0], command.Substats[1] = command.Substats[1], command.Substats[0]
+			}),
+
+			expectedID:          "scanner-002",
+			expectedDuplicateOf: []string{"existing-id"},
+		},
+		{
+			name: "ShouldRejectWhenSameContentExists",
+
+			artifactCommand: withCommand(func(command *CreateArtifactCommand) {
+				command.ID = ""
+				command.DuplicatePolicy = DUPLICATE_POLICY_REJECT
+			}),
+
+			expectedErrorCode: "duplicate_artifact",
+		},
+		{
+			name: "ShouldReturnErrorWhenDuplicatePolicyIsUnknown",
+
+			artifactCommand: withCommand(func(command *CreateArtifactCommand) {
+				command.ID = ""
+				command.DuplicatePolicy = "ignore"
+			}),
+
+			expectedErrorCode: "invalid_duplicate_policy",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			artifactRepository := repository.NewInMemoryArtifactRepository()
+			service := NewUpdateArtifactService(artifactRepository, artifactRepository, artifactRepository, artifactRepository, artifactRepository)
+			if _, err := service.CreateArtifact(existingCommand); err != nil {
+				t.Fatalf("failed to create existing artifact: %v", err)
+			}
+
+			result, err := service.CreateArtifact(tt.artifactCommand)
+			if tt.expectedErrorCode != "" {
+				var serviceError *Error
+				if !errors.As(err, &serviceError) || serviceError.Code != tt.expectedErrorCode {
+					t.Fatalf("expected error code %s, got %v", tt.expectedErrorCode, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result.ID != tt.expectedID {
+				t.Errorf("expected ID: %s, got: %s", tt.expectedID, result.ID)
+			}
+			if diff := cmp.Diff(tt.expectedDuplicateOf, result.DuplicateOf); diff != "" {
+				t.Errorf("duplicate_of mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdateArtifactServiceEquipmentSlots(t *testing.T) {
+	flowerCommand := CreateArtifactCommand{
+		ID:              "diluc-flower",
+		DuplicatePolicy: DUPLICATE_POLICY_ALLOW,
+		ArtifactSet:     "GladiatorsFinale",
+		Type:            "FLOWER",
+		Rarity:          5,
+		Level:           0,
+		PrimaryStat:     PrimaryStatCommand{Type: "HP_FLAT"},
+		Substats: []StatCommand{
+			{Type: "CRIT_RATE", Value: 3.9},
+			{Type: "CRIT_DMG", Value: 7.8},
+			{Type: "ATK_PERCENT", Value: 5.8},
+		},
+		Location: "Diluc",
+	}
+	withCommand := func(modify func(command *CreateArtifactCommand)) CreateArtifactCommand {
+		command := flowerCommand
+		modify(&command)
+		return command
+	}
+	locked := true
+	diluc := "Diluc"
+
+	tests := []struct {
+		name string
+
+		// WHEN
+		call func(s *UpdateArtifactService) (*ArtifactDTO, error)
+
+		// THEN
+		expectedLocation  string
+		expectedErrorCode string
+	}{
+		{
+			name: "ShouldCreateArtifactEquippedToFreeSlot",
+
+			call: func(s *UpdateArtifactService) (*ArtifactDTO, error) {
+				return s.CreateArtifact(withCommand(func(command *CreateArtifactCommand) {
+					command.ID = "diluc-plume"
+					command.Type = "PLUME"
+					command.PrimaryStat.Type = "ATK_FLAT"
+				}))
+			},
+
+			expectedLocation: "Diluc",
+		},
+		{
+			name: "ShouldReturnConflictWhenCreatingArtifactForOccupiedSlot",
+
+			call: func(s *UpdateArtifactService) (*ArtifactDTO, error) {
+				return s.CreateArtifact(withCommand(func(command *CreateArtifactCommand) {
+					command.ID = "another-flower"
+				}))
+			},
+
+			expectedErrorCode: "equipment_slot_occupied",
+		},
+		{
+			name: "ShouldReturnErrorWhenCharacterIsNotInCatalog",
+
+			call: func(s *UpdateArtifactService) (*ArtifactDTO, error) {
+				return s.CreateArtifact(withCommand(func(command *CreateArtifactCommand) {
+					command.ID = "unknown-flower"
+					command.Location = "Raiden Shogun"
+				}))
+			},
+
+			expectedErrorCode: "invalid_character",
+		},
+		{
+			name: "ShouldUpdateEquippedArtifactInPlace",
+
+			call: func(s *UpdateArtifactService) (*ArtifactDTO, error) {
+				return s.UpdateArtifact("diluc-flower", withCommand(func(command *CreateArtifactCommand) {
+					command.Locked = true
+				}))
+			},
+
+			expectedLocation: "Diluc",
+		},
+		{
+			name: "ShouldPatchLegacyArtifactWithoutChangingUnknownCharacter",
+
+			call: func(s *UpdateArtifactService) (*ArtifactDTO, error) {
+				return s.PatchArtifact("legacy-flower", PatchArtifactCommand{Locked: &locked})
+			},
+
+			expectedLocation: "Traveler",
+		},
+		{
+			name: "ShouldReturnConflictWhenPatchMovesArtifactToOccupiedSlot",
+
+			call: func(s *UpdateArtifactService) (*ArtifactDTO, error) {
+				return s.PatchArtifact("legacy-flower", PatchArtifactCommand{Location: &diluc})
+			},
+
+			expectedErrorCode: "equipment_slot_occupied",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			artifactRepository := repository.NewInMemoryArtifactRepository()
+			service := NewUpdateArtifactService(artifactRepository, artifactRepository, artifactRepository, artifactRepository, artifactRepository)
+			if _, err := service.CreateArtifact(flowerCommand); err != nil {
+				t.Fatalf("failed to create equipped artifact: %v", err)
+			}
+			legacy, err := newArtifactFromCommand("legacy-flower", flowerCommand)
+			if err != nil {
+				t.Fatalf("failed to build legacy artifact: %v", err)
+			}
+			legacy.EquippedBy = "Traveler"
+			if err := artifactRepository.SaveArtifact(legacy); err != nil {
+				t.Fatalf("failed to save legacy artifact: %v", err)
+			}
+
+			// WHEN
+			result, err := tt.call(service)
+
+			// THEN
+			if tt.expectedErrorCode != "" {
+				var serviceError *Error
+				if !errors.As(err, &serviceError) || serviceError.Code != tt.expectedErrorCode {
+					t.Fatalf("expected error code %s, got %v", tt.expectedErrorCode, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Location != tt.expectedLocation {
+				t.Errorf("expected location %s, got %s", tt.expectedLocation, result.Location)
+			}
+		})
+	}
+}
+
+func TestUpdateArtifactServiceUpdateArtifact(t *testing.T) {
+	maxLevelSubstats := []entity.Substat{
+		{Type: entity.SUBSTAT_CRIT_RATE, Value: 10.5},
+		{Type: entity.SUBSTAT_CRIT_DMG, Value: 21.0},
+		{Type: entity.SUBSTAT_HP_FLAT, Value: 269},
+		{Type: entity.SUBSTAT_ENERGY_RECHARGE, Value: 11.0},
+	}
+	primaryValue := 46.6
+	testArtifactCommand := CreateArtifactCommand{
+		ArtifactSet: "Gladiator",
+		Type:        "SANDS",
+		Rarity:      5,
+		Level:       20,
+		PrimaryStat: PrimaryStatCommand{
+			Type:  "ATK_PERCENT",
+			Value: &primaryValue,
+		},
+		Substats: []StatCommand{
+			{
+				Type:  "CRIT_RATE",
+				Value: 10.5,
+			},
+			{
+				Type:  "CRIT_DMG",
+				Value: 21.0,
+			},
+			{
+				Type:  "HP_FLAT",
+				Value: 269,
+			},
+			{
+				Type:  "ENERGY_RECHARGE",
+				Value: 11.0,
+			},
+		},
+	}
+
+	tests := []struct {
+		name string
+
+		// GIVEN
+		mockArtifactUpdaterError error
+
+		// WHEN
+		artifactCommand CreateArtifactCommand
+
+		// THEN
+		expectedArtifact *entity.Artifact
+		expectedError    error
+	}{
+		{
+			name: "ShouldUpdateArtifactSuccessfully",
+
+			artifactCommand: testArtifactCommand,
+
+			expectedArtifact: &entity.Artifact{
+				ID:          "test-id",
+				ArtifactSet: entity.ARTIFACT_SET_GLADIATORS_FINALOFFERING,
+				Type:        entity.ARTIFACT_TYPE_SANDS,
+				Rarity:      5,
+				Level:       20,
+				PrimaryStat: entity.PrimaryStat{Type: entity.ATK_PERCENT, Value: 46.6},
+				Substats:    maxLevelSubstats,
+			},
+			expectedError: nil,
+		},
+		{
+			name: "ShouldReturnErrorWhenArtifactSetIsInvalid",
+
+			artifactCommand: func() CreateArtifactCommand {
+				command := testArtifactCommand
+				command.ArtifactSet = "INVALID_SET"
+				return command
+			}(),
+
+			expectedError: entity.ErrInvalidArtifactSet,
+		},
+		{
+			name: "ShouldReturnErrorWhenArtifactUpdaterFails",
+
+			mockArtifactUpdaterError: repository.ErrArtifactNotFound,
+
+			artifactCommand: testArtifactCommand,
+
+			expectedArtifact: &entity.Artifact{
+				ID:          "test-id",
+				ArtifactSet: entity.ARTIFACT_SET_GLADIATORS_FINALOFFERING,
+				Type:        entity.ARTIFACT_TYPE_SANDS,
+				Rarity:      5,
+				Level:       20,
+				PrimaryStat: entity.PrimaryStat{Type: entity.ATK_PERCENT, Value: 46.6},
+				Substats:    maxLevelSubstats,
+			},
+			expectedError: repository.ErrArtifactNotFound,
+		},
+		{
+			name: "ShouldReturnErrorWhenBodyIDDoesNotMatchPath",
+
+			artifactCommand: func() CreateArtifactCommand {
+				command := testArtifactCommand
+				command.ID = "other-id"
+				return command
+			}(),
+
+			expectedError: ErrArtifactIDMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockArtifactUpdater := &repository.MockArtifactUpdater{
+				UpdateArtifactError: tt.mockArtifactUpdaterError,
+			}
+
+			service := UpdateArtifactService{
+				artifactUpdater: mockArtifactUpdater,
+			}
+
+			result, err := service.UpdateArtifact("test-id", tt.artifactCommand)
+			if !errors.Is(err, tt.expectedError) {
+				t.Errorf("UpdateArtifact() error = %v, expectedError %v", err, tt.expectedError)
+			}
+
+			var expectedResult *ArtifactDTO
+			if tt.expectedError == nil {
+				expectedResult = newArtifactDTO(tt.expectedArtifact)
+			}
+			if diff := cmp.Diff(expectedResult, result); diff != "" {
+				t.Errorf("UpdateArtifact() result mismatch (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tt.expectedArtifact, mockArtifactUpdater.UpdatedArtifact); diff != "" {
+				t.Errorf("UpdateArtifact() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdateArtifactServicePatchArtifact(t *testing.T) {
+	initialSubstats := []entity.Substat{
+		{Type: entity.SUBSTAT_CRIT_RATE, Value: 3.9},
+		{Type: entity.SUBSTAT_CRIT_DMG, Value: 7.8},
+		{Type: entity.SUBSTAT_HP_FLAT, Value: 299},
+	}
+	currentArtifact := &entity.Artifact{
+		ID:          "test-id",
+		ArtifactSet: entity.ARTIFACT_SET_GLADIATORS_FINALOFFERING,
+		Type:        entity.ARTIFACT_TYPE_SANDS,
+		Rarity:      5,
+		Level:       0,
+		PrimaryStat: entity.PrimaryStat{Type: entity.ATK_PERCENT, Value: 7.0},
+		Substats:    initialSubstats,
+	}
+
+	artifactSet := "NoblesseOblige"
+	level := 4
+	primaryValue := 14.9
+	invalidType := "INVALID_TYPE"
+	unequipped := ""
+	equippedArtifact := *currentArtifact
+	equippedArtifact.EquippedBy = "Diluc"
+
+	tests := []struct {
+		name string
+
+		// GIVEN
+		mockModifyArtifactCurrent *entity.Artifact
+		mockModifyArtifactError   error
+
+		// WHEN
+		patchCommand PatchArtifactCommand
+
+		// THEN
+		expectedArtifact *entity.Artifact
+		expectedError    error
+	}{
+		{
+			name: "ShouldPatchOnlySpecifiedFields",
+
+			mockModifyArtifactCurrent: currentArtifact,
+
+			patchCommand: PatchArtifactCommand{
+				ArtifactSet: &artifactSet,
+			},
+
+			expectedArtifact: &entity.Artifact{
+				ID:          "test-id",
+				ArtifactSet: entity.ARTIFACT_SET_NOBLESSE_OBLIGE,
+				Type:        entity.ARTIFACT_TYPE_SANDS,
+				Rarity:      5,
+				Level:       0,
+				PrimaryStat: entity.PrimaryStat{Type: entity.ATK_PERCENT, Value: 7.0},
+				Substats:    initialSubstats,
+			},
+			expectedError: nil,
+		},
+		{
+			name: "ShouldUnequipArtifactWhenLocationIsEmpty",
+
+			mockModifyArtifactCurrent: &equippedArtifact,
+
+			patchCommand: PatchArtifactCommand{
+				Location: &unequipped,
+			},
+
+			expectedArtifact: currentArtifact,
+			expectedError:    nil,
+		},
+		{
+			name: "ShouldMergePrimaryStatAndReplaceSubstatsWhenSpecified",
+
+			mockModifyArtifactCurrent: currentArtifact,
+
+			patchCommand: PatchArtifactCommand{
+				Level: &level,
+				PrimaryStat: &PatchStatCommand{
+					Value: &primaryValue,
+				},
+				Substats: &[]StatCommand{
+					{Type: "CRIT_RATE", Value: 3.9},
+					{Type: "CRIT_DMG", Value: 7.8},
+					{Type: "HP_FLAT", Value: 299},
+					{Type: "ENERGY_RECHARGE", Value: 6.5},
+				},
+			},
+
+			expectedArtifact: &entity.Artifact{
+				ID:          "test-id",
+				ArtifactSet: entity.ARTIFACT_SET_GLADIATORS_FINALOFFERING,
+				Type:        entity.ARTIFACT_TYPE_SANDS,
+				Rarity:      5,
+				Level:       4,
+				PrimaryStat: entity.PrimaryStat{Type: entity.ATK_PERCENT, Value: 14.9},
+				Substats: []entity.Substat{
+					{Type: entity.SUBSTAT_CRIT_RATE, Value: 3.9},
+					{Type: entity.SUBSTAT_CRIT_DMG, Value: 7.8},
+					{Type: entity.SUBSTAT_HP_FLAT, Value: 299},
+					{Type: entity.SUBSTAT_ENERGY_RECHARGE, Value: 6.5},
+				},
+			},
+			expectedError: nil,
+		},
+		{
+			name: "ShouldRecalculatePrimaryStatValueWhenLevelChangesWithoutValue",
+
+			mockModifyArtifactCurrent: currentArtifact,
+
+			patchCommand: PatchArtifactCommand{
+				Level: &level,
+				Substats: &[]StatCommand{
+					{Type: "CRIT_RATE", Value: 3.9},
+					{Type: "CRIT_DMG", Value: 7.8},
+					{Type: "HP_FLAT", Value: 299},
+					{Type: "ENERGY_RECHARGE", Value: 6.5},
+				},
+			},
+
+			expectedArtifact: &entity.Artifact{
+				ID:          "test-id",
+				ArtifactSet: entity.ARTIFACT_SET_GLADIATORS_FINALOFFERING,
+				Type:        entity.ARTIFACT_TYPE_SANDS,
+				Rarity:      5,
+				Level:       4,
+				PrimaryStat: entity.PrimaryStat{Type: entity.ATK_PERCENT, Value: 14.9},
+				Substats: []entity.Substat{
+					{Type: entity.SUBSTAT_CRIT_RATE, Value: 3.9},
+					{Type: entity.SUBSTAT_CRIT_DMG, Value: 7.8},
+					{Type: entity.SUBSTAT_HP_FLAT, Value: 299},
+					{Type: entity.SUBSTAT_ENERGY_RECHARGE, Value: 6.5},
+				},
+			},
+			expectedError: nil,
+		},
+		{
+			name: "ShouldReturnErrorWhenPatchedArtifactIsInvalid",
+
+			mockModifyArtifactCurrent: currentArtifact,
+
+			patchCommand: PatchArtifactCommand{
+				Type: &invalidType,
+			},
+
+			expectedError: entity.ErrInvalidArtifactType,
+		},
+		{
+			name: "ShouldReturnErrorWhenPatchedLevelIsInconsistentWithSubstats",
+
+			mockModifyArtifactCurrent: currentArtifact,
+
+			patchCommand: PatchArtifactCommand{
+				Level: &level,
+			},
+
+			expectedError: entity.ErrSubstatCountMismatchRarity,
+		},
+		{
+			name: "ShouldReturnErrorWhenArtifactNotFound",
+
+			mockModifyArtifactError: repository.ErrArtifactNotFound,
+
+			patchCommand: PatchArtifactCommand{
+				Level: &level,
+			},
+
+			expectedError: repository.ErrArtifactNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockArtifactModifier := &repository.MockArtifactModifier{
+				ModifyArtifactCurrent: tt.mockModifyArtifactCurrent,
+				ModifyArtifactError:   tt.mockModifyArtifactError,
+			}
+
+			service := UpdateArtifactService{
+				artifactModifier: mockArtifactModifier,
+			}
+
+			result, err := service.PatchArtifact("test-id", tt.patchCommand)
+			if !errors.Is(err, tt.expectedError) {
+				t.Errorf("PatchArtifact() error = %v, expectedError %v", err, tt.expectedError)
+			}
+
+			var expectedResult *ArtifactDTO
+			if tt.expectedError == nil {
+				expectedResult = newArtifactDTO(tt.expectedArtifact)
+			}
+			if diff := cmp.Diff(expectedResult, result); diff != "" {
+				t.Errorf("PatchArtifact() result mismatch (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tt.expectedArtifact, mockArtifactModifier.ModifiedArtifact); diff != "" {
+				t.Errorf("PatchArtifact() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdateArtifactServicePatchArtifactConcurrently(t *testing.T) {
+	const artifacts = 20
+
+	// GIVEN
+	artifactRepository := repository.NewInMemoryArtifactRepository()
+	service := NewUpdateArtifactService(artifactRepository, artifactRepository, artifactRepository, artifactRepository, artifactRepository)
+	for i := range artifacts {
+		_, err := service.CreateArtifact(CreateArtifactCommand{
+			ID:              fmt.Sprintf("flower-%d", i),
+			DuplicatePolicy: DUPLICATE_POLICY_ALLOW,
+			ArtifactSet:     "GladiatorsFinale",
+			Type:            "FLOWER",
+			Rarity:          5,
+			PrimaryStat:     PrimaryStatCommand{Type: "HP_FLAT"},
+			Substats: []StatCommand{
+				{Type: "CRIT_RATE", Value: 3.9},
+				{Type: "CRIT_DMG", Value: 7.8},
+				{Type: "ATK_PERCENT", Value: 5.8},
+			},
+		})
+		if err != nil {
+			t.Fatalf("failed to create artifact: %v", err)
+		}
+	}
+	artifactSet := "NoblesseOblige"
+	locked := true
+	patches := []PatchArtifactCommand{
+		{ArtifactSet: &artifactSet},
+		{Locked: &locked},
+		{Substats: &[]StatCommand{
+			{Type: "CRIT_RATE", Value: 3.9},
+			{Type: "CRIT_DMG", Value: 7.8},
+			{Type: "ATK_PERCENT", Value: 5.8},
+			{Type: "ENERGY_RECHARGE", Value: 6.5},
+		}},
+	}
+
+	// WHEN
+	// 同じ聖遺物の別のフィールドを同時に変更する
+	var wg sync.WaitGroup
+	for i := range artifacts {
+		for _, patchCommand := range patches {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := service.PatchArtifact(fmt.Sprintf("flower-%d", i), patchCommand); err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			}()
+		}
+	}
+	wg.Wait()
+
+	// THEN
+	// どの変更も他の変更に取り消されていないこと
+	for i := range artifacts {
+		artifact, err := artifactRepository.GetArtifactByID(fmt.Sprintf("flower-%d", i))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got := []any{artifact.ArtifactSet, artifact.Locked, len(artifact.Substats)}
+		if diff := cmp.Diff([]any{entity.ARTIFACT_SET_NOBLESSE_OBLIGE, true, 4}, got); diff != "" {
+			t.Errorf("%s lost a concurrent patch (-want +got):\n%s", artifact.ID, diff)
+		}
+	}
+}
+
+func TestUpdateArtifactServiceDeleteArtifact(t *testing.T) {
+	tests := []struct {
+		name string
+
+		// GIVEN
+		mockArtifactDeleterError error
+
+		// THEN
+		expectedError error
+	}{
+		{
+			name: "ShouldDeleteArtifactSuccessfully",
+
+			expectedError: nil,
+		},
+		{
+			name: "ShouldReturnErrorWhenArtifactDeleterFails",
+
+			mockArtifactDeleterError: repository.ErrArtifactNotFound,
+
+			expectedError: repository.ErrArtifactNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockArtifactDeleter := &repository.MockArtifactDeleter{
+				DeleteArtifactByIDError: tt.mockArtifactDeleterError,
+			}
+
+			service := UpdateArtifactService{
+				artifactDeleter: mockArtifactDeleter,
+			}
+
+			err := service.DeleteArtifact("test-id")
+			if !errors.Is(err, tt.expectedError) {
+				t.Errorf("DeleteArtifact() error = %v, expectedError %v", err, tt.expectedError)
+			}
 		})
 	}
 }